@@ -0,0 +1,367 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: bundestag/v1/service.proto
+
+package bundestagv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/kyzrfranz/bundestag-api/api/gen/bundestag/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// BundestagServiceName is the fully-qualified name of the BundestagService service.
+	BundestagServiceName = "bundestag.v1.BundestagService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// BundestagServiceListPoliticiansProcedure is the fully-qualified name of the BundestagService's
+	// ListPoliticians RPC.
+	BundestagServiceListPoliticiansProcedure = "/bundestag.v1.BundestagService/ListPoliticians"
+	// BundestagServiceGetPoliticianProcedure is the fully-qualified name of the BundestagService's
+	// GetPolitician RPC.
+	BundestagServiceGetPoliticianProcedure = "/bundestag.v1.BundestagService/GetPolitician"
+	// BundestagServiceGetPoliticianBioProcedure is the fully-qualified name of the BundestagService's
+	// GetPoliticianBio RPC.
+	BundestagServiceGetPoliticianBioProcedure = "/bundestag.v1.BundestagService/GetPoliticianBio"
+	// BundestagServiceListCommitteesProcedure is the fully-qualified name of the BundestagService's
+	// ListCommittees RPC.
+	BundestagServiceListCommitteesProcedure = "/bundestag.v1.BundestagService/ListCommittees"
+	// BundestagServiceGetCommitteeProcedure is the fully-qualified name of the BundestagService's
+	// GetCommittee RPC.
+	BundestagServiceGetCommitteeProcedure = "/bundestag.v1.BundestagService/GetCommittee"
+	// BundestagServiceGetCommitteeDetailsProcedure is the fully-qualified name of the
+	// BundestagService's GetCommitteeDetails RPC.
+	BundestagServiceGetCommitteeDetailsProcedure = "/bundestag.v1.BundestagService/GetCommitteeDetails"
+	// BundestagServiceListConstituenciesProcedure is the fully-qualified name of the BundestagService's
+	// ListConstituencies RPC.
+	BundestagServiceListConstituenciesProcedure = "/bundestag.v1.BundestagService/ListConstituencies"
+	// BundestagServiceListConstituencyPoliticiansProcedure is the fully-qualified name of the
+	// BundestagService's ListConstituencyPoliticians RPC.
+	BundestagServiceListConstituencyPoliticiansProcedure = "/bundestag.v1.BundestagService/ListConstituencyPoliticians"
+	// BundestagServiceWatchCatalogChangesProcedure is the fully-qualified name of the
+	// BundestagService's WatchCatalogChanges RPC.
+	BundestagServiceWatchCatalogChangesProcedure = "/bundestag.v1.BundestagService/WatchCatalogChanges"
+)
+
+// BundestagServiceClient is a client for the bundestag.v1.BundestagService service.
+type BundestagServiceClient interface {
+	// ListPoliticians returns the politician catalog, filtered by all given fields.
+	ListPoliticians(context.Context, *connect.Request[v1.ListPoliticiansRequest]) (*connect.Response[v1.ListPoliticiansResponse], error)
+	GetPolitician(context.Context, *connect.Request[v1.GetPoliticianRequest]) (*connect.Response[v1.GetPoliticianResponse], error)
+	GetPoliticianBio(context.Context, *connect.Request[v1.GetPoliticianBioRequest]) (*connect.Response[v1.GetPoliticianBioResponse], error)
+	ListCommittees(context.Context, *connect.Request[v1.ListCommitteesRequest]) (*connect.Response[v1.ListCommitteesResponse], error)
+	GetCommittee(context.Context, *connect.Request[v1.GetCommitteeRequest]) (*connect.Response[v1.GetCommitteeResponse], error)
+	GetCommitteeDetails(context.Context, *connect.Request[v1.GetCommitteeDetailsRequest]) (*connect.Response[v1.GetCommitteeDetailsResponse], error)
+	// ListConstituencies returns the constituencies covering a zipcode.
+	ListConstituencies(context.Context, *connect.Request[v1.ListConstituenciesRequest]) (*connect.Response[v1.ListConstituenciesResponse], error)
+	// ListConstituencyPoliticians returns the MdBs of the constituencies covering a zipcode.
+	ListConstituencyPoliticians(context.Context, *connect.Request[v1.ListConstituencyPoliticiansRequest]) (*connect.Response[v1.ListConstituencyPoliticiansResponse], error)
+	// WatchCatalogChanges streams the changes detected in the upstream catalogs,
+	// like the /events endpoint.
+	WatchCatalogChanges(context.Context, *connect.Request[v1.WatchCatalogChangesRequest]) (*connect.ServerStreamForClient[v1.WatchCatalogChangesResponse], error)
+}
+
+// NewBundestagServiceClient constructs a client for the bundestag.v1.BundestagService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewBundestagServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) BundestagServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	bundestagServiceMethods := v1.File_bundestag_v1_service_proto.Services().ByName("BundestagService").Methods()
+	return &bundestagServiceClient{
+		listPoliticians: connect.NewClient[v1.ListPoliticiansRequest, v1.ListPoliticiansResponse](
+			httpClient,
+			baseURL+BundestagServiceListPoliticiansProcedure,
+			connect.WithSchema(bundestagServiceMethods.ByName("ListPoliticians")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getPolitician: connect.NewClient[v1.GetPoliticianRequest, v1.GetPoliticianResponse](
+			httpClient,
+			baseURL+BundestagServiceGetPoliticianProcedure,
+			connect.WithSchema(bundestagServiceMethods.ByName("GetPolitician")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getPoliticianBio: connect.NewClient[v1.GetPoliticianBioRequest, v1.GetPoliticianBioResponse](
+			httpClient,
+			baseURL+BundestagServiceGetPoliticianBioProcedure,
+			connect.WithSchema(bundestagServiceMethods.ByName("GetPoliticianBio")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		listCommittees: connect.NewClient[v1.ListCommitteesRequest, v1.ListCommitteesResponse](
+			httpClient,
+			baseURL+BundestagServiceListCommitteesProcedure,
+			connect.WithSchema(bundestagServiceMethods.ByName("ListCommittees")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getCommittee: connect.NewClient[v1.GetCommitteeRequest, v1.GetCommitteeResponse](
+			httpClient,
+			baseURL+BundestagServiceGetCommitteeProcedure,
+			connect.WithSchema(bundestagServiceMethods.ByName("GetCommittee")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getCommitteeDetails: connect.NewClient[v1.GetCommitteeDetailsRequest, v1.GetCommitteeDetailsResponse](
+			httpClient,
+			baseURL+BundestagServiceGetCommitteeDetailsProcedure,
+			connect.WithSchema(bundestagServiceMethods.ByName("GetCommitteeDetails")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		listConstituencies: connect.NewClient[v1.ListConstituenciesRequest, v1.ListConstituenciesResponse](
+			httpClient,
+			baseURL+BundestagServiceListConstituenciesProcedure,
+			connect.WithSchema(bundestagServiceMethods.ByName("ListConstituencies")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		listConstituencyPoliticians: connect.NewClient[v1.ListConstituencyPoliticiansRequest, v1.ListConstituencyPoliticiansResponse](
+			httpClient,
+			baseURL+BundestagServiceListConstituencyPoliticiansProcedure,
+			connect.WithSchema(bundestagServiceMethods.ByName("ListConstituencyPoliticians")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		watchCatalogChanges: connect.NewClient[v1.WatchCatalogChangesRequest, v1.WatchCatalogChangesResponse](
+			httpClient,
+			baseURL+BundestagServiceWatchCatalogChangesProcedure,
+			connect.WithSchema(bundestagServiceMethods.ByName("WatchCatalogChanges")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// bundestagServiceClient implements BundestagServiceClient.
+type bundestagServiceClient struct {
+	listPoliticians             *connect.Client[v1.ListPoliticiansRequest, v1.ListPoliticiansResponse]
+	getPolitician               *connect.Client[v1.GetPoliticianRequest, v1.GetPoliticianResponse]
+	getPoliticianBio            *connect.Client[v1.GetPoliticianBioRequest, v1.GetPoliticianBioResponse]
+	listCommittees              *connect.Client[v1.ListCommitteesRequest, v1.ListCommitteesResponse]
+	getCommittee                *connect.Client[v1.GetCommitteeRequest, v1.GetCommitteeResponse]
+	getCommitteeDetails         *connect.Client[v1.GetCommitteeDetailsRequest, v1.GetCommitteeDetailsResponse]
+	listConstituencies          *connect.Client[v1.ListConstituenciesRequest, v1.ListConstituenciesResponse]
+	listConstituencyPoliticians *connect.Client[v1.ListConstituencyPoliticiansRequest, v1.ListConstituencyPoliticiansResponse]
+	watchCatalogChanges         *connect.Client[v1.WatchCatalogChangesRequest, v1.WatchCatalogChangesResponse]
+}
+
+// ListPoliticians calls bundestag.v1.BundestagService.ListPoliticians.
+func (c *bundestagServiceClient) ListPoliticians(ctx context.Context, req *connect.Request[v1.ListPoliticiansRequest]) (*connect.Response[v1.ListPoliticiansResponse], error) {
+	return c.listPoliticians.CallUnary(ctx, req)
+}
+
+// GetPolitician calls bundestag.v1.BundestagService.GetPolitician.
+func (c *bundestagServiceClient) GetPolitician(ctx context.Context, req *connect.Request[v1.GetPoliticianRequest]) (*connect.Response[v1.GetPoliticianResponse], error) {
+	return c.getPolitician.CallUnary(ctx, req)
+}
+
+// GetPoliticianBio calls bundestag.v1.BundestagService.GetPoliticianBio.
+func (c *bundestagServiceClient) GetPoliticianBio(ctx context.Context, req *connect.Request[v1.GetPoliticianBioRequest]) (*connect.Response[v1.GetPoliticianBioResponse], error) {
+	return c.getPoliticianBio.CallUnary(ctx, req)
+}
+
+// ListCommittees calls bundestag.v1.BundestagService.ListCommittees.
+func (c *bundestagServiceClient) ListCommittees(ctx context.Context, req *connect.Request[v1.ListCommitteesRequest]) (*connect.Response[v1.ListCommitteesResponse], error) {
+	return c.listCommittees.CallUnary(ctx, req)
+}
+
+// GetCommittee calls bundestag.v1.BundestagService.GetCommittee.
+func (c *bundestagServiceClient) GetCommittee(ctx context.Context, req *connect.Request[v1.GetCommitteeRequest]) (*connect.Response[v1.GetCommitteeResponse], error) {
+	return c.getCommittee.CallUnary(ctx, req)
+}
+
+// GetCommitteeDetails calls bundestag.v1.BundestagService.GetCommitteeDetails.
+func (c *bundestagServiceClient) GetCommitteeDetails(ctx context.Context, req *connect.Request[v1.GetCommitteeDetailsRequest]) (*connect.Response[v1.GetCommitteeDetailsResponse], error) {
+	return c.getCommitteeDetails.CallUnary(ctx, req)
+}
+
+// ListConstituencies calls bundestag.v1.BundestagService.ListConstituencies.
+func (c *bundestagServiceClient) ListConstituencies(ctx context.Context, req *connect.Request[v1.ListConstituenciesRequest]) (*connect.Response[v1.ListConstituenciesResponse], error) {
+	return c.listConstituencies.CallUnary(ctx, req)
+}
+
+// ListConstituencyPoliticians calls bundestag.v1.BundestagService.ListConstituencyPoliticians.
+func (c *bundestagServiceClient) ListConstituencyPoliticians(ctx context.Context, req *connect.Request[v1.ListConstituencyPoliticiansRequest]) (*connect.Response[v1.ListConstituencyPoliticiansResponse], error) {
+	return c.listConstituencyPoliticians.CallUnary(ctx, req)
+}
+
+// WatchCatalogChanges calls bundestag.v1.BundestagService.WatchCatalogChanges.
+func (c *bundestagServiceClient) WatchCatalogChanges(ctx context.Context, req *connect.Request[v1.WatchCatalogChangesRequest]) (*connect.ServerStreamForClient[v1.WatchCatalogChangesResponse], error) {
+	return c.watchCatalogChanges.CallServerStream(ctx, req)
+}
+
+// BundestagServiceHandler is an implementation of the bundestag.v1.BundestagService service.
+type BundestagServiceHandler interface {
+	// ListPoliticians returns the politician catalog, filtered by all given fields.
+	ListPoliticians(context.Context, *connect.Request[v1.ListPoliticiansRequest]) (*connect.Response[v1.ListPoliticiansResponse], error)
+	GetPolitician(context.Context, *connect.Request[v1.GetPoliticianRequest]) (*connect.Response[v1.GetPoliticianResponse], error)
+	GetPoliticianBio(context.Context, *connect.Request[v1.GetPoliticianBioRequest]) (*connect.Response[v1.GetPoliticianBioResponse], error)
+	ListCommittees(context.Context, *connect.Request[v1.ListCommitteesRequest]) (*connect.Response[v1.ListCommitteesResponse], error)
+	GetCommittee(context.Context, *connect.Request[v1.GetCommitteeRequest]) (*connect.Response[v1.GetCommitteeResponse], error)
+	GetCommitteeDetails(context.Context, *connect.Request[v1.GetCommitteeDetailsRequest]) (*connect.Response[v1.GetCommitteeDetailsResponse], error)
+	// ListConstituencies returns the constituencies covering a zipcode.
+	ListConstituencies(context.Context, *connect.Request[v1.ListConstituenciesRequest]) (*connect.Response[v1.ListConstituenciesResponse], error)
+	// ListConstituencyPoliticians returns the MdBs of the constituencies covering a zipcode.
+	ListConstituencyPoliticians(context.Context, *connect.Request[v1.ListConstituencyPoliticiansRequest]) (*connect.Response[v1.ListConstituencyPoliticiansResponse], error)
+	// WatchCatalogChanges streams the changes detected in the upstream catalogs,
+	// like the /events endpoint.
+	WatchCatalogChanges(context.Context, *connect.Request[v1.WatchCatalogChangesRequest], *connect.ServerStream[v1.WatchCatalogChangesResponse]) error
+}
+
+// NewBundestagServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewBundestagServiceHandler(svc BundestagServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	bundestagServiceMethods := v1.File_bundestag_v1_service_proto.Services().ByName("BundestagService").Methods()
+	bundestagServiceListPoliticiansHandler := connect.NewUnaryHandler(
+		BundestagServiceListPoliticiansProcedure,
+		svc.ListPoliticians,
+		connect.WithSchema(bundestagServiceMethods.ByName("ListPoliticians")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	bundestagServiceGetPoliticianHandler := connect.NewUnaryHandler(
+		BundestagServiceGetPoliticianProcedure,
+		svc.GetPolitician,
+		connect.WithSchema(bundestagServiceMethods.ByName("GetPolitician")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	bundestagServiceGetPoliticianBioHandler := connect.NewUnaryHandler(
+		BundestagServiceGetPoliticianBioProcedure,
+		svc.GetPoliticianBio,
+		connect.WithSchema(bundestagServiceMethods.ByName("GetPoliticianBio")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	bundestagServiceListCommitteesHandler := connect.NewUnaryHandler(
+		BundestagServiceListCommitteesProcedure,
+		svc.ListCommittees,
+		connect.WithSchema(bundestagServiceMethods.ByName("ListCommittees")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	bundestagServiceGetCommitteeHandler := connect.NewUnaryHandler(
+		BundestagServiceGetCommitteeProcedure,
+		svc.GetCommittee,
+		connect.WithSchema(bundestagServiceMethods.ByName("GetCommittee")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	bundestagServiceGetCommitteeDetailsHandler := connect.NewUnaryHandler(
+		BundestagServiceGetCommitteeDetailsProcedure,
+		svc.GetCommitteeDetails,
+		connect.WithSchema(bundestagServiceMethods.ByName("GetCommitteeDetails")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	bundestagServiceListConstituenciesHandler := connect.NewUnaryHandler(
+		BundestagServiceListConstituenciesProcedure,
+		svc.ListConstituencies,
+		connect.WithSchema(bundestagServiceMethods.ByName("ListConstituencies")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	bundestagServiceListConstituencyPoliticiansHandler := connect.NewUnaryHandler(
+		BundestagServiceListConstituencyPoliticiansProcedure,
+		svc.ListConstituencyPoliticians,
+		connect.WithSchema(bundestagServiceMethods.ByName("ListConstituencyPoliticians")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	bundestagServiceWatchCatalogChangesHandler := connect.NewServerStreamHandler(
+		BundestagServiceWatchCatalogChangesProcedure,
+		svc.WatchCatalogChanges,
+		connect.WithSchema(bundestagServiceMethods.ByName("WatchCatalogChanges")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/bundestag.v1.BundestagService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case BundestagServiceListPoliticiansProcedure:
+			bundestagServiceListPoliticiansHandler.ServeHTTP(w, r)
+		case BundestagServiceGetPoliticianProcedure:
+			bundestagServiceGetPoliticianHandler.ServeHTTP(w, r)
+		case BundestagServiceGetPoliticianBioProcedure:
+			bundestagServiceGetPoliticianBioHandler.ServeHTTP(w, r)
+		case BundestagServiceListCommitteesProcedure:
+			bundestagServiceListCommitteesHandler.ServeHTTP(w, r)
+		case BundestagServiceGetCommitteeProcedure:
+			bundestagServiceGetCommitteeHandler.ServeHTTP(w, r)
+		case BundestagServiceGetCommitteeDetailsProcedure:
+			bundestagServiceGetCommitteeDetailsHandler.ServeHTTP(w, r)
+		case BundestagServiceListConstituenciesProcedure:
+			bundestagServiceListConstituenciesHandler.ServeHTTP(w, r)
+		case BundestagServiceListConstituencyPoliticiansProcedure:
+			bundestagServiceListConstituencyPoliticiansHandler.ServeHTTP(w, r)
+		case BundestagServiceWatchCatalogChangesProcedure:
+			bundestagServiceWatchCatalogChangesHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedBundestagServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedBundestagServiceHandler struct{}
+
+func (UnimplementedBundestagServiceHandler) ListPoliticians(context.Context, *connect.Request[v1.ListPoliticiansRequest]) (*connect.Response[v1.ListPoliticiansResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bundestag.v1.BundestagService.ListPoliticians is not implemented"))
+}
+
+func (UnimplementedBundestagServiceHandler) GetPolitician(context.Context, *connect.Request[v1.GetPoliticianRequest]) (*connect.Response[v1.GetPoliticianResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bundestag.v1.BundestagService.GetPolitician is not implemented"))
+}
+
+func (UnimplementedBundestagServiceHandler) GetPoliticianBio(context.Context, *connect.Request[v1.GetPoliticianBioRequest]) (*connect.Response[v1.GetPoliticianBioResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bundestag.v1.BundestagService.GetPoliticianBio is not implemented"))
+}
+
+func (UnimplementedBundestagServiceHandler) ListCommittees(context.Context, *connect.Request[v1.ListCommitteesRequest]) (*connect.Response[v1.ListCommitteesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bundestag.v1.BundestagService.ListCommittees is not implemented"))
+}
+
+func (UnimplementedBundestagServiceHandler) GetCommittee(context.Context, *connect.Request[v1.GetCommitteeRequest]) (*connect.Response[v1.GetCommitteeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bundestag.v1.BundestagService.GetCommittee is not implemented"))
+}
+
+func (UnimplementedBundestagServiceHandler) GetCommitteeDetails(context.Context, *connect.Request[v1.GetCommitteeDetailsRequest]) (*connect.Response[v1.GetCommitteeDetailsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bundestag.v1.BundestagService.GetCommitteeDetails is not implemented"))
+}
+
+func (UnimplementedBundestagServiceHandler) ListConstituencies(context.Context, *connect.Request[v1.ListConstituenciesRequest]) (*connect.Response[v1.ListConstituenciesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bundestag.v1.BundestagService.ListConstituencies is not implemented"))
+}
+
+func (UnimplementedBundestagServiceHandler) ListConstituencyPoliticians(context.Context, *connect.Request[v1.ListConstituencyPoliticiansRequest]) (*connect.Response[v1.ListConstituencyPoliticiansResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bundestag.v1.BundestagService.ListConstituencyPoliticians is not implemented"))
+}
+
+func (UnimplementedBundestagServiceHandler) WatchCatalogChanges(context.Context, *connect.Request[v1.WatchCatalogChangesRequest], *connect.ServerStream[v1.WatchCatalogChangesResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("bundestag.v1.BundestagService.WatchCatalogChanges is not implemented"))
+}
@@ -0,0 +1,1362 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: bundestag/v1/service.proto
+
+package bundestagv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListPoliticiansRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Faction string                 `protobuf:"bytes,1,opt,name=faction,proto3" json:"faction,omitempty"`
+	State   string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	// Number of the constituency.
+	Constituency  string `protobuf:"bytes,3,opt,name=constituency,proto3" json:"constituency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPoliticiansRequest) Reset() {
+	*x = ListPoliticiansRequest{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPoliticiansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoliticiansRequest) ProtoMessage() {}
+
+func (x *ListPoliticiansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoliticiansRequest.ProtoReflect.Descriptor instead.
+func (*ListPoliticiansRequest) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListPoliticiansRequest) GetFaction() string {
+	if x != nil {
+		return x.Faction
+	}
+	return ""
+}
+
+func (x *ListPoliticiansRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ListPoliticiansRequest) GetConstituency() string {
+	if x != nil {
+		return x.Constituency
+	}
+	return ""
+}
+
+type ListPoliticiansResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Politicians   []*PersonListEntry     `protobuf:"bytes,1,rep,name=politicians,proto3" json:"politicians,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPoliticiansResponse) Reset() {
+	*x = ListPoliticiansResponse{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPoliticiansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPoliticiansResponse) ProtoMessage() {}
+
+func (x *ListPoliticiansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPoliticiansResponse.ProtoReflect.Descriptor instead.
+func (*ListPoliticiansResponse) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListPoliticiansResponse) GetPoliticians() []*PersonListEntry {
+	if x != nil {
+		return x.Politicians
+	}
+	return nil
+}
+
+type GetPoliticianRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPoliticianRequest) Reset() {
+	*x = GetPoliticianRequest{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPoliticianRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoliticianRequest) ProtoMessage() {}
+
+func (x *GetPoliticianRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoliticianRequest.ProtoReflect.Descriptor instead.
+func (*GetPoliticianRequest) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetPoliticianRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetPoliticianResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Politician    *PersonListEntry       `protobuf:"bytes,1,opt,name=politician,proto3" json:"politician,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPoliticianResponse) Reset() {
+	*x = GetPoliticianResponse{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPoliticianResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoliticianResponse) ProtoMessage() {}
+
+func (x *GetPoliticianResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoliticianResponse.ProtoReflect.Descriptor instead.
+func (*GetPoliticianResponse) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPoliticianResponse) GetPolitician() *PersonListEntry {
+	if x != nil {
+		return x.Politician
+	}
+	return nil
+}
+
+type GetPoliticianBioRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Rendition of the HTML fields: html (sanitized, the default), text or markdown.
+	Format        string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPoliticianBioRequest) Reset() {
+	*x = GetPoliticianBioRequest{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPoliticianBioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoliticianBioRequest) ProtoMessage() {}
+
+func (x *GetPoliticianBioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoliticianBioRequest.ProtoReflect.Descriptor instead.
+func (*GetPoliticianBioRequest) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPoliticianBioRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetPoliticianBioRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type GetPoliticianBioResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Politician    *Politician            `protobuf:"bytes,1,opt,name=politician,proto3" json:"politician,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPoliticianBioResponse) Reset() {
+	*x = GetPoliticianBioResponse{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPoliticianBioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPoliticianBioResponse) ProtoMessage() {}
+
+func (x *GetPoliticianBioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPoliticianBioResponse.ProtoReflect.Descriptor instead.
+func (*GetPoliticianBioResponse) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetPoliticianBioResponse) GetPolitician() *Politician {
+	if x != nil {
+		return x.Politician
+	}
+	return nil
+}
+
+type ListCommitteesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommitteesRequest) Reset() {
+	*x = ListCommitteesRequest{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommitteesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommitteesRequest) ProtoMessage() {}
+
+func (x *ListCommitteesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommitteesRequest.ProtoReflect.Descriptor instead.
+func (*ListCommitteesRequest) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{6}
+}
+
+type ListCommitteesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Committees    []*CommitteeListEntry  `protobuf:"bytes,1,rep,name=committees,proto3" json:"committees,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommitteesResponse) Reset() {
+	*x = ListCommitteesResponse{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommitteesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommitteesResponse) ProtoMessage() {}
+
+func (x *ListCommitteesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommitteesResponse.ProtoReflect.Descriptor instead.
+func (*ListCommitteesResponse) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListCommitteesResponse) GetCommittees() []*CommitteeListEntry {
+	if x != nil {
+		return x.Committees
+	}
+	return nil
+}
+
+type GetCommitteeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommitteeRequest) Reset() {
+	*x = GetCommitteeRequest{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommitteeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommitteeRequest) ProtoMessage() {}
+
+func (x *GetCommitteeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommitteeRequest.ProtoReflect.Descriptor instead.
+func (*GetCommitteeRequest) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetCommitteeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCommitteeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Committee     *CommitteeListEntry    `protobuf:"bytes,1,opt,name=committee,proto3" json:"committee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommitteeResponse) Reset() {
+	*x = GetCommitteeResponse{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommitteeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommitteeResponse) ProtoMessage() {}
+
+func (x *GetCommitteeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommitteeResponse.ProtoReflect.Descriptor instead.
+func (*GetCommitteeResponse) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetCommitteeResponse) GetCommittee() *CommitteeListEntry {
+	if x != nil {
+		return x.Committee
+	}
+	return nil
+}
+
+type GetCommitteeDetailsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Rendition of the HTML fields: html (sanitized, the default), text or markdown.
+	Format        string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommitteeDetailsRequest) Reset() {
+	*x = GetCommitteeDetailsRequest{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommitteeDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommitteeDetailsRequest) ProtoMessage() {}
+
+func (x *GetCommitteeDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommitteeDetailsRequest.ProtoReflect.Descriptor instead.
+func (*GetCommitteeDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetCommitteeDetailsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetCommitteeDetailsRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type GetCommitteeDetailsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Details       *CommitteeDetails      `protobuf:"bytes,1,opt,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommitteeDetailsResponse) Reset() {
+	*x = GetCommitteeDetailsResponse{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommitteeDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommitteeDetailsResponse) ProtoMessage() {}
+
+func (x *GetCommitteeDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommitteeDetailsResponse.ProtoReflect.Descriptor instead.
+func (*GetCommitteeDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetCommitteeDetailsResponse) GetDetails() *CommitteeDetails {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type ListConstituenciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Zipcode       string                 `protobuf:"bytes,1,opt,name=zipcode,proto3" json:"zipcode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConstituenciesRequest) Reset() {
+	*x = ListConstituenciesRequest{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConstituenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConstituenciesRequest) ProtoMessage() {}
+
+func (x *ListConstituenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConstituenciesRequest.ProtoReflect.Descriptor instead.
+func (*ListConstituenciesRequest) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListConstituenciesRequest) GetZipcode() string {
+	if x != nil {
+		return x.Zipcode
+	}
+	return ""
+}
+
+type ListConstituenciesResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Constituencies []*Constituency        `protobuf:"bytes,1,rep,name=constituencies,proto3" json:"constituencies,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListConstituenciesResponse) Reset() {
+	*x = ListConstituenciesResponse{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConstituenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConstituenciesResponse) ProtoMessage() {}
+
+func (x *ListConstituenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConstituenciesResponse.ProtoReflect.Descriptor instead.
+func (*ListConstituenciesResponse) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListConstituenciesResponse) GetConstituencies() []*Constituency {
+	if x != nil {
+		return x.Constituencies
+	}
+	return nil
+}
+
+type ListConstituencyPoliticiansRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Zipcode       string                 `protobuf:"bytes,1,opt,name=zipcode,proto3" json:"zipcode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConstituencyPoliticiansRequest) Reset() {
+	*x = ListConstituencyPoliticiansRequest{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConstituencyPoliticiansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConstituencyPoliticiansRequest) ProtoMessage() {}
+
+func (x *ListConstituencyPoliticiansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConstituencyPoliticiansRequest.ProtoReflect.Descriptor instead.
+func (*ListConstituencyPoliticiansRequest) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListConstituencyPoliticiansRequest) GetZipcode() string {
+	if x != nil {
+		return x.Zipcode
+	}
+	return ""
+}
+
+type ListConstituencyPoliticiansResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Politicians   []*PersonListEntry     `protobuf:"bytes,1,rep,name=politicians,proto3" json:"politicians,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConstituencyPoliticiansResponse) Reset() {
+	*x = ListConstituencyPoliticiansResponse{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConstituencyPoliticiansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConstituencyPoliticiansResponse) ProtoMessage() {}
+
+func (x *ListConstituencyPoliticiansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConstituencyPoliticiansResponse.ProtoReflect.Descriptor instead.
+func (*ListConstituencyPoliticiansResponse) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListConstituencyPoliticiansResponse) GetPoliticians() []*PersonListEntry {
+	if x != nil {
+		return x.Politicians
+	}
+	return nil
+}
+
+type WatchCatalogChangesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Topics to receive (politicians, committees, news), all if empty.
+	Topics []string `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	// ID of the last event received, missed events are replayed. If they
+	// aren't known anymore, e.g. after a restart, a stream.resync event is sent
+	// instead.
+	LastEventId   uint64 `protobuf:"varint,2,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCatalogChangesRequest) Reset() {
+	*x = WatchCatalogChangesRequest{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCatalogChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCatalogChangesRequest) ProtoMessage() {}
+
+func (x *WatchCatalogChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCatalogChangesRequest.ProtoReflect.Descriptor instead.
+func (*WatchCatalogChangesRequest) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WatchCatalogChangesRequest) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *WatchCatalogChangesRequest) GetLastEventId() uint64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+type WatchCatalogChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCatalogChangesResponse) Reset() {
+	*x = WatchCatalogChangesResponse{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCatalogChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCatalogChangesResponse) ProtoMessage() {}
+
+func (x *WatchCatalogChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCatalogChangesResponse.ProtoReflect.Descriptor instead.
+func (*WatchCatalogChangesResponse) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WatchCatalogChangesResponse) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+// Event mirrors the events of /events.
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The upper 32 bits are the epoch of the server process, the lower ones
+	// count its events.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// e.g. politician.faction_changed
+	Type            string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Topic           string                 `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic,omitempty"`
+	Time            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	PoliticianId    string                 `protobuf:"bytes,5,opt,name=politician_id,json=politicianId,proto3" json:"politician_id,omitempty"`
+	Faction         string                 `protobuf:"bytes,6,opt,name=faction,proto3" json:"faction,omitempty"`
+	PreviousFaction string                 `protobuf:"bytes,7,opt,name=previous_faction,json=previousFaction,proto3" json:"previous_faction,omitempty"`
+	CommitteeId     string                 `protobuf:"bytes,8,opt,name=committee_id,json=committeeId,proto3" json:"committee_id,omitempty"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*Event_Change
+	//	*Event_Membership
+	//	*Event_Politician
+	//	*Event_Committee
+	//	*Event_NewsItem
+	Data          isEvent_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Event) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Event) GetPoliticianId() string {
+	if x != nil {
+		return x.PoliticianId
+	}
+	return ""
+}
+
+func (x *Event) GetFaction() string {
+	if x != nil {
+		return x.Faction
+	}
+	return ""
+}
+
+func (x *Event) GetPreviousFaction() string {
+	if x != nil {
+		return x.PreviousFaction
+	}
+	return ""
+}
+
+func (x *Event) GetCommitteeId() string {
+	if x != nil {
+		return x.CommitteeId
+	}
+	return ""
+}
+
+func (x *Event) GetData() isEvent_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Event) GetChange() *Change {
+	if x != nil {
+		if x, ok := x.Data.(*Event_Change); ok {
+			return x.Change
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetMembership() *Membership {
+	if x != nil {
+		if x, ok := x.Data.(*Event_Membership); ok {
+			return x.Membership
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetPolitician() *Politician {
+	if x != nil {
+		if x, ok := x.Data.(*Event_Politician); ok {
+			return x.Politician
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetCommittee() *CommitteeListEntry {
+	if x != nil {
+		if x, ok := x.Data.(*Event_Committee); ok {
+			return x.Committee
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetNewsItem() *NewsItem {
+	if x != nil {
+		if x, ok := x.Data.(*Event_NewsItem); ok {
+			return x.NewsItem
+		}
+	}
+	return nil
+}
+
+type isEvent_Data interface {
+	isEvent_Data()
+}
+
+type Event_Change struct {
+	Change *Change `protobuf:"bytes,9,opt,name=change,proto3,oneof"`
+}
+
+type Event_Membership struct {
+	Membership *Membership `protobuf:"bytes,10,opt,name=membership,proto3,oneof"`
+}
+
+type Event_Politician struct {
+	Politician *Politician `protobuf:"bytes,11,opt,name=politician,proto3,oneof"`
+}
+
+type Event_Committee struct {
+	Committee *CommitteeListEntry `protobuf:"bytes,12,opt,name=committee,proto3,oneof"`
+}
+
+type Event_NewsItem struct {
+	NewsItem *NewsItem `protobuf:"bytes,13,opt,name=news_item,json=newsItem,proto3,oneof"`
+}
+
+func (*Event_Change) isEvent_Data() {}
+
+func (*Event_Membership) isEvent_Data() {}
+
+func (*Event_Politician) isEvent_Data() {}
+
+func (*Event_Committee) isEvent_Data() {}
+
+func (*Event_NewsItem) isEvent_Data() {}
+
+type Change struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Change) Reset() {
+	*x = Change{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Change) ProtoMessage() {}
+
+func (x *Change) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Change.ProtoReflect.Descriptor instead.
+func (*Change) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Change) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Change) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type Membership struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// joined or left
+	Action        string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Membership) Reset() {
+	*x = Membership{}
+	mi := &file_bundestag_v1_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Membership) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Membership) ProtoMessage() {}
+
+func (x *Membership) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Membership.ProtoReflect.Descriptor instead.
+func (*Membership) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Membership) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Membership) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_bundestag_v1_service_proto protoreflect.FileDescriptor
+
+const file_bundestag_v1_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1abundestag/v1/service.proto\x12\fbundestag.v1\x1a\x18bundestag/v1/types.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"l\n" +
+	"\x16ListPoliticiansRequest\x12\x18\n" +
+	"\afaction\x18\x01 \x01(\tR\afaction\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\"\n" +
+	"\fconstituency\x18\x03 \x01(\tR\fconstituency\"Z\n" +
+	"\x17ListPoliticiansResponse\x12?\n" +
+	"\vpoliticians\x18\x01 \x03(\v2\x1d.bundestag.v1.PersonListEntryR\vpoliticians\"&\n" +
+	"\x14GetPoliticianRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"V\n" +
+	"\x15GetPoliticianResponse\x12=\n" +
+	"\n" +
+	"politician\x18\x01 \x01(\v2\x1d.bundestag.v1.PersonListEntryR\n" +
+	"politician\"A\n" +
+	"\x17GetPoliticianBioRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"T\n" +
+	"\x18GetPoliticianBioResponse\x128\n" +
+	"\n" +
+	"politician\x18\x01 \x01(\v2\x18.bundestag.v1.PoliticianR\n" +
+	"politician\"\x17\n" +
+	"\x15ListCommitteesRequest\"Z\n" +
+	"\x16ListCommitteesResponse\x12@\n" +
+	"\n" +
+	"committees\x18\x01 \x03(\v2 .bundestag.v1.CommitteeListEntryR\n" +
+	"committees\"%\n" +
+	"\x13GetCommitteeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"V\n" +
+	"\x14GetCommitteeResponse\x12>\n" +
+	"\tcommittee\x18\x01 \x01(\v2 .bundestag.v1.CommitteeListEntryR\tcommittee\"D\n" +
+	"\x1aGetCommitteeDetailsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"W\n" +
+	"\x1bGetCommitteeDetailsResponse\x128\n" +
+	"\adetails\x18\x01 \x01(\v2\x1e.bundestag.v1.CommitteeDetailsR\adetails\"5\n" +
+	"\x19ListConstituenciesRequest\x12\x18\n" +
+	"\azipcode\x18\x01 \x01(\tR\azipcode\"`\n" +
+	"\x1aListConstituenciesResponse\x12B\n" +
+	"\x0econstituencies\x18\x01 \x03(\v2\x1a.bundestag.v1.ConstituencyR\x0econstituencies\">\n" +
+	"\"ListConstituencyPoliticiansRequest\x12\x18\n" +
+	"\azipcode\x18\x01 \x01(\tR\azipcode\"f\n" +
+	"#ListConstituencyPoliticiansResponse\x12?\n" +
+	"\vpoliticians\x18\x01 \x03(\v2\x1d.bundestag.v1.PersonListEntryR\vpoliticians\"X\n" +
+	"\x1aWatchCatalogChangesRequest\x12\x16\n" +
+	"\x06topics\x18\x01 \x03(\tR\x06topics\x12\"\n" +
+	"\rlast_event_id\x18\x02 \x01(\x04R\vlastEventId\"H\n" +
+	"\x1bWatchCatalogChangesResponse\x12)\n" +
+	"\x05event\x18\x01 \x01(\v2\x13.bundestag.v1.EventR\x05event\"\xa7\x04\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05topic\x18\x03 \x01(\tR\x05topic\x12.\n" +
+	"\x04time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12#\n" +
+	"\rpolitician_id\x18\x05 \x01(\tR\fpoliticianId\x12\x18\n" +
+	"\afaction\x18\x06 \x01(\tR\afaction\x12)\n" +
+	"\x10previous_faction\x18\a \x01(\tR\x0fpreviousFaction\x12!\n" +
+	"\fcommittee_id\x18\b \x01(\tR\vcommitteeId\x12.\n" +
+	"\x06change\x18\t \x01(\v2\x14.bundestag.v1.ChangeH\x00R\x06change\x12:\n" +
+	"\n" +
+	"membership\x18\n" +
+	" \x01(\v2\x18.bundestag.v1.MembershipH\x00R\n" +
+	"membership\x12:\n" +
+	"\n" +
+	"politician\x18\v \x01(\v2\x18.bundestag.v1.PoliticianH\x00R\n" +
+	"politician\x12@\n" +
+	"\tcommittee\x18\f \x01(\v2 .bundestag.v1.CommitteeListEntryH\x00R\tcommittee\x125\n" +
+	"\tnews_item\x18\r \x01(\v2\x16.bundestag.v1.NewsItemH\x00R\bnewsItemB\x06\n" +
+	"\x04data\",\n" +
+	"\x06Change\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"8\n" +
+	"\n" +
+	"Membership\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name2\xd3\a\n" +
+	"\x10BundestagService\x12c\n" +
+	"\x0fListPoliticians\x12$.bundestag.v1.ListPoliticiansRequest\x1a%.bundestag.v1.ListPoliticiansResponse\"\x03\x90\x02\x01\x12]\n" +
+	"\rGetPolitician\x12\".bundestag.v1.GetPoliticianRequest\x1a#.bundestag.v1.GetPoliticianResponse\"\x03\x90\x02\x01\x12f\n" +
+	"\x10GetPoliticianBio\x12%.bundestag.v1.GetPoliticianBioRequest\x1a&.bundestag.v1.GetPoliticianBioResponse\"\x03\x90\x02\x01\x12`\n" +
+	"\x0eListCommittees\x12#.bundestag.v1.ListCommitteesRequest\x1a$.bundestag.v1.ListCommitteesResponse\"\x03\x90\x02\x01\x12Z\n" +
+	"\fGetCommittee\x12!.bundestag.v1.GetCommitteeRequest\x1a\".bundestag.v1.GetCommitteeResponse\"\x03\x90\x02\x01\x12o\n" +
+	"\x13GetCommitteeDetails\x12(.bundestag.v1.GetCommitteeDetailsRequest\x1a).bundestag.v1.GetCommitteeDetailsResponse\"\x03\x90\x02\x01\x12l\n" +
+	"\x12ListConstituencies\x12'.bundestag.v1.ListConstituenciesRequest\x1a(.bundestag.v1.ListConstituenciesResponse\"\x03\x90\x02\x01\x12\x87\x01\n" +
+	"\x1bListConstituencyPoliticians\x120.bundestag.v1.ListConstituencyPoliticiansRequest\x1a1.bundestag.v1.ListConstituencyPoliticiansResponse\"\x03\x90\x02\x01\x12l\n" +
+	"\x13WatchCatalogChanges\x12(.bundestag.v1.WatchCatalogChangesRequest\x1a).bundestag.v1.WatchCatalogChangesResponse0\x01BEZCgithub.com/kyzrfranz/bundestag-api/api/gen/bundestag/v1;bundestagv1b\x06proto3"
+
+var (
+	file_bundestag_v1_service_proto_rawDescOnce sync.Once
+	file_bundestag_v1_service_proto_rawDescData []byte
+)
+
+func file_bundestag_v1_service_proto_rawDescGZIP() []byte {
+	file_bundestag_v1_service_proto_rawDescOnce.Do(func() {
+		file_bundestag_v1_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bundestag_v1_service_proto_rawDesc), len(file_bundestag_v1_service_proto_rawDesc)))
+	})
+	return file_bundestag_v1_service_proto_rawDescData
+}
+
+var file_bundestag_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_bundestag_v1_service_proto_goTypes = []any{
+	(*ListPoliticiansRequest)(nil),              // 0: bundestag.v1.ListPoliticiansRequest
+	(*ListPoliticiansResponse)(nil),             // 1: bundestag.v1.ListPoliticiansResponse
+	(*GetPoliticianRequest)(nil),                // 2: bundestag.v1.GetPoliticianRequest
+	(*GetPoliticianResponse)(nil),               // 3: bundestag.v1.GetPoliticianResponse
+	(*GetPoliticianBioRequest)(nil),             // 4: bundestag.v1.GetPoliticianBioRequest
+	(*GetPoliticianBioResponse)(nil),            // 5: bundestag.v1.GetPoliticianBioResponse
+	(*ListCommitteesRequest)(nil),               // 6: bundestag.v1.ListCommitteesRequest
+	(*ListCommitteesResponse)(nil),              // 7: bundestag.v1.ListCommitteesResponse
+	(*GetCommitteeRequest)(nil),                 // 8: bundestag.v1.GetCommitteeRequest
+	(*GetCommitteeResponse)(nil),                // 9: bundestag.v1.GetCommitteeResponse
+	(*GetCommitteeDetailsRequest)(nil),          // 10: bundestag.v1.GetCommitteeDetailsRequest
+	(*GetCommitteeDetailsResponse)(nil),         // 11: bundestag.v1.GetCommitteeDetailsResponse
+	(*ListConstituenciesRequest)(nil),           // 12: bundestag.v1.ListConstituenciesRequest
+	(*ListConstituenciesResponse)(nil),          // 13: bundestag.v1.ListConstituenciesResponse
+	(*ListConstituencyPoliticiansRequest)(nil),  // 14: bundestag.v1.ListConstituencyPoliticiansRequest
+	(*ListConstituencyPoliticiansResponse)(nil), // 15: bundestag.v1.ListConstituencyPoliticiansResponse
+	(*WatchCatalogChangesRequest)(nil),          // 16: bundestag.v1.WatchCatalogChangesRequest
+	(*WatchCatalogChangesResponse)(nil),         // 17: bundestag.v1.WatchCatalogChangesResponse
+	(*Event)(nil),                               // 18: bundestag.v1.Event
+	(*Change)(nil),                              // 19: bundestag.v1.Change
+	(*Membership)(nil),                          // 20: bundestag.v1.Membership
+	(*PersonListEntry)(nil),                     // 21: bundestag.v1.PersonListEntry
+	(*Politician)(nil),                          // 22: bundestag.v1.Politician
+	(*CommitteeListEntry)(nil),                  // 23: bundestag.v1.CommitteeListEntry
+	(*CommitteeDetails)(nil),                    // 24: bundestag.v1.CommitteeDetails
+	(*Constituency)(nil),                        // 25: bundestag.v1.Constituency
+	(*timestamppb.Timestamp)(nil),               // 26: google.protobuf.Timestamp
+	(*NewsItem)(nil),                            // 27: bundestag.v1.NewsItem
+}
+var file_bundestag_v1_service_proto_depIdxs = []int32{
+	21, // 0: bundestag.v1.ListPoliticiansResponse.politicians:type_name -> bundestag.v1.PersonListEntry
+	21, // 1: bundestag.v1.GetPoliticianResponse.politician:type_name -> bundestag.v1.PersonListEntry
+	22, // 2: bundestag.v1.GetPoliticianBioResponse.politician:type_name -> bundestag.v1.Politician
+	23, // 3: bundestag.v1.ListCommitteesResponse.committees:type_name -> bundestag.v1.CommitteeListEntry
+	23, // 4: bundestag.v1.GetCommitteeResponse.committee:type_name -> bundestag.v1.CommitteeListEntry
+	24, // 5: bundestag.v1.GetCommitteeDetailsResponse.details:type_name -> bundestag.v1.CommitteeDetails
+	25, // 6: bundestag.v1.ListConstituenciesResponse.constituencies:type_name -> bundestag.v1.Constituency
+	21, // 7: bundestag.v1.ListConstituencyPoliticiansResponse.politicians:type_name -> bundestag.v1.PersonListEntry
+	18, // 8: bundestag.v1.WatchCatalogChangesResponse.event:type_name -> bundestag.v1.Event
+	26, // 9: bundestag.v1.Event.time:type_name -> google.protobuf.Timestamp
+	19, // 10: bundestag.v1.Event.change:type_name -> bundestag.v1.Change
+	20, // 11: bundestag.v1.Event.membership:type_name -> bundestag.v1.Membership
+	22, // 12: bundestag.v1.Event.politician:type_name -> bundestag.v1.Politician
+	23, // 13: bundestag.v1.Event.committee:type_name -> bundestag.v1.CommitteeListEntry
+	27, // 14: bundestag.v1.Event.news_item:type_name -> bundestag.v1.NewsItem
+	0,  // 15: bundestag.v1.BundestagService.ListPoliticians:input_type -> bundestag.v1.ListPoliticiansRequest
+	2,  // 16: bundestag.v1.BundestagService.GetPolitician:input_type -> bundestag.v1.GetPoliticianRequest
+	4,  // 17: bundestag.v1.BundestagService.GetPoliticianBio:input_type -> bundestag.v1.GetPoliticianBioRequest
+	6,  // 18: bundestag.v1.BundestagService.ListCommittees:input_type -> bundestag.v1.ListCommitteesRequest
+	8,  // 19: bundestag.v1.BundestagService.GetCommittee:input_type -> bundestag.v1.GetCommitteeRequest
+	10, // 20: bundestag.v1.BundestagService.GetCommitteeDetails:input_type -> bundestag.v1.GetCommitteeDetailsRequest
+	12, // 21: bundestag.v1.BundestagService.ListConstituencies:input_type -> bundestag.v1.ListConstituenciesRequest
+	14, // 22: bundestag.v1.BundestagService.ListConstituencyPoliticians:input_type -> bundestag.v1.ListConstituencyPoliticiansRequest
+	16, // 23: bundestag.v1.BundestagService.WatchCatalogChanges:input_type -> bundestag.v1.WatchCatalogChangesRequest
+	1,  // 24: bundestag.v1.BundestagService.ListPoliticians:output_type -> bundestag.v1.ListPoliticiansResponse
+	3,  // 25: bundestag.v1.BundestagService.GetPolitician:output_type -> bundestag.v1.GetPoliticianResponse
+	5,  // 26: bundestag.v1.BundestagService.GetPoliticianBio:output_type -> bundestag.v1.GetPoliticianBioResponse
+	7,  // 27: bundestag.v1.BundestagService.ListCommittees:output_type -> bundestag.v1.ListCommitteesResponse
+	9,  // 28: bundestag.v1.BundestagService.GetCommittee:output_type -> bundestag.v1.GetCommitteeResponse
+	11, // 29: bundestag.v1.BundestagService.GetCommitteeDetails:output_type -> bundestag.v1.GetCommitteeDetailsResponse
+	13, // 30: bundestag.v1.BundestagService.ListConstituencies:output_type -> bundestag.v1.ListConstituenciesResponse
+	15, // 31: bundestag.v1.BundestagService.ListConstituencyPoliticians:output_type -> bundestag.v1.ListConstituencyPoliticiansResponse
+	17, // 32: bundestag.v1.BundestagService.WatchCatalogChanges:output_type -> bundestag.v1.WatchCatalogChangesResponse
+	24, // [24:33] is the sub-list for method output_type
+	15, // [15:24] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_bundestag_v1_service_proto_init() }
+func file_bundestag_v1_service_proto_init() {
+	if File_bundestag_v1_service_proto != nil {
+		return
+	}
+	file_bundestag_v1_types_proto_init()
+	file_bundestag_v1_service_proto_msgTypes[18].OneofWrappers = []any{
+		(*Event_Change)(nil),
+		(*Event_Membership)(nil),
+		(*Event_Politician)(nil),
+		(*Event_Committee)(nil),
+		(*Event_NewsItem)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bundestag_v1_service_proto_rawDesc), len(file_bundestag_v1_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bundestag_v1_service_proto_goTypes,
+		DependencyIndexes: file_bundestag_v1_service_proto_depIdxs,
+		MessageInfos:      file_bundestag_v1_service_proto_msgTypes,
+	}.Build()
+	File_bundestag_v1_service_proto = out.File
+	file_bundestag_v1_service_proto_goTypes = nil
+	file_bundestag_v1_service_proto_depIdxs = nil
+}
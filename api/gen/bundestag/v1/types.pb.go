@@ -0,0 +1,1757 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: bundestag/v1/types.proto
+
+package bundestagv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DocumentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentUrl   string                 `protobuf:"bytes,1,opt,name=document_url,json=documentUrl,proto3" json:"document_url,omitempty"`
+	DocumentStand string                 `protobuf:"bytes,2,opt,name=document_stand,json=documentStand,proto3" json:"document_stand,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentInfo) Reset() {
+	*x = DocumentInfo{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentInfo) ProtoMessage() {}
+
+func (x *DocumentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentInfo.ProtoReflect.Descriptor instead.
+func (*DocumentInfo) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DocumentInfo) GetDocumentUrl() string {
+	if x != nil {
+		return x.DocumentUrl
+	}
+	return ""
+}
+
+func (x *DocumentInfo) GetDocumentStand() string {
+	if x != nil {
+		return x.DocumentStand
+	}
+	return ""
+}
+
+type MdbId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MdbId) Reset() {
+	*x = MdbId{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MdbId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MdbId) ProtoMessage() {}
+
+func (x *MdbId) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MdbId.ProtoReflect.Descriptor instead.
+func (*MdbId) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MdbId) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *MdbId) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type MdbName struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MdbName) Reset() {
+	*x = MdbName{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MdbName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MdbName) ProtoMessage() {}
+
+func (x *MdbName) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MdbName.ProtoReflect.Descriptor instead.
+func (*MdbName) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MdbName) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *MdbName) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type Constituency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Constituency) Reset() {
+	*x = Constituency{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Constituency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Constituency) ProtoMessage() {}
+
+func (x *Constituency) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Constituency.ProtoReflect.Descriptor instead.
+func (*Constituency) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Constituency) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Constituency) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Constituency) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Entry of the politician catalog.
+type PersonListEntry struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Faction              string                 `protobuf:"bytes,1,opt,name=faction,proto3" json:"faction,omitempty"`
+	Id                   *MdbId                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 *MdbName               `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	BioUrl               string                 `protobuf:"bytes,4,opt,name=bio_url,json=bioUrl,proto3" json:"bio_url,omitempty"`
+	InfoXmlUrl           string                 `protobuf:"bytes,5,opt,name=info_xml_url,json=infoXmlUrl,proto3" json:"info_xml_url,omitempty"`
+	InfoXmlUrlMitmischen string                 `protobuf:"bytes,6,opt,name=info_xml_url_mitmischen,json=infoXmlUrlMitmischen,proto3" json:"info_xml_url_mitmischen,omitempty"`
+	State                string                 `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	Constituency         *Constituency          `protobuf:"bytes,8,opt,name=constituency,proto3" json:"constituency,omitempty"`
+	Elected              string                 `protobuf:"bytes,9,opt,name=elected,proto3" json:"elected,omitempty"`
+	PhotoUrl             string                 `protobuf:"bytes,10,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
+	PhotoLargeUrl        string                 `protobuf:"bytes,11,opt,name=photo_large_url,json=photoLargeUrl,proto3" json:"photo_large_url,omitempty"`
+	PhotoLastChanged     string                 `protobuf:"bytes,12,opt,name=photo_last_changed,json=photoLastChanged,proto3" json:"photo_last_changed,omitempty"`
+	PhotoChangedDateTime string                 `protobuf:"bytes,13,opt,name=photo_changed_date_time,json=photoChangedDateTime,proto3" json:"photo_changed_date_time,omitempty"`
+	ImageAltText         string                 `protobuf:"bytes,14,opt,name=image_alt_text,json=imageAltText,proto3" json:"image_alt_text,omitempty"`
+	LastChanged          string                 `protobuf:"bytes,15,opt,name=last_changed,json=lastChanged,proto3" json:"last_changed,omitempty"`
+	ChangedDateTime      string                 `protobuf:"bytes,16,opt,name=changed_date_time,json=changedDateTime,proto3" json:"changed_date_time,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *PersonListEntry) Reset() {
+	*x = PersonListEntry{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PersonListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersonListEntry) ProtoMessage() {}
+
+func (x *PersonListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersonListEntry.ProtoReflect.Descriptor instead.
+func (*PersonListEntry) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PersonListEntry) GetFaction() string {
+	if x != nil {
+		return x.Faction
+	}
+	return ""
+}
+
+func (x *PersonListEntry) GetId() *MdbId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *PersonListEntry) GetName() *MdbName {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+func (x *PersonListEntry) GetBioUrl() string {
+	if x != nil {
+		return x.BioUrl
+	}
+	return ""
+}
+
+func (x *PersonListEntry) GetInfoXmlUrl() string {
+	if x != nil {
+		return x.InfoXmlUrl
+	}
+	return ""
+}
+
+func (x *PersonListEntry) GetInfoXmlUrlMitmischen() string {
+	if x != nil {
+		return x.InfoXmlUrlMitmischen
+	}
+	return ""
+}
+
+func (x *PersonListEntry) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *PersonListEntry) GetConstituency() *Constituency {
+	if x != nil {
+		return x.Constituency
+	}
+	return nil
+}
+
+func (x *PersonListEntry) GetElected() string {
+	if x != nil {
+		return x.Elected
+	}
+	return ""
+}
+
+func (x *PersonListEntry) GetPhotoUrl() string {
+	if x != nil {
+		return x.PhotoUrl
+	}
+	return ""
+}
+
+func (x *PersonListEntry) GetPhotoLargeUrl() string {
+	if x != nil {
+		return x.PhotoLargeUrl
+	}
+	return ""
+}
+
+func (x *PersonListEntry) GetPhotoLastChanged() string {
+	if x != nil {
+		return x.PhotoLastChanged
+	}
+	return ""
+}
+
+func (x *PersonListEntry) GetPhotoChangedDateTime() string {
+	if x != nil {
+		return x.PhotoChangedDateTime
+	}
+	return ""
+}
+
+func (x *PersonListEntry) GetImageAltText() string {
+	if x != nil {
+		return x.ImageAltText
+	}
+	return ""
+}
+
+func (x *PersonListEntry) GetLastChanged() string {
+	if x != nil {
+		return x.LastChanged
+	}
+	return ""
+}
+
+func (x *PersonListEntry) GetChangedDateTime() string {
+	if x != nil {
+		return x.ChangedDateTime
+	}
+	return ""
+}
+
+type Politician struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentInfo  *DocumentInfo          `protobuf:"bytes,1,opt,name=document_info,json=documentInfo,proto3" json:"document_info,omitempty"`
+	Bio           *PoliticianBio         `protobuf:"bytes,2,opt,name=bio,proto3" json:"bio,omitempty"`
+	Media         *Media                 `protobuf:"bytes,3,opt,name=media,proto3" json:"media,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Politician) Reset() {
+	*x = Politician{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Politician) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Politician) ProtoMessage() {}
+
+func (x *Politician) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Politician.ProtoReflect.Descriptor instead.
+func (*Politician) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Politician) GetDocumentInfo() *DocumentInfo {
+	if x != nil {
+		return x.DocumentInfo
+	}
+	return nil
+}
+
+func (x *Politician) GetBio() *PoliticianBio {
+	if x != nil {
+		return x.Bio
+	}
+	return nil
+}
+
+func (x *Politician) GetMedia() *Media {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+type PoliticianBio struct {
+	state                                protoimpl.MessageState `protogen:"open.v1"`
+	Id                                   *MdbId                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ArticleId                            string                 `protobuf:"bytes,2,opt,name=article_id,json=articleId,proto3" json:"article_id,omitempty"`
+	SourceUrl                            string                 `protobuf:"bytes,3,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	ExitDate                             string                 `protobuf:"bytes,4,opt,name=exit_date,json=exitDate,proto3" json:"exit_date,omitempty"`
+	LastName                             string                 `protobuf:"bytes,5,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	FirstName                            string                 `protobuf:"bytes,6,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	NobilityTitle                        string                 `protobuf:"bytes,7,opt,name=nobility_title,json=nobilityTitle,proto3" json:"nobility_title,omitempty"`
+	AcademicTitle                        string                 `protobuf:"bytes,8,opt,name=academic_title,json=academicTitle,proto3" json:"academic_title,omitempty"`
+	LocationSuffix                       string                 `protobuf:"bytes,9,opt,name=location_suffix,json=locationSuffix,proto3" json:"location_suffix,omitempty"`
+	DateOfBirth                          string                 `protobuf:"bytes,10,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	ReligionOrDenomination               string                 `protobuf:"bytes,11,opt,name=religion_or_denomination,json=religionOrDenomination,proto3" json:"religion_or_denomination,omitempty"`
+	EducationOrProfessionalQualification string                 `protobuf:"bytes,12,opt,name=education_or_professional_qualification,json=educationOrProfessionalQualification,proto3" json:"education_or_professional_qualification,omitempty"`
+	HigherEducation                      string                 `protobuf:"bytes,13,opt,name=higher_education,json=higherEducation,proto3" json:"higher_education,omitempty"`
+	Profession                           *Profession            `protobuf:"bytes,14,opt,name=profession,proto3" json:"profession,omitempty"`
+	Gender                               string                 `protobuf:"bytes,15,opt,name=gender,proto3" json:"gender,omitempty"`
+	MaritalStatus                        string                 `protobuf:"bytes,16,opt,name=marital_status,json=maritalStatus,proto3" json:"marital_status,omitempty"`
+	NumberOfKids                         string                 `protobuf:"bytes,17,opt,name=number_of_kids,json=numberOfKids,proto3" json:"number_of_kids,omitempty"`
+	Faction                              string                 `protobuf:"bytes,18,opt,name=faction,proto3" json:"faction,omitempty"`
+	Party                                string                 `protobuf:"bytes,19,opt,name=party,proto3" json:"party,omitempty"`
+	State                                string                 `protobuf:"bytes,20,opt,name=state,proto3" json:"state,omitempty"`
+	Constituency                         *Constituency          `protobuf:"bytes,21,opt,name=constituency,proto3" json:"constituency,omitempty"`
+	Elected                              string                 `protobuf:"bytes,22,opt,name=elected,proto3" json:"elected,omitempty"`
+	BioUrl                               string                 `protobuf:"bytes,23,opt,name=bio_url,json=bioUrl,proto3" json:"bio_url,omitempty"`
+	BiographicInfo                       string                 `protobuf:"bytes,24,opt,name=biographic_info,json=biographicInfo,proto3" json:"biographic_info,omitempty"`
+	Trivia                               string                 `protobuf:"bytes,25,opt,name=trivia,proto3" json:"trivia,omitempty"`
+	Homepage                             string                 `protobuf:"bytes,26,opt,name=homepage,proto3" json:"homepage,omitempty"`
+	OtherWebsites                        *OtherWebsites         `protobuf:"bytes,27,opt,name=other_websites,json=otherWebsites,proto3" json:"other_websites,omitempty"`
+	Phone                                string                 `protobuf:"bytes,28,opt,name=phone,proto3" json:"phone,omitempty"`
+	Memberships                          *Memberships           `protobuf:"bytes,29,opt,name=memberships,proto3" json:"memberships,omitempty"`
+	MandatedPublishableInfo              string                 `protobuf:"bytes,30,opt,name=mandated_publishable_info,json=mandatedPublishableInfo,proto3" json:"mandated_publishable_info,omitempty"`
+	unknownFields                        protoimpl.UnknownFields
+	sizeCache                            protoimpl.SizeCache
+}
+
+func (x *PoliticianBio) Reset() {
+	*x = PoliticianBio{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoliticianBio) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoliticianBio) ProtoMessage() {}
+
+func (x *PoliticianBio) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoliticianBio.ProtoReflect.Descriptor instead.
+func (*PoliticianBio) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PoliticianBio) GetId() *MdbId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *PoliticianBio) GetArticleId() string {
+	if x != nil {
+		return x.ArticleId
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetExitDate() string {
+	if x != nil {
+		return x.ExitDate
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetNobilityTitle() string {
+	if x != nil {
+		return x.NobilityTitle
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetAcademicTitle() string {
+	if x != nil {
+		return x.AcademicTitle
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetLocationSuffix() string {
+	if x != nil {
+		return x.LocationSuffix
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetDateOfBirth() string {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetReligionOrDenomination() string {
+	if x != nil {
+		return x.ReligionOrDenomination
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetEducationOrProfessionalQualification() string {
+	if x != nil {
+		return x.EducationOrProfessionalQualification
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetHigherEducation() string {
+	if x != nil {
+		return x.HigherEducation
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetProfession() *Profession {
+	if x != nil {
+		return x.Profession
+	}
+	return nil
+}
+
+func (x *PoliticianBio) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetMaritalStatus() string {
+	if x != nil {
+		return x.MaritalStatus
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetNumberOfKids() string {
+	if x != nil {
+		return x.NumberOfKids
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetFaction() string {
+	if x != nil {
+		return x.Faction
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetParty() string {
+	if x != nil {
+		return x.Party
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetConstituency() *Constituency {
+	if x != nil {
+		return x.Constituency
+	}
+	return nil
+}
+
+func (x *PoliticianBio) GetElected() string {
+	if x != nil {
+		return x.Elected
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetBioUrl() string {
+	if x != nil {
+		return x.BioUrl
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetBiographicInfo() string {
+	if x != nil {
+		return x.BiographicInfo
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetTrivia() string {
+	if x != nil {
+		return x.Trivia
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetHomepage() string {
+	if x != nil {
+		return x.Homepage
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetOtherWebsites() *OtherWebsites {
+	if x != nil {
+		return x.OtherWebsites
+	}
+	return nil
+}
+
+func (x *PoliticianBio) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *PoliticianBio) GetMemberships() *Memberships {
+	if x != nil {
+		return x.Memberships
+	}
+	return nil
+}
+
+func (x *PoliticianBio) GetMandatedPublishableInfo() string {
+	if x != nil {
+		return x.MandatedPublishableInfo
+	}
+	return ""
+}
+
+type Profession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Profession) Reset() {
+	*x = Profession{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Profession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Profession) ProtoMessage() {}
+
+func (x *Profession) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Profession.ProtoReflect.Descriptor instead.
+func (*Profession) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Profession) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Profession) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type OtherWebsites struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Websites      []*Website             `protobuf:"bytes,1,rep,name=websites,proto3" json:"websites,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OtherWebsites) Reset() {
+	*x = OtherWebsites{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OtherWebsites) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OtherWebsites) ProtoMessage() {}
+
+func (x *OtherWebsites) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OtherWebsites.ProtoReflect.Descriptor instead.
+func (*OtherWebsites) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OtherWebsites) GetWebsites() []*Website {
+	if x != nil {
+		return x.Websites
+	}
+	return nil
+}
+
+type Website struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Website) Reset() {
+	*x = Website{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Website) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Website) ProtoMessage() {}
+
+func (x *Website) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Website.ProtoReflect.Descriptor instead.
+func (*Website) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Website) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Website) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type Memberships struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	LeadCommittees             []*Committee           `protobuf:"bytes,1,rep,name=lead_committees,json=leadCommittees,proto3" json:"lead_committees,omitempty"`
+	RegularMemberCommittees    []*Committee           `protobuf:"bytes,2,rep,name=regular_member_committees,json=regularMemberCommittees,proto3" json:"regular_member_committees,omitempty"`
+	SubstituteMemberCommittees []*Committee           `protobuf:"bytes,3,rep,name=substitute_member_committees,json=substituteMemberCommittees,proto3" json:"substitute_member_committees,omitempty"`
+	ViceChairOtherCommittees   []*Committee           `protobuf:"bytes,4,rep,name=vice_chair_other_committees,json=viceChairOtherCommittees,proto3" json:"vice_chair_other_committees,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *Memberships) Reset() {
+	*x = Memberships{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Memberships) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Memberships) ProtoMessage() {}
+
+func (x *Memberships) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Memberships.ProtoReflect.Descriptor instead.
+func (*Memberships) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Memberships) GetLeadCommittees() []*Committee {
+	if x != nil {
+		return x.LeadCommittees
+	}
+	return nil
+}
+
+func (x *Memberships) GetRegularMemberCommittees() []*Committee {
+	if x != nil {
+		return x.RegularMemberCommittees
+	}
+	return nil
+}
+
+func (x *Memberships) GetSubstituteMemberCommittees() []*Committee {
+	if x != nil {
+		return x.SubstituteMemberCommittees
+	}
+	return nil
+}
+
+func (x *Memberships) GetViceChairOtherCommittees() []*Committee {
+	if x != nil {
+		return x.ViceChairOtherCommittees
+	}
+	return nil
+}
+
+// Committee an MdB is a member of.
+type Committee struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Committee) Reset() {
+	*x = Committee{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Committee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Committee) ProtoMessage() {}
+
+func (x *Committee) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Committee.ProtoReflect.Descriptor instead.
+func (*Committee) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Committee) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Committee) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Committee) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type Media struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Photo         *Foto                  `protobuf:"bytes,1,opt,name=photo,proto3" json:"photo,omitempty"`
+	SpeechesUrl   string                 `protobuf:"bytes,2,opt,name=speeches_url,json=speechesUrl,proto3" json:"speeches_url,omitempty"`
+	SpeechesRss   string                 `protobuf:"bytes,3,opt,name=speeches_rss,json=speechesRss,proto3" json:"speeches_rss,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Media) Reset() {
+	*x = Media{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Media) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Media) ProtoMessage() {}
+
+func (x *Media) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Media.ProtoReflect.Descriptor instead.
+func (*Media) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Media) GetPhoto() *Foto {
+	if x != nil {
+		return x.Photo
+	}
+	return nil
+}
+
+func (x *Media) GetSpeechesUrl() string {
+	if x != nil {
+		return x.SpeechesUrl
+	}
+	return ""
+}
+
+func (x *Media) GetSpeechesRss() string {
+	if x != nil {
+		return x.SpeechesRss
+	}
+	return ""
+}
+
+type Foto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Copyright     string                 `protobuf:"bytes,2,opt,name=copyright,proto3" json:"copyright,omitempty"`
+	LargeUrl      string                 `protobuf:"bytes,3,opt,name=large_url,json=largeUrl,proto3" json:"large_url,omitempty"`
+	AltText       string                 `protobuf:"bytes,4,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Foto) Reset() {
+	*x = Foto{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Foto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Foto) ProtoMessage() {}
+
+func (x *Foto) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Foto.ProtoReflect.Descriptor instead.
+func (*Foto) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Foto) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Foto) GetCopyright() string {
+	if x != nil {
+		return x.Copyright
+	}
+	return ""
+}
+
+func (x *Foto) GetLargeUrl() string {
+	if x != nil {
+		return x.LargeUrl
+	}
+	return ""
+}
+
+func (x *Foto) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+// Entry of the committee catalog.
+type CommitteeListEntry struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Live                 int32                  `protobuf:"varint,2,opt,name=live,proto3" json:"live,omitempty"`
+	CommitteeName        string                 `protobuf:"bytes,3,opt,name=committee_name,json=committeeName,proto3" json:"committee_name,omitempty"`
+	CommitteeShortName   string                 `protobuf:"bytes,4,opt,name=committee_short_name,json=committeeShortName,proto3" json:"committee_short_name,omitempty"`
+	CommitteeTeaser      string                 `protobuf:"bytes,5,opt,name=committee_teaser,json=committeeTeaser,proto3" json:"committee_teaser,omitempty"`
+	LastChanged          string                 `protobuf:"bytes,6,opt,name=last_changed,json=lastChanged,proto3" json:"last_changed,omitempty"`
+	ChangedDateTime      string                 `protobuf:"bytes,7,opt,name=changed_date_time,json=changedDateTime,proto3" json:"changed_date_time,omitempty"`
+	CommitteeDetailXml   string                 `protobuf:"bytes,8,opt,name=committee_detail_xml,json=committeeDetailXml,proto3" json:"committee_detail_xml,omitempty"`
+	ImageUrl             string                 `protobuf:"bytes,9,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	ImageGrossUrl        string                 `protobuf:"bytes,10,opt,name=image_gross_url,json=imageGrossUrl,proto3" json:"image_gross_url,omitempty"`
+	ImageXl              string                 `protobuf:"bytes,11,opt,name=image_xl,json=imageXl,proto3" json:"image_xl,omitempty"`
+	ImageXxl             string                 `protobuf:"bytes,12,opt,name=image_xxl,json=imageXxl,proto3" json:"image_xxl,omitempty"`
+	ImageCopyright       string                 `protobuf:"bytes,13,opt,name=image_copyright,json=imageCopyright,proto3" json:"image_copyright,omitempty"`
+	ImageLastChanged     string                 `protobuf:"bytes,14,opt,name=image_last_changed,json=imageLastChanged,proto3" json:"image_last_changed,omitempty"`
+	ImageChangedDateTime string                 `protobuf:"bytes,15,opt,name=image_changed_date_time,json=imageChangedDateTime,proto3" json:"image_changed_date_time,omitempty"`
+	ImageAltText         string                 `protobuf:"bytes,16,opt,name=image_alt_text,json=imageAltText,proto3" json:"image_alt_text,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CommitteeListEntry) Reset() {
+	*x = CommitteeListEntry{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitteeListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitteeListEntry) ProtoMessage() {}
+
+func (x *CommitteeListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitteeListEntry.ProtoReflect.Descriptor instead.
+func (*CommitteeListEntry) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CommitteeListEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetLive() int32 {
+	if x != nil {
+		return x.Live
+	}
+	return 0
+}
+
+func (x *CommitteeListEntry) GetCommitteeName() string {
+	if x != nil {
+		return x.CommitteeName
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetCommitteeShortName() string {
+	if x != nil {
+		return x.CommitteeShortName
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetCommitteeTeaser() string {
+	if x != nil {
+		return x.CommitteeTeaser
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetLastChanged() string {
+	if x != nil {
+		return x.LastChanged
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetChangedDateTime() string {
+	if x != nil {
+		return x.ChangedDateTime
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetCommitteeDetailXml() string {
+	if x != nil {
+		return x.CommitteeDetailXml
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetImageGrossUrl() string {
+	if x != nil {
+		return x.ImageGrossUrl
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetImageXl() string {
+	if x != nil {
+		return x.ImageXl
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetImageXxl() string {
+	if x != nil {
+		return x.ImageXxl
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetImageCopyright() string {
+	if x != nil {
+		return x.ImageCopyright
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetImageLastChanged() string {
+	if x != nil {
+		return x.ImageLastChanged
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetImageChangedDateTime() string {
+	if x != nil {
+		return x.ImageChangedDateTime
+	}
+	return ""
+}
+
+func (x *CommitteeListEntry) GetImageAltText() string {
+	if x != nil {
+		return x.ImageAltText
+	}
+	return ""
+}
+
+type CommitteeDetails struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	DocumentInfo       *DocumentInfo          `protobuf:"bytes,1,opt,name=document_info,json=documentInfo,proto3" json:"document_info,omitempty"`
+	CommitteeId        string                 `protobuf:"bytes,2,opt,name=committee_id,json=committeeId,proto3" json:"committee_id,omitempty"`
+	CommitteeName      string                 `protobuf:"bytes,3,opt,name=committee_name,json=committeeName,proto3" json:"committee_name,omitempty"`
+	SourceUrl          string                 `protobuf:"bytes,4,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	ImageUrl           string                 `protobuf:"bytes,5,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	LargeImageUrl      string                 `protobuf:"bytes,6,opt,name=large_image_url,json=largeImageUrl,proto3" json:"large_image_url,omitempty"`
+	ImageCopyright     string                 `protobuf:"bytes,7,opt,name=image_copyright,json=imageCopyright,proto3" json:"image_copyright,omitempty"`
+	ImageAltText       string                 `protobuf:"bytes,8,opt,name=image_alt_text,json=imageAltText,proto3" json:"image_alt_text,omitempty"`
+	Tasks              string                 `protobuf:"bytes,9,opt,name=tasks,proto3" json:"tasks,omitempty"`
+	Contact            string                 `protobuf:"bytes,10,opt,name=contact,proto3" json:"contact,omitempty"`
+	ChairpersonId      string                 `protobuf:"bytes,11,opt,name=chairperson_id,json=chairpersonId,proto3" json:"chairperson_id,omitempty"`
+	DeputyChairpersons []string               `protobuf:"bytes,12,rep,name=deputy_chairpersons,json=deputyChairpersons,proto3" json:"deputy_chairpersons,omitempty"`
+	Members            []*PersonListEntry     `protobuf:"bytes,13,rep,name=members,proto3" json:"members,omitempty"`
+	NewsItems          []*NewsItem            `protobuf:"bytes,14,rep,name=news_items,json=newsItems,proto3" json:"news_items,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CommitteeDetails) Reset() {
+	*x = CommitteeDetails{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitteeDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitteeDetails) ProtoMessage() {}
+
+func (x *CommitteeDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitteeDetails.ProtoReflect.Descriptor instead.
+func (*CommitteeDetails) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CommitteeDetails) GetDocumentInfo() *DocumentInfo {
+	if x != nil {
+		return x.DocumentInfo
+	}
+	return nil
+}
+
+func (x *CommitteeDetails) GetCommitteeId() string {
+	if x != nil {
+		return x.CommitteeId
+	}
+	return ""
+}
+
+func (x *CommitteeDetails) GetCommitteeName() string {
+	if x != nil {
+		return x.CommitteeName
+	}
+	return ""
+}
+
+func (x *CommitteeDetails) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *CommitteeDetails) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *CommitteeDetails) GetLargeImageUrl() string {
+	if x != nil {
+		return x.LargeImageUrl
+	}
+	return ""
+}
+
+func (x *CommitteeDetails) GetImageCopyright() string {
+	if x != nil {
+		return x.ImageCopyright
+	}
+	return ""
+}
+
+func (x *CommitteeDetails) GetImageAltText() string {
+	if x != nil {
+		return x.ImageAltText
+	}
+	return ""
+}
+
+func (x *CommitteeDetails) GetTasks() string {
+	if x != nil {
+		return x.Tasks
+	}
+	return ""
+}
+
+func (x *CommitteeDetails) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+func (x *CommitteeDetails) GetChairpersonId() string {
+	if x != nil {
+		return x.ChairpersonId
+	}
+	return ""
+}
+
+func (x *CommitteeDetails) GetDeputyChairpersons() []string {
+	if x != nil {
+		return x.DeputyChairpersons
+	}
+	return nil
+}
+
+func (x *CommitteeDetails) GetMembers() []*PersonListEntry {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *CommitteeDetails) GetNewsItems() []*NewsItem {
+	if x != nil {
+		return x.NewsItems
+	}
+	return nil
+}
+
+type NewsItem struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Title           string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description     string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	PublicationDate string                 `protobuf:"bytes,3,opt,name=publication_date,json=publicationDate,proto3" json:"publication_date,omitempty"`
+	Url             string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *NewsItem) Reset() {
+	*x = NewsItem{}
+	mi := &file_bundestag_v1_types_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewsItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewsItem) ProtoMessage() {}
+
+func (x *NewsItem) ProtoReflect() protoreflect.Message {
+	mi := &file_bundestag_v1_types_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewsItem.ProtoReflect.Descriptor instead.
+func (*NewsItem) Descriptor() ([]byte, []int) {
+	return file_bundestag_v1_types_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *NewsItem) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *NewsItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *NewsItem) GetPublicationDate() string {
+	if x != nil {
+		return x.PublicationDate
+	}
+	return ""
+}
+
+func (x *NewsItem) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+var File_bundestag_v1_types_proto protoreflect.FileDescriptor
+
+const file_bundestag_v1_types_proto_rawDesc = "" +
+	"\n" +
+	"\x18bundestag/v1/types.proto\x12\fbundestag.v1\"X\n" +
+	"\fDocumentInfo\x12!\n" +
+	"\fdocument_url\x18\x01 \x01(\tR\vdocumentUrl\x12%\n" +
+	"\x0edocument_stand\x18\x02 \x01(\tR\rdocumentStand\"5\n" +
+	"\x05MdbId\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"7\n" +
+	"\aMdbName\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"L\n" +
+	"\fConstituency\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\tR\x06number\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\"\xfc\x04\n" +
+	"\x0fPersonListEntry\x12\x18\n" +
+	"\afaction\x18\x01 \x01(\tR\afaction\x12#\n" +
+	"\x02id\x18\x02 \x01(\v2\x13.bundestag.v1.MdbIdR\x02id\x12)\n" +
+	"\x04name\x18\x03 \x01(\v2\x15.bundestag.v1.MdbNameR\x04name\x12\x17\n" +
+	"\abio_url\x18\x04 \x01(\tR\x06bioUrl\x12 \n" +
+	"\finfo_xml_url\x18\x05 \x01(\tR\n" +
+	"infoXmlUrl\x125\n" +
+	"\x17info_xml_url_mitmischen\x18\x06 \x01(\tR\x14infoXmlUrlMitmischen\x12\x14\n" +
+	"\x05state\x18\a \x01(\tR\x05state\x12>\n" +
+	"\fconstituency\x18\b \x01(\v2\x1a.bundestag.v1.ConstituencyR\fconstituency\x12\x18\n" +
+	"\aelected\x18\t \x01(\tR\aelected\x12\x1b\n" +
+	"\tphoto_url\x18\n" +
+	" \x01(\tR\bphotoUrl\x12&\n" +
+	"\x0fphoto_large_url\x18\v \x01(\tR\rphotoLargeUrl\x12,\n" +
+	"\x12photo_last_changed\x18\f \x01(\tR\x10photoLastChanged\x125\n" +
+	"\x17photo_changed_date_time\x18\r \x01(\tR\x14photoChangedDateTime\x12$\n" +
+	"\x0eimage_alt_text\x18\x0e \x01(\tR\fimageAltText\x12!\n" +
+	"\flast_changed\x18\x0f \x01(\tR\vlastChanged\x12*\n" +
+	"\x11changed_date_time\x18\x10 \x01(\tR\x0fchangedDateTime\"\xa7\x01\n" +
+	"\n" +
+	"Politician\x12?\n" +
+	"\rdocument_info\x18\x01 \x01(\v2\x1a.bundestag.v1.DocumentInfoR\fdocumentInfo\x12-\n" +
+	"\x03bio\x18\x02 \x01(\v2\x1b.bundestag.v1.PoliticianBioR\x03bio\x12)\n" +
+	"\x05media\x18\x03 \x01(\v2\x13.bundestag.v1.MediaR\x05media\"\xaa\t\n" +
+	"\rPoliticianBio\x12#\n" +
+	"\x02id\x18\x01 \x01(\v2\x13.bundestag.v1.MdbIdR\x02id\x12\x1d\n" +
+	"\n" +
+	"article_id\x18\x02 \x01(\tR\tarticleId\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x03 \x01(\tR\tsourceUrl\x12\x1b\n" +
+	"\texit_date\x18\x04 \x01(\tR\bexitDate\x12\x1b\n" +
+	"\tlast_name\x18\x05 \x01(\tR\blastName\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x06 \x01(\tR\tfirstName\x12%\n" +
+	"\x0enobility_title\x18\a \x01(\tR\rnobilityTitle\x12%\n" +
+	"\x0eacademic_title\x18\b \x01(\tR\racademicTitle\x12'\n" +
+	"\x0flocation_suffix\x18\t \x01(\tR\x0elocationSuffix\x12\"\n" +
+	"\rdate_of_birth\x18\n" +
+	" \x01(\tR\vdateOfBirth\x128\n" +
+	"\x18religion_or_denomination\x18\v \x01(\tR\x16religionOrDenomination\x12U\n" +
+	"'education_or_professional_qualification\x18\f \x01(\tR$educationOrProfessionalQualification\x12)\n" +
+	"\x10higher_education\x18\r \x01(\tR\x0fhigherEducation\x128\n" +
+	"\n" +
+	"profession\x18\x0e \x01(\v2\x18.bundestag.v1.ProfessionR\n" +
+	"profession\x12\x16\n" +
+	"\x06gender\x18\x0f \x01(\tR\x06gender\x12%\n" +
+	"\x0emarital_status\x18\x10 \x01(\tR\rmaritalStatus\x12$\n" +
+	"\x0enumber_of_kids\x18\x11 \x01(\tR\fnumberOfKids\x12\x18\n" +
+	"\afaction\x18\x12 \x01(\tR\afaction\x12\x14\n" +
+	"\x05party\x18\x13 \x01(\tR\x05party\x12\x14\n" +
+	"\x05state\x18\x14 \x01(\tR\x05state\x12>\n" +
+	"\fconstituency\x18\x15 \x01(\v2\x1a.bundestag.v1.ConstituencyR\fconstituency\x12\x18\n" +
+	"\aelected\x18\x16 \x01(\tR\aelected\x12\x17\n" +
+	"\abio_url\x18\x17 \x01(\tR\x06bioUrl\x12'\n" +
+	"\x0fbiographic_info\x18\x18 \x01(\tR\x0ebiographicInfo\x12\x16\n" +
+	"\x06trivia\x18\x19 \x01(\tR\x06trivia\x12\x1a\n" +
+	"\bhomepage\x18\x1a \x01(\tR\bhomepage\x12B\n" +
+	"\x0eother_websites\x18\x1b \x01(\v2\x1b.bundestag.v1.OtherWebsitesR\rotherWebsites\x12\x14\n" +
+	"\x05phone\x18\x1c \x01(\tR\x05phone\x12;\n" +
+	"\vmemberships\x18\x1d \x01(\v2\x19.bundestag.v1.MembershipsR\vmemberships\x12:\n" +
+	"\x19mandated_publishable_info\x18\x1e \x01(\tR\x17mandatedPublishableInfo\"8\n" +
+	"\n" +
+	"Profession\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"B\n" +
+	"\rOtherWebsites\x121\n" +
+	"\bwebsites\x18\x01 \x03(\v2\x15.bundestag.v1.WebsiteR\bwebsites\"1\n" +
+	"\aWebsite\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"\xd7\x02\n" +
+	"\vMemberships\x12@\n" +
+	"\x0flead_committees\x18\x01 \x03(\v2\x17.bundestag.v1.CommitteeR\x0eleadCommittees\x12S\n" +
+	"\x19regular_member_committees\x18\x02 \x03(\v2\x17.bundestag.v1.CommitteeR\x17regularMemberCommittees\x12Y\n" +
+	"\x1csubstitute_member_committees\x18\x03 \x03(\v2\x17.bundestag.v1.CommitteeR\x1asubstituteMemberCommittees\x12V\n" +
+	"\x1bvice_chair_other_committees\x18\x04 \x03(\v2\x17.bundestag.v1.CommitteeR\x18viceChairOtherCommittees\"A\n" +
+	"\tCommittee\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\"w\n" +
+	"\x05Media\x12(\n" +
+	"\x05photo\x18\x01 \x01(\v2\x12.bundestag.v1.FotoR\x05photo\x12!\n" +
+	"\fspeeches_url\x18\x02 \x01(\tR\vspeechesUrl\x12!\n" +
+	"\fspeeches_rss\x18\x03 \x01(\tR\vspeechesRss\"n\n" +
+	"\x04Foto\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1c\n" +
+	"\tcopyright\x18\x02 \x01(\tR\tcopyright\x12\x1b\n" +
+	"\tlarge_url\x18\x03 \x01(\tR\blargeUrl\x12\x19\n" +
+	"\balt_text\x18\x04 \x01(\tR\aaltText\"\xee\x04\n" +
+	"\x12CommitteeListEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04live\x18\x02 \x01(\x05R\x04live\x12%\n" +
+	"\x0ecommittee_name\x18\x03 \x01(\tR\rcommitteeName\x120\n" +
+	"\x14committee_short_name\x18\x04 \x01(\tR\x12committeeShortName\x12)\n" +
+	"\x10committee_teaser\x18\x05 \x01(\tR\x0fcommitteeTeaser\x12!\n" +
+	"\flast_changed\x18\x06 \x01(\tR\vlastChanged\x12*\n" +
+	"\x11changed_date_time\x18\a \x01(\tR\x0fchangedDateTime\x120\n" +
+	"\x14committee_detail_xml\x18\b \x01(\tR\x12committeeDetailXml\x12\x1b\n" +
+	"\timage_url\x18\t \x01(\tR\bimageUrl\x12&\n" +
+	"\x0fimage_gross_url\x18\n" +
+	" \x01(\tR\rimageGrossUrl\x12\x19\n" +
+	"\bimage_xl\x18\v \x01(\tR\aimageXl\x12\x1b\n" +
+	"\timage_xxl\x18\f \x01(\tR\bimageXxl\x12'\n" +
+	"\x0fimage_copyright\x18\r \x01(\tR\x0eimageCopyright\x12,\n" +
+	"\x12image_last_changed\x18\x0e \x01(\tR\x10imageLastChanged\x125\n" +
+	"\x17image_changed_date_time\x18\x0f \x01(\tR\x14imageChangedDateTime\x12$\n" +
+	"\x0eimage_alt_text\x18\x10 \x01(\tR\fimageAltText\"\xc8\x04\n" +
+	"\x10CommitteeDetails\x12?\n" +
+	"\rdocument_info\x18\x01 \x01(\v2\x1a.bundestag.v1.DocumentInfoR\fdocumentInfo\x12!\n" +
+	"\fcommittee_id\x18\x02 \x01(\tR\vcommitteeId\x12%\n" +
+	"\x0ecommittee_name\x18\x03 \x01(\tR\rcommitteeName\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x04 \x01(\tR\tsourceUrl\x12\x1b\n" +
+	"\timage_url\x18\x05 \x01(\tR\bimageUrl\x12&\n" +
+	"\x0flarge_image_url\x18\x06 \x01(\tR\rlargeImageUrl\x12'\n" +
+	"\x0fimage_copyright\x18\a \x01(\tR\x0eimageCopyright\x12$\n" +
+	"\x0eimage_alt_text\x18\b \x01(\tR\fimageAltText\x12\x14\n" +
+	"\x05tasks\x18\t \x01(\tR\x05tasks\x12\x18\n" +
+	"\acontact\x18\n" +
+	" \x01(\tR\acontact\x12%\n" +
+	"\x0echairperson_id\x18\v \x01(\tR\rchairpersonId\x12/\n" +
+	"\x13deputy_chairpersons\x18\f \x03(\tR\x12deputyChairpersons\x127\n" +
+	"\amembers\x18\r \x03(\v2\x1d.bundestag.v1.PersonListEntryR\amembers\x125\n" +
+	"\n" +
+	"news_items\x18\x0e \x03(\v2\x16.bundestag.v1.NewsItemR\tnewsItems\"\x7f\n" +
+	"\bNewsItem\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12)\n" +
+	"\x10publication_date\x18\x03 \x01(\tR\x0fpublicationDate\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03urlBEZCgithub.com/kyzrfranz/bundestag-api/api/gen/bundestag/v1;bundestagv1b\x06proto3"
+
+var (
+	file_bundestag_v1_types_proto_rawDescOnce sync.Once
+	file_bundestag_v1_types_proto_rawDescData []byte
+)
+
+func file_bundestag_v1_types_proto_rawDescGZIP() []byte {
+	file_bundestag_v1_types_proto_rawDescOnce.Do(func() {
+		file_bundestag_v1_types_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bundestag_v1_types_proto_rawDesc), len(file_bundestag_v1_types_proto_rawDesc)))
+	})
+	return file_bundestag_v1_types_proto_rawDescData
+}
+
+var file_bundestag_v1_types_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_bundestag_v1_types_proto_goTypes = []any{
+	(*DocumentInfo)(nil),       // 0: bundestag.v1.DocumentInfo
+	(*MdbId)(nil),              // 1: bundestag.v1.MdbId
+	(*MdbName)(nil),            // 2: bundestag.v1.MdbName
+	(*Constituency)(nil),       // 3: bundestag.v1.Constituency
+	(*PersonListEntry)(nil),    // 4: bundestag.v1.PersonListEntry
+	(*Politician)(nil),         // 5: bundestag.v1.Politician
+	(*PoliticianBio)(nil),      // 6: bundestag.v1.PoliticianBio
+	(*Profession)(nil),         // 7: bundestag.v1.Profession
+	(*OtherWebsites)(nil),      // 8: bundestag.v1.OtherWebsites
+	(*Website)(nil),            // 9: bundestag.v1.Website
+	(*Memberships)(nil),        // 10: bundestag.v1.Memberships
+	(*Committee)(nil),          // 11: bundestag.v1.Committee
+	(*Media)(nil),              // 12: bundestag.v1.Media
+	(*Foto)(nil),               // 13: bundestag.v1.Foto
+	(*CommitteeListEntry)(nil), // 14: bundestag.v1.CommitteeListEntry
+	(*CommitteeDetails)(nil),   // 15: bundestag.v1.CommitteeDetails
+	(*NewsItem)(nil),           // 16: bundestag.v1.NewsItem
+}
+var file_bundestag_v1_types_proto_depIdxs = []int32{
+	1,  // 0: bundestag.v1.PersonListEntry.id:type_name -> bundestag.v1.MdbId
+	2,  // 1: bundestag.v1.PersonListEntry.name:type_name -> bundestag.v1.MdbName
+	3,  // 2: bundestag.v1.PersonListEntry.constituency:type_name -> bundestag.v1.Constituency
+	0,  // 3: bundestag.v1.Politician.document_info:type_name -> bundestag.v1.DocumentInfo
+	6,  // 4: bundestag.v1.Politician.bio:type_name -> bundestag.v1.PoliticianBio
+	12, // 5: bundestag.v1.Politician.media:type_name -> bundestag.v1.Media
+	1,  // 6: bundestag.v1.PoliticianBio.id:type_name -> bundestag.v1.MdbId
+	7,  // 7: bundestag.v1.PoliticianBio.profession:type_name -> bundestag.v1.Profession
+	3,  // 8: bundestag.v1.PoliticianBio.constituency:type_name -> bundestag.v1.Constituency
+	8,  // 9: bundestag.v1.PoliticianBio.other_websites:type_name -> bundestag.v1.OtherWebsites
+	10, // 10: bundestag.v1.PoliticianBio.memberships:type_name -> bundestag.v1.Memberships
+	9,  // 11: bundestag.v1.OtherWebsites.websites:type_name -> bundestag.v1.Website
+	11, // 12: bundestag.v1.Memberships.lead_committees:type_name -> bundestag.v1.Committee
+	11, // 13: bundestag.v1.Memberships.regular_member_committees:type_name -> bundestag.v1.Committee
+	11, // 14: bundestag.v1.Memberships.substitute_member_committees:type_name -> bundestag.v1.Committee
+	11, // 15: bundestag.v1.Memberships.vice_chair_other_committees:type_name -> bundestag.v1.Committee
+	13, // 16: bundestag.v1.Media.photo:type_name -> bundestag.v1.Foto
+	0,  // 17: bundestag.v1.CommitteeDetails.document_info:type_name -> bundestag.v1.DocumentInfo
+	4,  // 18: bundestag.v1.CommitteeDetails.members:type_name -> bundestag.v1.PersonListEntry
+	16, // 19: bundestag.v1.CommitteeDetails.news_items:type_name -> bundestag.v1.NewsItem
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_bundestag_v1_types_proto_init() }
+func file_bundestag_v1_types_proto_init() {
+	if File_bundestag_v1_types_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bundestag_v1_types_proto_rawDesc), len(file_bundestag_v1_types_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_bundestag_v1_types_proto_goTypes,
+		DependencyIndexes: file_bundestag_v1_types_proto_depIdxs,
+		MessageInfos:      file_bundestag_v1_types_proto_msgTypes,
+	}.Build()
+	File_bundestag_v1_types_proto = out.File
+	file_bundestag_v1_types_proto_goTypes = nil
+	file_bundestag_v1_types_proto_depIdxs = nil
+}
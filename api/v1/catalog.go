@@ -45,6 +45,16 @@ func (c PersonListEntry) GetDetailUrl() *url.URL {
 	return dUrl
 }
 
+func (c PersonListEntry) Images() []Image {
+	return images(c.PhotoURL, c.PhotoLargeURL)
+}
+
+// ImageInfo has no copyright, the catalog doesn't list it. See
+// Politician.ImageInfo.
+func (c PersonListEntry) ImageInfo() ImageInfo {
+	return ImageInfo{AltText: c.ImageAltText, LastChanged: c.PhotoLastChanged, ChangedDateTime: c.PhotoChangedDateTime}
+}
+
 type CommitteeCatalog struct {
 	DocumentInfo DocumentInfo         `xml:"dokumentInfo" json:"dokumentInfo"`
 	Committees   []CommitteeListEntry `xml:"ausschuesse>ausschuss" json:"committees"`
@@ -81,3 +91,11 @@ func (c CommitteeListEntry) GetDetailUrl() *url.URL {
 	dUrl, _ := url.Parse(c.DetailXML)
 	return dUrl
 }
+
+func (c CommitteeListEntry) Images() []Image {
+	return images(c.ImageURL, c.ImageGrossURL, c.ImageXL, c.ImageXXL)
+}
+
+func (c CommitteeListEntry) ImageInfo() ImageInfo {
+	return ImageInfo{Copyright: c.ImageCopyright, AltText: c.ImageAltText, LastChanged: c.ImageLastChanged, ChangedDateTime: c.ImageChangedDateTime}
+}
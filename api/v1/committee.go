@@ -17,6 +17,14 @@ type CommitteeDetails struct {
 	NewsItems          []NewsItem        `xml:"news>item" json:"news_items"`
 }
 
+func (c CommitteeDetails) Images() []Image {
+	return images(c.ImageURL, c.LargeImageURL)
+}
+
+func (c CommitteeDetails) ImageInfo() ImageInfo {
+	return ImageInfo{Copyright: c.ImageCopyright, AltText: c.ImageAltText}
+}
+
 type NewsItem struct {
 	Title           string `xml:"title" json:"title"`
 	Description     string `xml:"description" json:"description"`
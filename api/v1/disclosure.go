@@ -0,0 +1,33 @@
+package v1
+
+// Disclosure is one entry of the mandated publishable information
+// (Veröffentlichungspflichtige Angaben) of an MdB. Raw always carries the
+// original text, the other fields are only set where it could be parsed.
+type Disclosure struct {
+	Category     int      `json:"category,omitempty"`
+	CategoryName string   `json:"categoryName,omitempty"`
+	Organisation string   `json:"organisation,omitempty"`
+	Role         string   `json:"role,omitempty"`
+	Location     string   `json:"location,omitempty"`
+	Income       *Income  `json:"income,omitempty"`
+	Period       string   `json:"period,omitempty"`
+	Raw          string   `json:"raw"`
+	Unparsed     []string `json:"unparsed,omitempty"`
+}
+
+// Income is either a level (Stufe 1–10) with its range in EUR or an amount.
+type Income struct {
+	Level  int      `json:"level,omitempty"`
+	Min    float64  `json:"min,omitempty"`
+	Max    float64  `json:"max,omitempty"`
+	Amount *float64 `json:"amount,omitempty"`
+	Raw    string   `json:"raw"`
+}
+
+// PoliticianDisclosure is a Disclosure together with the MdB it belongs to.
+type PoliticianDisclosure struct {
+	PoliticianID string `json:"politicianId"`
+	Name         string `json:"name"`
+	Faction      string `json:"faction"`
+	Disclosure
+}
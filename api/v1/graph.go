@@ -0,0 +1,59 @@
+package v1
+
+type GraphMode string
+
+const (
+	// GraphBipartite connects MdBs with their committees.
+	GraphBipartite GraphMode = "bipartite"
+	// GraphProjected connects MdBs sitting on the same committees.
+	GraphProjected GraphMode = "projected"
+)
+
+type NodeType string
+
+const (
+	NodePolitician NodeType = "politician"
+	NodeCommittee  NodeType = "committee"
+)
+
+type MembershipRole string
+
+const (
+	RoleMember     MembershipRole = "member"
+	RoleSubstitute MembershipRole = "substitute"
+	RoleLead       MembershipRole = "lead"
+	RoleChair      MembershipRole = "chair"
+	RoleViceChair  MembershipRole = "viceChair"
+)
+
+// Graph is an undirected network of MdBs and committees. Node IDs are
+// prefixed by their type, e.g. "politician-1001" or "committee-a11", as the
+// IDs of both may clash.
+type Graph struct {
+	Mode  GraphMode   `json:"mode"`
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+type GraphNode struct {
+	ID    string   `json:"id"`
+	Type  NodeType `json:"type"`
+	Label string   `json:"label"`
+	// RefID is the ID of the politician or committee in the API.
+	RefID   string `json:"refId"`
+	Faction string `json:"faction,omitempty"`
+	State   string `json:"state,omitempty"`
+	Party   string `json:"party,omitempty"`
+	// Members is the number of members of a committee.
+	Members int `json:"members,omitempty"`
+}
+
+// GraphEdge links an MdB to a committee, weighted 1, or two MdBs, weighted
+// by the number of committees they share.
+type GraphEdge struct {
+	Source     string           `json:"source"`
+	Target     string           `json:"target"`
+	Weight     int              `json:"weight"`
+	Roles      []MembershipRole `json:"roles,omitempty"`
+	Committees []string         `json:"committees,omitempty"`
+}
@@ -0,0 +1,65 @@
+package v1
+
+import "time"
+
+// ImageSize is one of the renditions upstream offers of an image, from the
+// smallest to the largest.
+type ImageSize int
+
+const (
+	ImageSmall ImageSize = iota
+	ImageLarge
+	ImageXL
+	ImageXXL
+)
+
+// Image is a rendition of the photo of a politician or the picture of a
+// committee.
+type Image struct {
+	Size ImageSize
+	URL  string
+}
+
+// images lists the renditions with a URL, in the order of sizes.
+func images(urls ...string) []Image {
+	var result []Image
+	for size, url := range urls {
+		if url != "" {
+			result = append(result, Image{Size: ImageSize(size), URL: url})
+		}
+	}
+	return result
+}
+
+// ImageInfo is the attribution and the last change of an image, as far as
+// upstream lists them with the resource.
+type ImageInfo struct {
+	Copyright       string
+	AltText         string
+	LastChanged     string
+	ChangedDateTime string
+}
+
+// Changed is the time of the last change, only to the day if upstream lists
+// no time. It is zero if upstream lists neither.
+func (i ImageInfo) Changed() time.Time {
+	if t, err := time.ParseInLocation("02.01.2006 15:04", i.ChangedDateTime, Berlin); err == nil {
+		return t
+	}
+	t, _ := time.ParseInLocation("02.01.2006", i.LastChanged, Berlin)
+	return t
+}
+
+// PhotoMeta describes a photo for attribution and layout.
+type PhotoMeta struct {
+	Copyright   string `json:"copyright"`
+	AltText     string `json:"altText"`
+	SourceURL   string `json:"sourceUrl"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	LastChanged string `json:"lastChanged,omitempty"`
+	// BlurHash and DominantColor, a hex triplet like "#8a8f94", can be shown
+	// until the photo is loaded.
+	BlurHash      string `json:"blurHash,omitempty"`
+	DominantColor string `json:"dominantColor,omitempty"`
+}
@@ -1,10 +1,24 @@
 package v1
 
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
 type ID struct {
 	Value  string `json:"value" xml:",chardata"`
 	Status string `json:"status" xml:"status,attr"`
 }
 
+// Active reports whether the MdB currently holds the mandate. The catalog
+// lists former MdBs of the legislative period too, only current ones have
+// the status "Aktiv". An ID without status is taken as active.
+func (i ID) Active() bool {
+	status := strings.TrimSpace(i.Status)
+	return status == "" || strings.EqualFold(status, "Aktiv")
+}
+
 type MdbName struct {
 	Value  string `json:"value" xml:",chardata"`
 	Status string `json:"status" xml:"status,attr"`
@@ -16,6 +30,14 @@ type Politician struct {
 	Media        Media         `json:"media" xml:"mdbMedien"`
 }
 
+func (p Politician) Images() []Image {
+	return images(p.Media.Foto.URL, p.Media.Foto.LargeUrl)
+}
+
+func (p Politician) ImageInfo() ImageInfo {
+	return ImageInfo{Copyright: p.Media.Foto.Copyright, AltText: p.Media.Foto.AltText}
+}
+
 type PoliticianBio struct {
 	Id                                   ID            `json:"id" xml:"mdbID"`
 	ArticleID                            string        `json:"articleId" xml:"articleId"`
@@ -49,6 +71,19 @@ type PoliticianBio struct {
 	MandatedPublishableInfo              string        `json:"mandatedPublishableInfo,omitempty" xml:"mdbVeroeffentlichungspflichtigeAngaben"`
 }
 
+// genders as upstream lists them, in lower case
+const (
+	GenderMale    = "männlich"
+	GenderFemale  = "weiblich"
+	GenderDiverse = "divers"
+)
+
+// BirthDate parses DateOfBirth, false if it isn't a date.
+func (b PoliticianBio) BirthDate() (time.Time, bool) {
+	t, err := ParseDate(b.DateOfBirth)
+	return t, err == nil
+}
+
 type Profession struct {
 	Field string `json:"field,omitempty" xml:"berufsfeld,attr"`
 	Value string `json:"value" xml:",chardata"`
@@ -76,6 +111,20 @@ type Memberships struct {
 	ViceChairOtherCommittees   []Committee `json:"viceChairOtherCommittees,omitempty" xml:"mdbStellvVorsitzSonstigeGremien>mdbStellvVorsitzSonstigesGremium"`
 }
 
+// Committees lists every committee once, although an MdB can hold more than
+// one role in the same committee.
+func (m Memberships) Committees() []Committee {
+	var result []Committee
+	for _, committees := range [][]Committee{m.LeadCommittees, m.RegularMemberCommittees, m.SubstituteMemberCommittees, m.ViceChairOtherCommittees} {
+		for _, c := range committees {
+			if !slices.Contains(result, c) {
+				result = append(result, c)
+			}
+		}
+	}
+	return result
+}
+
 type Committee struct {
 	Id   string `json:"id,omitempty" xml:"id,attr"`
 	Name string `json:"name" xml:"gremiumName"`
@@ -1,5 +1,36 @@
 package v1
 
+import (
+	"time"
+	_ "time/tzdata" // upstream times are local to Berlin
+)
+
+// Berlin is the location of the dates and times upstream lists.
+var Berlin, _ = time.LoadLocation("Europe/Berlin")
+
+// ParseDate reads a date as upstream writes it, e.g. 31.12.1970.
+func ParseDate(s string) (time.Time, error) {
+	return time.Parse("02.01.2006", s)
+}
+
+// Address is a postal address.
+type Address struct {
+	Street      string
+	PostalCode  string
+	Locality    string
+	Country     string
+	CountryCode string
+}
+
+// Office is the postal address of the Bundestag, which all MdBs share.
+var Office = Address{
+	Street:      "Platz der Republik 1",
+	PostalCode:  "11011",
+	Locality:    "Berlin",
+	Country:     "Deutschland",
+	CountryCode: "DE",
+}
+
 type DocumentInfo struct {
 	DocumentURL   string `json:"documentURL,omitempty" xml:"dokumentURL"`
 	DocumentStand string `json:"documentStand" xml:"dokumentStand"`
@@ -0,0 +1,29 @@
+package v1
+
+type StationKind string
+
+const (
+	StationEducation  StationKind = "education"
+	StationProfession StationKind = "profession"
+	StationParty      StationKind = "party"
+	StationMandate    StationKind = "mandate"
+	StationCommittee  StationKind = "committee"
+	StationOther      StationKind = "other"
+)
+
+// CareerStation is a single entry of a career timeline. Stations the parser
+// couldn't classify or date with certainty are flagged as uncertain.
+type CareerStation struct {
+	Kind      StationKind `json:"kind"`
+	Title     string      `json:"title"`
+	StartYear int         `json:"startYear,omitempty"`
+	EndYear   int         `json:"endYear,omitempty"`
+	Ongoing   bool        `json:"ongoing,omitempty"`
+	Source    string      `json:"source"`
+	Uncertain bool        `json:"uncertain,omitempty"`
+}
+
+type CareerTimeline struct {
+	PoliticianID string          `json:"politicianId"`
+	Stations     []CareerStation `json:"stations"`
+}
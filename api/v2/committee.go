@@ -0,0 +1,47 @@
+package v2
+
+// Committee is an entry of the committee catalog.
+type Committee struct {
+	ID        string `json:"id" xml:"id"`
+	Name      string `json:"name" xml:"name"`
+	ShortName string `json:"shortName,omitempty" xml:"shortName,omitempty"`
+	Teaser    string `json:"teaser,omitempty" xml:"teaser,omitempty"`
+	Live      bool   `json:"live" xml:"live"`
+	Image     *Image `json:"image,omitempty" xml:"image,omitempty"`
+	SourceURL string `json:"sourceUrl,omitempty" xml:"sourceUrl,omitempty"`
+	ChangedAt string `json:"changedAt,omitempty" xml:"changedAt,omitempty"`
+}
+
+// CommitteeDetails are the tasks, chairs and members of a committee.
+type CommitteeDetails struct {
+	ID                   string       `json:"id" xml:"id"`
+	Name                 string       `json:"name" xml:"name"`
+	Tasks                string       `json:"tasks,omitempty" xml:"tasks,omitempty"`
+	Contact              string       `json:"contact,omitempty" xml:"contact,omitempty"`
+	ChairpersonID        string       `json:"chairpersonId,omitempty" xml:"chairpersonId,omitempty"`
+	DeputyChairpersonIDs []string     `json:"deputyChairpersonIds" xml:"deputyChairpersonIds>id"`
+	Members              []Politician `json:"members" xml:"members>member"`
+	News                 []NewsItem   `json:"news" xml:"news>item"`
+	Image                *Image       `json:"image,omitempty" xml:"image,omitempty"`
+	URL                  string       `json:"url,omitempty" xml:"url,omitempty"`
+	SourceURL            string       `json:"sourceUrl,omitempty" xml:"sourceUrl,omitempty"`
+	SourceDate           string       `json:"sourceDate,omitempty" xml:"sourceDate,omitempty"`
+}
+
+type NewsItem struct {
+	Title       string `json:"title" xml:"title"`
+	Description string `json:"description,omitempty" xml:"description,omitempty"`
+	PublishedAt string `json:"publishedAt,omitempty" xml:"publishedAt,omitempty"`
+	URL         string `json:"url,omitempty" xml:"url,omitempty"`
+}
+
+// Image is a photo of an MdB or the picture of a committee.
+type Image struct {
+	URL       string `json:"url" xml:"url"`
+	LargeURL  string `json:"largeUrl,omitempty" xml:"largeUrl,omitempty"`
+	XLURL     string `json:"xlUrl,omitempty" xml:"xlUrl,omitempty"`
+	XXLURL    string `json:"xxlUrl,omitempty" xml:"xxlUrl,omitempty"`
+	Copyright string `json:"copyright,omitempty" xml:"copyright,omitempty"`
+	AltText   string `json:"altText,omitempty" xml:"altText,omitempty"`
+	ChangedAt string `json:"changedAt,omitempty" xml:"changedAt,omitempty"`
+}
@@ -0,0 +1,172 @@
+package v2
+
+import (
+	"time"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+)
+
+// conversions of the XML-bound v1 structs, usable with resources.NewMappedRepo
+
+func FromPersonListEntry(e *v1.PersonListEntry) *Politician {
+	p := &Politician{
+		ID:           e.Id.Value,
+		Status:       e.Id.Status,
+		Name:         e.Name.Value,
+		Faction:      e.Faction,
+		State:        e.State,
+		Constituency: constituency(e.Constituency),
+		Elected:      e.Elected,
+		URL:          e.BioURL,
+		SourceURL:    e.InfoXMLURL,
+		ChangedAt:    timestamp(e.ChangedDateTime, e.LastChanged),
+	}
+	if e.PhotoURL != "" {
+		p.Photo = &Image{
+			URL:       e.PhotoURL,
+			LargeURL:  e.PhotoLargeURL,
+			AltText:   e.ImageAltText,
+			ChangedAt: timestamp(e.PhotoChangedDateTime, e.PhotoLastChanged),
+		}
+	}
+	return p
+}
+
+func FromPolitician(p *v1.Politician) *Bio {
+	bio := p.Bio
+	b := &Bio{
+		ID:              bio.Id.Value,
+		Status:          bio.Id.Status,
+		FirstName:       bio.FirstName,
+		LastName:        bio.LastName,
+		NobilityTitle:   bio.NobilityTitle,
+		AcademicTitle:   bio.AcademicTitle,
+		LocationSuffix:  bio.LocationSuffix,
+		BirthDate:       date(bio.DateOfBirth),
+		ExitDate:        date(bio.ExitDate),
+		Gender:          bio.Gender,
+		MaritalStatus:   bio.MaritalStatus,
+		Children:        bio.NumberKids,
+		Religion:        bio.ReligionOrDenomination,
+		Education:       bio.EducationOrProfessionalQualification,
+		HigherEducation: bio.HigherEducation,
+		Faction:         bio.Faction,
+		Party:           bio.Party,
+		State:           bio.State,
+		Constituency:    constituency(bio.Constituency),
+		Elected:         bio.Elected,
+		Biography:       bio.BiographicInfo,
+		Trivia:          bio.Trivia,
+		Homepage:        bio.Homepage,
+		Websites:        []Website{},
+		Phone:           bio.Phone,
+		Memberships:     []Membership{},
+		PublishableInfo: bio.MandatedPublishableInfo,
+		URL:             bio.BioURL,
+		SourceURL:       p.DocumentInfo.DocumentURL,
+		SourceDate:      date(p.DocumentInfo.DocumentStand),
+	}
+	if bio.Profession.Value != "" {
+		b.Profession = &Profession{Field: bio.Profession.Field, Name: bio.Profession.Value}
+	}
+	for _, w := range bio.OtherWebsite.Website {
+		b.Websites = append(b.Websites, Website{Title: w.Title, URL: w.URL})
+	}
+	for _, m := range []struct {
+		role       string
+		committees []v1.Committee
+	}{
+		{RoleLead, bio.Memberships.LeadCommittees},
+		{RoleMember, bio.Memberships.RegularMemberCommittees},
+		{RoleSubstitute, bio.Memberships.SubstituteMemberCommittees},
+		{RoleViceChairOther, bio.Memberships.ViceChairOtherCommittees},
+	} {
+		for _, c := range m.committees {
+			b.Memberships = append(b.Memberships, Membership{CommitteeID: c.Id, Name: c.Name, URL: c.Url, Role: m.role})
+		}
+	}
+	if foto := p.Media.Foto; foto.URL != "" {
+		b.Photo = &Image{URL: foto.URL, LargeURL: foto.LargeUrl, Copyright: foto.Copyright, AltText: foto.AltText}
+	}
+	if p.Media.SpeechesUrl != "" || p.Media.SpeechesRSS != "" {
+		b.Speeches = &Speeches{URL: p.Media.SpeechesUrl, RSS: p.Media.SpeechesRSS}
+	}
+	return b
+}
+
+func FromCommitteeListEntry(e *v1.CommitteeListEntry) *Committee {
+	c := &Committee{
+		ID:        e.Id,
+		Name:      e.Name,
+		ShortName: e.ShortName,
+		Teaser:    e.Teaser,
+		Live:      e.Live != 0,
+		SourceURL: e.DetailXML,
+		ChangedAt: timestamp(e.ChangedDateTime, e.LastChanged),
+	}
+	if e.ImageURL != "" {
+		c.Image = &Image{
+			URL:       e.ImageURL,
+			LargeURL:  e.ImageGrossURL,
+			XLURL:     e.ImageXL,
+			XXLURL:    e.ImageXXL,
+			Copyright: e.ImageCopyright,
+			AltText:   e.ImageAltText,
+			ChangedAt: timestamp(e.ImageChangedDateTime, e.ImageLastChanged),
+		}
+	}
+	return c
+}
+
+func FromCommitteeDetails(d *v1.CommitteeDetails) *CommitteeDetails {
+	c := &CommitteeDetails{
+		ID:                   d.Id,
+		Name:                 d.CommitteeName,
+		Tasks:                d.Tasks,
+		Contact:              d.Contact,
+		ChairpersonID:        d.ChairpersonID,
+		DeputyChairpersonIDs: append([]string{}, d.DeputyChairpersons...),
+		Members:              make([]Politician, len(d.Members)),
+		News:                 make([]NewsItem, len(d.NewsItems)),
+		URL:                  d.SourceURL,
+		SourceURL:            d.DocumentInfo.DocumentURL,
+		SourceDate:           date(d.DocumentInfo.DocumentStand),
+	}
+	for i := range d.Members {
+		c.Members[i] = *FromPersonListEntry(&d.Members[i])
+	}
+	for i, n := range d.NewsItems {
+		c.News[i] = NewsItem{Title: n.Title, Description: n.Description, PublishedAt: date(n.PublicationDate), URL: n.URL}
+	}
+	if d.ImageURL != "" {
+		c.Image = &Image{URL: d.ImageURL, LargeURL: d.LargeImageURL, Copyright: d.ImageCopyright, AltText: d.ImageAltText}
+	}
+	return c
+}
+
+func constituency(c v1.Constituency) *Constituency {
+	if c.Number == "" && c.Name == "" {
+		return nil
+	}
+	return &Constituency{Number: c.Number, Name: c.Name, URL: c.Url}
+}
+
+// date turns the German dates of upstream into ISO 8601, anything else is
+// passed on as is.
+func date(s string) string {
+	t, err := v1.ParseDate(s)
+	if err != nil {
+		return s
+	}
+	return t.Format(time.DateOnly)
+}
+
+// timestamp turns the German date and time of upstream into RFC 3339, falling
+// back to the date alone.
+func timestamp(dateTime, day string) string {
+	t, err := time.ParseInLocation("02.01.2006 15:04", dateTime, v1.Berlin)
+	if err != nil {
+		return date(day)
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,48 @@
+package v2
+
+import (
+	"testing"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+)
+
+func TestFromPersonListEntryTimes(t *testing.T) {
+	tests := []struct {
+		name, dateTime, day, want string
+	}{
+		{"winter", "01.03.2025 10:00", "01.03.2025", "2025-03-01T10:00:00+01:00"},
+		{"summer", "01.07.2025 10:00", "01.07.2025", "2025-07-01T10:00:00+02:00"},
+		{"only the day", "", "01.07.2025", "2025-07-01"},
+		{"neither", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := FromPersonListEntry(&v1.PersonListEntry{ChangedDateTime: tt.dateTime, LastChanged: tt.day})
+			if p.ChangedAt != tt.want {
+				t.Errorf("ChangedAt = %q, want %q", p.ChangedAt, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromPolitician(t *testing.T) {
+	b := FromPolitician(&v1.Politician{Bio: v1.PoliticianBio{
+		Id:          v1.ID{Value: "1001", Status: "Aktiv"},
+		DateOfBirth: "12.05.1975",
+		ExitDate:    "unbekannt",
+		Memberships: v1.Memberships{
+			LeadCommittees:          []v1.Committee{{Id: "a11", Name: "Haushaltsausschuss"}},
+			RegularMemberCommittees: []v1.Committee{{Id: "a11", Name: "Haushaltsausschuss"}},
+		},
+	}})
+
+	if b.ID != "1001" || b.Status != "Aktiv" || b.BirthDate != "1975-05-12" || b.ExitDate != "unbekannt" {
+		t.Errorf("bio = %+v", b)
+	}
+	if len(b.Memberships) != 2 || b.Memberships[0].Role != RoleLead || b.Memberships[1].Role != RoleMember {
+		t.Errorf("memberships = %+v", b.Memberships)
+	}
+	if b.Websites == nil || b.Photo != nil || b.Constituency != nil {
+		t.Errorf("websites %v, photo %v, constituency %v", b.Websites, b.Photo, b.Constituency)
+	}
+}
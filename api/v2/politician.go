@@ -0,0 +1,91 @@
+package v2
+
+// Politician is an entry of the MdB catalog.
+type Politician struct {
+	ID           string        `json:"id" xml:"id"`
+	Status       string        `json:"status,omitempty" xml:"status,omitempty"`
+	Name         string        `json:"name" xml:"name"`
+	Faction      string        `json:"faction,omitempty" xml:"faction,omitempty"`
+	State        string        `json:"state,omitempty" xml:"state,omitempty"`
+	Constituency *Constituency `json:"constituency,omitempty" xml:"constituency,omitempty"`
+	Elected      string        `json:"elected,omitempty" xml:"elected,omitempty"`
+	Photo        *Image        `json:"photo,omitempty" xml:"photo,omitempty"`
+	URL          string        `json:"url,omitempty" xml:"url,omitempty"`
+	SourceURL    string        `json:"sourceUrl,omitempty" xml:"sourceUrl,omitempty"`
+	ChangedAt    string        `json:"changedAt,omitempty" xml:"changedAt,omitempty"`
+}
+
+// Bio is the full record of an MdB.
+type Bio struct {
+	ID              string        `json:"id" xml:"id"`
+	Status          string        `json:"status,omitempty" xml:"status,omitempty"`
+	FirstName       string        `json:"firstName" xml:"firstName"`
+	LastName        string        `json:"lastName" xml:"lastName"`
+	NobilityTitle   string        `json:"nobilityTitle,omitempty" xml:"nobilityTitle,omitempty"`
+	AcademicTitle   string        `json:"academicTitle,omitempty" xml:"academicTitle,omitempty"`
+	LocationSuffix  string        `json:"locationSuffix,omitempty" xml:"locationSuffix,omitempty"`
+	BirthDate       string        `json:"birthDate,omitempty" xml:"birthDate,omitempty"`
+	ExitDate        string        `json:"exitDate,omitempty" xml:"exitDate,omitempty"`
+	Gender          string        `json:"gender,omitempty" xml:"gender,omitempty"`
+	MaritalStatus   string        `json:"maritalStatus,omitempty" xml:"maritalStatus,omitempty"`
+	Children        string        `json:"children,omitempty" xml:"children,omitempty"`
+	Religion        string        `json:"religion,omitempty" xml:"religion,omitempty"`
+	Education       string        `json:"education,omitempty" xml:"education,omitempty"`
+	HigherEducation string        `json:"higherEducation,omitempty" xml:"higherEducation,omitempty"`
+	Profession      *Profession   `json:"profession,omitempty" xml:"profession,omitempty"`
+	Faction         string        `json:"faction,omitempty" xml:"faction,omitempty"`
+	Party           string        `json:"party,omitempty" xml:"party,omitempty"`
+	State           string        `json:"state,omitempty" xml:"state,omitempty"`
+	Constituency    *Constituency `json:"constituency,omitempty" xml:"constituency,omitempty"`
+	Elected         string        `json:"elected,omitempty" xml:"elected,omitempty"`
+	Biography       string        `json:"biography,omitempty" xml:"biography,omitempty"`
+	Trivia          string        `json:"trivia,omitempty" xml:"trivia,omitempty"`
+	Homepage        string        `json:"homepage,omitempty" xml:"homepage,omitempty"`
+	Websites        []Website     `json:"websites" xml:"websites>website"`
+	Phone           string        `json:"phone,omitempty" xml:"phone,omitempty"`
+	Memberships     []Membership  `json:"memberships" xml:"memberships>membership"`
+	PublishableInfo string        `json:"publishableInfo,omitempty" xml:"publishableInfo,omitempty"`
+	Photo           *Image        `json:"photo,omitempty" xml:"photo,omitempty"`
+	Speeches        *Speeches     `json:"speeches,omitempty" xml:"speeches,omitempty"`
+	URL             string        `json:"url,omitempty" xml:"url,omitempty"`
+	SourceURL       string        `json:"sourceUrl,omitempty" xml:"sourceUrl,omitempty"`
+	SourceDate      string        `json:"sourceDate,omitempty" xml:"sourceDate,omitempty"`
+}
+
+type Constituency struct {
+	Number string `json:"number" xml:"number"`
+	Name   string `json:"name" xml:"name"`
+	URL    string `json:"url,omitempty" xml:"url,omitempty"`
+}
+
+type Profession struct {
+	Field string `json:"field,omitempty" xml:"field,omitempty"`
+	Name  string `json:"name" xml:"name"`
+}
+
+type Website struct {
+	Title string `json:"title" xml:"title"`
+	URL   string `json:"url" xml:"url"`
+}
+
+// Roles of an MdB in a committee.
+const (
+	RoleLead           = "lead"
+	RoleMember         = "member"
+	RoleSubstitute     = "substitute"
+	RoleViceChairOther = "viceChairOther"
+)
+
+// Membership is a role of an MdB in a committee or another body of the
+// Bundestag. Only committees have an ID.
+type Membership struct {
+	CommitteeID string `json:"committeeId,omitempty" xml:"committeeId,omitempty"`
+	Name        string `json:"name" xml:"name"`
+	URL         string `json:"url,omitempty" xml:"url,omitempty"`
+	Role        string `json:"role" xml:"role"`
+}
+
+type Speeches struct {
+	URL string `json:"url,omitempty" xml:"url,omitempty"`
+	RSS string `json:"rss,omitempty" xml:"rss,omitempty"`
+}
@@ -1,17 +1,50 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log/slog"
+	nethttp "net/http"
 	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"connectrpc.com/grpcreflect"
+	"github.com/kyzrfranz/bundestag-api/api/gen/bundestag/v1/bundestagv1connect"
 	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	v2 "github.com/kyzrfranz/bundestag-api/api/v2"
+	"github.com/kyzrfranz/bundestag-api/internal/card"
+	"github.com/kyzrfranz/bundestag-api/internal/chart"
+	"github.com/kyzrfranz/bundestag-api/internal/contact"
 	"github.com/kyzrfranz/bundestag-api/internal/data"
+	"github.com/kyzrfranz/bundestag-api/internal/disclosure"
+	"github.com/kyzrfranz/bundestag-api/internal/events"
+	"github.com/kyzrfranz/bundestag-api/internal/export"
+	"github.com/kyzrfranz/bundestag-api/internal/gql"
+	"github.com/kyzrfranz/bundestag-api/internal/graph"
+	"github.com/kyzrfranz/bundestag-api/internal/history"
 	"github.com/kyzrfranz/bundestag-api/internal/http"
+	"github.com/kyzrfranz/bundestag-api/internal/img"
+	"github.com/kyzrfranz/bundestag-api/internal/linkeddata"
+	"github.com/kyzrfranz/bundestag-api/internal/links"
 	"github.com/kyzrfranz/bundestag-api/internal/proxy"
 	"github.com/kyzrfranz/bundestag-api/internal/rest"
+	"github.com/kyzrfranz/bundestag-api/internal/richtext"
+	"github.com/kyzrfranz/bundestag-api/internal/rpc"
+	"github.com/kyzrfranz/bundestag-api/internal/timeline"
 	"github.com/kyzrfranz/bundestag-api/internal/upstream"
+	"github.com/kyzrfranz/bundestag-api/internal/webhook"
 	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+	"github.com/samber/lo"
+)
+
+const (
+	// v1Deprecated is the release of /v2, which deprecates /v1.
+	v1Deprecated = "2026-10-19"
+	// v1Sunset is the default end of /v1, see V1_SUNSET.
+	v1Sunset = "2027-04-30"
 )
 
 var (
@@ -24,35 +57,270 @@ func main() {
 	logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	constSearchProxyUrl = stringOrEnv("CONSTITUENCY_PROXY_URL", "https://www.bundestag.de/ajax/filterlist/de/533302-533302/plz-ort-autocomplete")
 
+	catalogTTL := durationOrEnv("CATALOG_TTL", 5*time.Minute)
+
 	dataUrl := mustGetUrl("https://www.bundestag.de/xml/v2/mdb/index.xml") // TODO config
-	politicianReader := data.NewCatalogReader[v1.PersonCatalog, v1.PersonListEntry](&upstream.XMLFetcher{Url: dataUrl})
+	politicianReader := data.NewCatalogReader[v1.PersonCatalog, v1.PersonListEntry](upstream.NewCachedFetcher(&upstream.XMLFetcher{Url: dataUrl}, catalogTTL))
 
 	committeeUrl := mustGetUrl("https://www.bundestag.de/xml/v2/ausschuesse/index.xml") // TODO config
-	committeeReader := data.NewCatalogReader[v1.CommitteeCatalog, v1.CommitteeListEntry](&upstream.XMLFetcher{Url: committeeUrl})
+	committeeReader := data.NewCatalogReader[v1.CommitteeCatalog, v1.CommitteeListEntry](upstream.NewCachedFetcher(&upstream.XMLFetcher{Url: committeeUrl}, catalogTTL))
+
+	// Cloud Run appends the client to X-Forwarded-For
+	proxyHops := 0
+	if os.Getenv("K_SERVICE") != "" {
+		proxyHops = 1
+	}
+	proxyHops = intOrEnv("PROXY_HOPS", proxyHops)
+
+	// public root of the API for absolute links, derived per request if
+	// unset, from the forwarded headers only behind proxies
+	publicBase := rest.PublicBase{Forwarded: proxyHops > 0}
+	if s := stringOrEnv("PUBLIC_BASE_URL", ""); s != "" {
+		publicBase.URL = mustGetUrl(s)
+	}
+	ld := linkeddata.NewMapper(publicBase)
+	routes := rest.NewRoutes(publicBase)
+	hal := links.NewBuilder(routes)
+	images := img.NewPipeline(imageStore(), img.Options{
+		WebPQuality: intOrEnv("WEBP_QUALITY", img.DefaultOptions.WebPQuality),
+		JPEGQuality: intOrEnv("JPEG_QUALITY", img.DefaultOptions.JPEGQuality),
+		Presign:     durationOrEnv("IMAGE_PRESIGN", 0),
+	})
 
 	apiServer := http.NewApiServer(8080, logger)
 
 	apiServer.Use(http.MiddlewareRecovery)
 	apiServer.Use(http.MiddlewareCORS)
+	apiServer.Use(publicBase.Vary)
+	// filled as the routes are set up, before the server starts
+	expensive := newExpensiveRequests()
+	apiServer.Use(http.MiddlewareRateLimit(http.RateLimitOptions{
+		Cheap:       http.Budget{PerMinute: intOrEnv("RATE_LIMIT", 300), Burst: intOrEnv("RATE_LIMIT_BURST", 60)},
+		Expensive:   http.Budget{PerMinute: intOrEnv("RATE_LIMIT_EXPENSIVE", 30), Burst: intOrEnv("RATE_LIMIT_EXPENSIVE_BURST", 10)},
+		IsExpensive: expensive.isExpensive,
+		Keys:        stringsOrEnv("API_KEYS"),
+		KeyFactor:   intOrEnv("API_KEY_FACTOR", 10),
+		ProxyHops:   proxyHops,
+	}))
+
+	historyStore := history.NewStore(historyBackend(), intOrEnv("HISTORY_MAX_VERSIONS", 100))
+	historyHandler := history.NewHandler(historyStore)
+
+	politicianDetailRepo := history.NewRecordingRepo(resources.NewDetailRepo[v1.Politician](&politicianReader), historyStore, history.KindBio)
+	politicianCatalogHandler := rest.NewHandler[v1.PersonListEntry](resources.NewCatalogueRepo[v1.PersonListEntry](&politicianReader),
+		rest.WithRepresentation(rest.WebP[v1.PersonListEntry](images)),
+		rest.WithRepresentation(export.Representations(export.PersonListEntries)...),
+		rest.WithRepresentation(linkeddata.Representation(ld.PersonListEntry)),
+		rest.WithLinks(hal.PersonListEntry),
+		rest.WithEmbed("bio", func(req *nethttp.Request, ps []v1.PersonListEntry) func(*v1.PersonListEntry) (any, error) {
+			bios := politicianDetailRepo.Batch(req.Context(), lo.Map(ps, func(p v1.PersonListEntry, _ int) string { return p.Id.Value }))
+			return func(p *v1.PersonListEntry) (any, error) {
+				bio, err := bios(p.Id.Value)
+				if err != nil {
+					return nil, err
+				}
+				return bio, richtext.Politician(req, bio)
+			}
+		}),
+		rest.WithEmbeddedRepresentation(export.Representations(
+			append(export.Expanded(export.PersonListEntries), export.Embed[v1.PersonListEntry]("bio", export.Politicians)...))...))
+	politicianDetailHandler := rest.NewHandler[v1.Politician](politicianDetailRepo, rest.WithTransform(richtext.Politician),
+		rest.WithRepresentation(export.Representations(export.Politicians)...),
+		rest.WithRepresentation(linkeddata.Representation(ld.Politician)),
+		rest.WithLinks(hal.Politician))
+	committeeCatalogueHandler := rest.NewHandler[v1.CommitteeListEntry](resources.NewCatalogueRepo[v1.CommitteeListEntry](&committeeReader),
+		rest.WithRepresentation(export.Representations(export.Committees)...),
+		rest.WithRepresentation(linkeddata.Representation(ld.Committee)),
+		rest.WithRepresentation(rest.WebP[v1.CommitteeListEntry](images)),
+		rest.WithLinks(hal.CommitteeListEntry))
+	committeeDetailRepo := history.NewRecordingRepo(resources.NewDetailRepo[v1.CommitteeDetails](&committeeReader), historyStore, history.KindCommittee)
+	committeeDetailHandler := rest.NewHandler[v1.CommitteeDetails](committeeDetailRepo, rest.WithTransform(richtext.Committee),
+		rest.WithRepresentation(linkeddata.Representation(ld.CommitteeDetails)),
+		rest.WithLinks(hal.CommitteeDetails))
+
+	// the unversioned routes are v1 and also served below /v1, those with a
+	// counterpart in /v2 announce their deprecation
+	deprecated := http.MiddlewareDeprecation(parseDate("v1 deprecation", v1Deprecated), dateOrEnv("V1_SUNSET", v1Sunset), func(r *nethttp.Request) string {
+		return "/v2" + strings.TrimPrefix(r.URL.Path, "/v1")
+	})
+	addV1 := func(path string, hFunc func(w nethttp.ResponseWriter, r *nethttp.Request), mw ...http.Middleware) {
+		apiServer.AddHandler(path, hFunc, mw...)
+		apiServer.AddHandler("/v1"+path, hFunc, mw...)
+	}
+
+	// only the conversion to WebP, the embedded bios and vCards, which read
+	// every bio, are expensive
+	expensive["/politicians"] = func(r *nethttp.Request) bool {
+		return embedsBio(r) || politicianCatalogHandler.Selects(r, "vcf")
+	}
+	expensive["/politicians/{id}"] = func(r *nethttp.Request) bool { return politicianCatalogHandler.Selects(r, "webp") }
+	expensive["/committees/{id}"] = func(r *nethttp.Request) bool { return committeeCatalogueHandler.Selects(r, "webp") }
+	addV1("/politicians", politicianCatalogHandler.List, deprecated)
+	addV1(routes.Route(links.RoutePolitician, "/politicians/{id}"), history.PointInTime(historyHandler, history.KindPolitician, politicianCatalogHandler), deprecated)
+	addV1(routes.Route(links.RoutePoliticianBio, "/politicians/{id}/bio"), history.PointInTime(historyHandler, history.KindBio, politicianDetailHandler), deprecated)
+	addV1("/politicians/{id}/history", historyHandler.History(history.KindPolitician, history.KindBio))
+	photoHandler := rest.NewPhotoHandler(resources.NewCatalogueRepo[v1.PersonListEntry](&politicianReader), images,
+		intsOrEnv("PHOTO_SIZES", []int{32, 48, 64, 96, 128, 192, 256, 384, 512, 768}),
+		// the catalog doesn't list the copyright of the photos
+		rest.WithCopyright(func(req *nethttp.Request, p *v1.PersonListEntry) (string, error) {
+			bio, err := politicianDetailRepo.Get(req.Context(), p.Id.Value)
+			if err != nil {
+				return "", err
+			}
+			return bio.Media.Foto.Copyright, nil
+		}))
+	addV1(routes.Route(links.RoutePoliticianPhoto, "/politicians/{id}/photo"), photoHandler.Get)
+	addV1("/politicians/{id}/photo/meta", photoHandler.Meta)
+	factionColors, err := card.ParseColors(stringOrEnv("FACTION_COLORS", ""))
+	if err != nil {
+		bail("parse faction colors", err)
+	}
+	cards, err := card.NewRenderer(factionColors)
+	if err != nil {
+		bail("create card renderer", err)
+	}
+	politicianCards, err := card.NewHandler(resources.NewCatalogueRepo[v1.PersonListEntry](&politicianReader), images, cards, card.Politician,
+		card.WithData(func(req *nethttp.Request, p *v1.PersonListEntry) (any, error) {
+			return politicianDetailRepo.Get(req.Context(), p.Id.Value)
+		}))
+	if err != nil {
+		bail("create politician cards", err)
+	}
+	addV1("/politicians/{id}/card.png", politicianCards.Get)
+	addV1("/committees", committeeCatalogueHandler.List, deprecated)
+	addV1(routes.Route(links.RouteCommittee, "/committees/{id}"), committeeCatalogueHandler.Get, deprecated)
+	addV1(routes.Route(links.RouteCommitteeDetail, "/committees/{id}/detail"), history.PointInTime(historyHandler, history.KindCommittee, committeeDetailHandler), deprecated)
+	addV1("/committees/{id}/history", historyHandler.History(history.KindCommittee))
+	committeeCards, err := card.NewHandler(resources.NewCatalogueRepo[v1.CommitteeListEntry](&committeeReader), images, cards, card.Committee,
+		card.WithData(func(req *nethttp.Request, c *v1.CommitteeListEntry) (any, error) {
+			return committeeDetailRepo.Get(req.Context(), c.Id)
+		}))
+	if err != nil {
+		bail("create committee cards", err)
+	}
+	addV1("/committees/{id}/card.png", committeeCards.Get)
+	hemicycleHandler := chart.NewHandler(resources.NewCatalogueRepo[v1.PersonListEntry](&politicianReader), committeeDetailRepo, factionColors)
+	addV1("/charts/hemicycle.svg", hemicycleHandler.SVG)
+	addV1("/charts/hemicycle.png", hemicycleHandler.PNG)
+
+	contactHandler := contact.NewHandler(resources.NewCatalogueRepo[v1.PersonListEntry](&politicianReader), politicianDetailRepo, images)
+	addV1("/politicians/{id}/vcard", contactHandler.VCard)
+	addV1("/politicians/{id}/qr", contactHandler.QR)
+	addV1(routes.Route(links.RouteCommitteeMembers, "/committees/{id}/members"), rest.NewNestedHandler(committeeDetailRepo,
+		func(c *v1.CommitteeDetails) []v1.PersonListEntry { return c.Members },
+		append(append(export.Representations(export.PersonListEntries), contactHandler.Representation()), rest.Linked(hal.PersonListEntry)...)...), deprecated)
+	addV1(routes.Route(links.RoutePoliticianCommittees, "/politicians/{id}/committees"), rest.NewNestedHandler(politicianDetailRepo,
+		func(p *v1.Politician) []v1.Committee { return p.Bio.Memberships.Committees() },
+		rest.Linked(hal.Committee)...), deprecated)
+
+	// change detection on the upstream catalogs
+	bus := events.NewBus(logger)
+	watcher := events.NewWatcher(bus, durationOrEnv("REFRESH_INTERVAL", time.Hour), logger,
+		&politicianReader, politicianDetailRepo, &committeeReader, committeeDetailRepo)
+	watcher.RecordTo(historyStore)
+	go watcher.Run(context.Background())
+
+	timelineHandler := timeline.NewHandler(politicianDetailRepo)
+	addV1("/politicians/{id}/timeline", timelineHandler.Get)
+
+	disclosureHandler := disclosure.NewHandler(resources.NewCatalogueRepo[v1.PersonListEntry](&politicianReader), politicianDetailRepo)
+	disclosureEvents, _ := bus.Subscribe(256)
+	go disclosureHandler.Run(context.Background(), disclosureEvents)
+	addV1("/politicians/{id}/disclosures", disclosureHandler.Politician)
+	addV1("/disclosures", disclosureHandler.Search)
 
-	politicianCatalogHandler := rest.NewHandler[v1.PersonListEntry](resources.NewCatalogueRepo[v1.PersonListEntry](&politicianReader))
-	politicianDetailHandler := rest.NewHandler[v1.Politician](resources.NewDetailRepo[v1.Politician](&politicianReader))
-	committeeCatalogueHandler := rest.NewHandler[v1.CommitteeListEntry](resources.NewCatalogueRepo[v1.CommitteeListEntry](&committeeReader))
-	committeeDetailHandler := rest.NewHandler[v1.CommitteeDetails](resources.NewDetailRepo[v1.CommitteeDetails](&committeeReader))
+	graphHandler := graph.NewHandler(resources.NewCatalogueRepo[v1.PersonListEntry](&politicianReader), politicianDetailRepo,
+		resources.NewCatalogueRepo[v1.CommitteeListEntry](&committeeReader), committeeDetailRepo)
+	graphEvents, _ := bus.Subscribe(256)
+	go graphHandler.Run(context.Background(), graphEvents)
+	addV1("/graphs/committees", graphHandler.Committees)
 
-	apiServer.AddHandler("/politicians", politicianCatalogHandler.List)
-	apiServer.AddHandler("/politicians/{id}", politicianCatalogHandler.Get)
-	apiServer.AddHandler("/politicians/{id}/bio", politicianDetailHandler.Get)
-	apiServer.AddHandler("/committees", committeeCatalogueHandler.List)
-	apiServer.AddHandler("/committees/{id}", committeeCatalogueHandler.Get)
-	apiServer.AddHandler("/committees/{id}/detail", committeeDetailHandler.Get)
+	eventStream := events.NewStream(bus, apiServer.ShuttingDown())
+	apiServer.AddHandler("/events", eventStream.Serve)
+
+	//webhooks are only exposed if a management token is configured
+	if webhookToken := stringOrEnv("WEBHOOK_TOKEN", ""); webhookToken != "" {
+		webhookStore, err := webhook.NewStore(resources.NewFileCache(stringOrEnv("WEBHOOK_STORE", "webhooks.json")))
+		if err != nil {
+			bail("read webhook store", err)
+		}
+		webhookEvents, _ := bus.Subscribe(256)
+		go webhook.NewDispatcher(webhookStore, logger).Run(context.Background(), webhookEvents)
+
+		webhookHandler := webhook.NewHandler(webhookStore, webhookToken)
+		apiServer.AddHandler("POST /webhooks", webhookHandler.Create)
+		apiServer.AddHandler("GET /webhooks", webhookHandler.List)
+		apiServer.AddHandler("GET /webhooks/{id}", webhookHandler.Get)
+		apiServer.AddHandler("DELETE /webhooks/{id}", webhookHandler.Delete)
+		apiServer.AddHandler("GET /webhooks/{id}/deliveries", webhookHandler.Deliveries)
+	}
 
 	apiServer.AddStaticHandler("/", "./static")
 
 	//proxy for zipcode search
-	cProxy := proxy.NewConstituencyProxy(constSearchProxyUrl, resources.NewCatalogueRepo[v1.PersonListEntry](&politicianReader))
-	apiServer.AddHandler("/constituencies/{zipcode}", cProxy.ConstituencySearch)
-	apiServer.AddHandler("/constituencies/{zipcode}/politicians", cProxy.ConstituencyPoliticianSearch)
+	cProxy := proxy.NewConstituencyProxy(constSearchProxyUrl, resources.NewCatalogueRepo[v1.PersonListEntry](&politicianReader),
+		append(rest.Linked(hal.PersonListEntry), contactHandler.Representation())...)
+	addV1("/constituencies/{zipcode}", cProxy.ConstituencySearch, deprecated)
+	addV1("/constituencies/{zipcode}/politicians", cProxy.ConstituencyPoliticianSearch, deprecated)
+
+	// v2 serves the same resources in a consistently named model
+	routesV2 := rest.NewRoutes(publicBase)
+	halV2 := links.NewBuilderV2(routesV2, hal)
+	bioRepoV2 := resources.NewMappedRepo(politicianDetailRepo, v2.FromPolitician)
+	detailRepoV2 := resources.NewMappedRepo(committeeDetailRepo, v2.FromCommitteeDetails)
+	politicianHandlerV2 := rest.NewHandler[v2.Politician](resources.NewMappedRepo(resources.NewCatalogueRepo[v1.PersonListEntry](&politicianReader), v2.FromPersonListEntry),
+		rest.WithLinks(halV2.Politician),
+		rest.WithEmbed("bio", func(req *nethttp.Request, ps []v2.Politician) func(*v2.Politician) (any, error) {
+			bios := politicianDetailRepo.Batch(req.Context(), lo.Map(ps, func(p v2.Politician, _ int) string { return p.ID }))
+			return func(p *v2.Politician) (any, error) {
+				politician, err := bios(p.ID)
+				if err != nil {
+					return nil, err
+				}
+				bio := v2.FromPolitician(politician)
+				return bio, richtext.Bio(req, bio)
+			}
+		}))
+	bioHandlerV2 := rest.NewHandler[v2.Bio](bioRepoV2, rest.WithTransform(richtext.Bio), rest.WithLinks(halV2.Bio))
+	committeeHandlerV2 := rest.NewHandler[v2.Committee](resources.NewMappedRepo(resources.NewCatalogueRepo[v1.CommitteeListEntry](&committeeReader), v2.FromCommitteeListEntry),
+		rest.WithLinks(halV2.Committee))
+	detailHandlerV2 := rest.NewHandler[v2.CommitteeDetails](detailRepoV2, rest.WithTransform(richtext.CommitteeDetails), rest.WithLinks(halV2.CommitteeDetails))
+
+	expensive["/v2/politicians"] = func(r *nethttp.Request) bool {
+		return embedsBio(r) || politicianHandlerV2.Selects(r, "vcf")
+	}
+	apiServer.AddHandler("/v2/politicians", politicianHandlerV2.List)
+	apiServer.AddHandler(routesV2.Route(links.RoutePolitician, "/v2/politicians/{id}"), politicianHandlerV2.Get)
+	apiServer.AddHandler(routesV2.Route(links.RoutePoliticianBio, "/v2/politicians/{id}/bio"), bioHandlerV2.Get)
+	apiServer.AddHandler(routesV2.Route(links.RoutePoliticianCommittees, "/v2/politicians/{id}/committees"), rest.NewNestedHandler(bioRepoV2,
+		func(b *v2.Bio) []v2.Membership { return b.Memberships },
+		rest.Linked(halV2.Membership)...))
+	apiServer.AddHandler("/v2/committees", committeeHandlerV2.List)
+	apiServer.AddHandler(routesV2.Route(links.RouteCommittee, "/v2/committees/{id}"), committeeHandlerV2.Get)
+	apiServer.AddHandler(routesV2.Route(links.RouteCommitteeDetail, "/v2/committees/{id}/detail"), detailHandlerV2.Get)
+	apiServer.AddHandler(routesV2.Route(links.RouteCommitteeMembers, "/v2/committees/{id}/members"), rest.NewNestedHandler(detailRepoV2,
+		func(c *v2.CommitteeDetails) []v2.Politician { return c.Members },
+		rest.Linked(halV2.Politician)...))
+	apiServer.AddHandler("/v2/constituencies/{zipcode}", cProxy.ConstituencySearchV2)
+	apiServer.AddHandler("/v2/constituencies/{zipcode}/politicians", cProxy.ConstituencyPoliticianSearchV2(rest.Linked(halV2.Politician)...))
+
+	graphqlHandler, err := gql.NewHandler(resources.NewCatalogueRepo[v1.PersonListEntry](&politicianReader), politicianDetailRepo,
+		resources.NewCatalogueRepo[v1.CommitteeListEntry](&committeeReader), committeeDetailRepo, cProxy,
+		gql.WithTransforms(richtext.Politician, richtext.Committee),
+		gql.WithLimits(gql.Limits{MaxDepth: intOrEnv("GRAPHQL_MAX_DEPTH", 10), MaxComplexity: intOrEnv("GRAPHQL_MAX_COMPLEXITY", 5000)}))
+	if err != nil {
+		bail("build graphql schema", err)
+	}
+	apiServer.AddHandler("GET /graphql", graphqlHandler.ServeHTTP)
+	apiServer.AddHandler("POST /graphql", graphqlHandler.ServeHTTP)
+
+	// gRPC, gRPC-Web and Connect on the same h2c listener
+	rpcService := rpc.NewService(resources.NewCatalogueRepo[v1.PersonListEntry](&politicianReader), politicianDetailRepo,
+		resources.NewCatalogueRepo[v1.CommitteeListEntry](&committeeReader), committeeDetailRepo, cProxy, bus, apiServer.ShuttingDown(), logger)
+	apiServer.AddHttpHandler(bundestagv1connect.NewBundestagServiceHandler(rpcService))
+	reflector := grpcreflect.NewStaticReflector(bundestagv1connect.BundestagServiceName)
+	apiServer.AddHttpHandler(grpcreflect.NewHandlerV1(reflector))
+	apiServer.AddHttpHandler(grpcreflect.NewHandlerV1Alpha(reflector))
 
 	apiServer.ListenAndServe()
 }
@@ -62,6 +330,63 @@ func bail(stage string, err error) {
 	os.Exit(1)
 }
 
+// imageStore keeps images in S3_BUCKET if set, otherwise in IMAGE_DIR.
+func imageStore() img.Store {
+	if bucket := stringOrEnv("S3_BUCKET", ""); bucket != "" {
+		return s3Store("image", bucket, "")
+	}
+
+	// the file system of Cloud Run is kept in the memory of the instance
+	cacheMB := 512
+	if os.Getenv("K_SERVICE") != "" {
+		cacheMB = 32
+	}
+	store, err := img.NewFileStore(stringOrEnv("IMAGE_DIR", ".img"), int64(intOrEnv("IMAGE_CACHE_MB", cacheMB))<<20)
+	if err != nil {
+		bail("create image store", err)
+	}
+	return store
+}
+
+// historyBackend keeps the history in the bucket of the images or in
+// HISTORY_DIR. On Cloud Run the file system is kept in the memory of the
+// instance and lost on restart, so HISTORY_DIR must be a mounted volume there.
+func historyBackend() history.Backend {
+	if bucket := stringOrEnv("S3_BUCKET", ""); bucket != "" {
+		return s3Store("history", bucket, "history/")
+	}
+
+	dir, ok := os.LookupEnv("HISTORY_DIR")
+	if !ok && os.Getenv("K_SERVICE") != "" {
+		bail("create history store", errors.New("set S3_BUCKET or mount a volume at HISTORY_DIR"))
+	}
+	if dir == "" {
+		dir = ".history"
+	}
+	store, err := img.NewFileStore(dir, 0)
+	if err != nil {
+		bail("create history store", err)
+	}
+	return store
+}
+
+func s3Store(what, bucket, prefix string) *img.S3Store {
+	endpoint := mustGetUrl(stringOrEnv("S3_ENDPOINT", "https://s3.amazonaws.com"))
+	store, err := img.NewS3Store(context.Background(), img.S3Options{
+		Endpoint:  endpoint.Host,
+		Bucket:    bucket,
+		Region:    stringOrEnv("S3_REGION", ""),
+		AccessKey: stringOrEnv("S3_ACCESS_KEY", ""),
+		SecretKey: stringOrEnv("S3_SECRET_KEY", ""),
+		Prefix:    stringOrEnv("S3_PREFIX", "") + prefix,
+		Insecure:  endpoint.Scheme == "http",
+	})
+	if err != nil {
+		bail("create "+what+" store", err)
+	}
+	return store
+}
+
 func mustGetUrl(s string) *url.URL {
 	parsedUrl, err := url.Parse(s)
 	if err != nil {
@@ -71,6 +396,66 @@ func mustGetUrl(s string) *url.URL {
 	return parsedUrl
 }
 
+// expensiveRoutes fetch bios or go upstream on every request, convert images
+// or scan all MdBs. The routes of v1 are listed once, for both prefixes.
+var expensiveRoutes = []string{
+	"/politicians/{id}/bio",
+	"/politicians/{id}/committees",
+	"/politicians/{id}/photo",
+	"/politicians/{id}/photo/meta",
+	"/politicians/{id}/card.png",
+	"/politicians/{id}/timeline",
+	"/politicians/{id}/vcard",
+	"/politicians/{id}/qr",
+	"/politicians/{id}/disclosures",
+	"/disclosures",
+	"/committees/{id}/detail",
+	"/committees/{id}/members",
+	"/committees/{id}/card.png",
+	"/charts/hemicycle.svg",
+	"/charts/hemicycle.png",
+	"/graphs/committees",
+	"/constituencies/{zipcode}",
+	"/constituencies/{zipcode}/politicians",
+	"/v2/politicians/{id}/bio",
+	"/v2/politicians/{id}/committees",
+	"/v2/committees/{id}/detail",
+	"/v2/committees/{id}/members",
+	"/v2/constituencies/{zipcode}",
+	"/v2/constituencies/{zipcode}/politicians",
+	"GET /graphql",
+	"POST /graphql",
+	"/" + bundestagv1connect.BundestagServiceName + "/",
+}
+
+// expensiveRequests tells the requests to charge against the expensive
+// budget, by the pattern of their route. The routes of v1 are looked up
+// without the /v1 prefix.
+type expensiveRequests map[string]func(r *nethttp.Request) bool
+
+func newExpensiveRequests() expensiveRequests {
+	e := expensiveRequests{}
+	for _, pattern := range expensiveRoutes {
+		e[pattern] = func(*nethttp.Request) bool { return true }
+	}
+	return e
+}
+
+func (e expensiveRequests) isExpensive(r *nethttp.Request) bool {
+	f := e[strings.TrimPrefix(r.Pattern, "/v1")]
+	return f != nil && f(r)
+}
+
+// embedsBio tells list requests that fetch the bio of every MdB.
+func embedsBio(r *nethttp.Request) bool {
+	for _, embed := range strings.Split(r.URL.Query().Get("embed"), ",") {
+		if strings.TrimSpace(embed) == "bio" {
+			return true
+		}
+	}
+	return false
+}
+
 func stringOrEnv(key string, defaultVal string) (s string) {
 	s = os.Getenv(key)
 	if s != "" {
@@ -79,3 +464,78 @@ func stringOrEnv(key string, defaultVal string) (s string) {
 
 	return defaultVal
 }
+
+func durationOrEnv(key string, defaultVal time.Duration) time.Duration {
+	s := os.Getenv(key)
+	if s == "" {
+		return defaultVal
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		bail("parse "+key, err)
+	}
+
+	return d
+}
+
+func dateOrEnv(key string, defaultVal string) time.Time {
+	s := os.Getenv(key)
+	if s == "" {
+		return parseDate(key, defaultVal)
+	}
+
+	return parseDate(key, s)
+}
+
+func parseDate(name string, s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		bail("parse "+name, err)
+	}
+
+	return t
+}
+
+func intOrEnv(key string, defaultVal int) int {
+	s := os.Getenv(key)
+	if s == "" {
+		return defaultVal
+	}
+
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		bail("parse "+key, err)
+	}
+
+	return i
+}
+
+func stringsOrEnv(key string) []string {
+	var ss []string
+	for _, f := range strings.Split(os.Getenv(key), ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			ss = append(ss, f)
+		}
+	}
+
+	return ss
+}
+
+func intsOrEnv(key string, defaultVal []int) []int {
+	s := os.Getenv(key)
+	if s == "" {
+		return defaultVal
+	}
+
+	var ints []int
+	for _, f := range strings.Split(s, ",") {
+		i, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil {
+			bail("parse "+key, err)
+		}
+		ints = append(ints, i)
+	}
+
+	return ints
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+)
+
+func TestExpensiveRequests(t *testing.T) {
+	const chrome = "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.7"
+	const chromeImage = "image/avif,image/webp,image/apng,image/svg+xml,image/*,*/*;q=0.8"
+
+	handler := rest.NewHandler[v1.PersonListEntry](nil, rest.WithRepresentation(rest.WebP[v1.PersonListEntry](nil)))
+	expensive := newExpensiveRequests()
+	expensive["/politicians"] = embedsBio
+	expensive["/politicians/{id}"] = func(r *nethttp.Request) bool { return handler.Selects(r, "webp") }
+
+	tests := []struct {
+		pattern string
+		target  string
+		accept  string
+		want    bool
+	}{
+		{"/politicians", "/politicians", "", false},
+		{"/politicians", "/politicians?embed=bio", "", true},
+		{"/v1/politicians", "/v1/politicians?embed=committees,bio", "", true},
+		{"/politicians/{id}", "/politicians/1001", "", false},
+		{"/politicians/{id}", "/politicians/1001", chrome, false},
+		{"/politicians/{id}", "/politicians/1001", chromeImage, true},
+		{"/v1/politicians/{id}", "/v1/politicians/1001?format=webp", "", true},
+		{"/politicians/{id}/bio", "/politicians/1001/bio", "", true},
+		{"/v1/disclosures", "/v1/disclosures?q=GmbH", "", true},
+		{"/v2/politicians/{id}/bio", "/v2/politicians/1001/bio", "", true},
+		{"/v2/politicians/{id}", "/v2/politicians/1001", "", false},
+		{"POST /graphql", "/graphql", "", true},
+		{"/bundestag.v1.BundestagService/", "/bundestag.v1.BundestagService/ListPoliticians", "", true},
+		{"/politicians/{id}/history", "/politicians/1001/history", "", false},
+		{"/committees/{id}/members", "/committees/a11/members?format=vcf", "", true},
+		{"/v2/constituencies/{zipcode}/politicians", "/v2/constituencies/10117/politicians?format=vcf", "", true},
+		{"/events", "/events", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			r := httptest.NewRequest(nethttp.MethodGet, tt.target, nil)
+			r.Pattern = tt.pattern
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			if got := expensive.isExpensive(r); got != tt.want {
+				t.Errorf("isExpensive(%s, %q) = %v, want %v", tt.target, tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestV1Deprecation(t *testing.T) {
+	deprecated := parseDate("v1 deprecation", v1Deprecated)
+	sunset := dateOrEnv("V1_SUNSET", v1Sunset)
+	if !deprecated.Before(sunset) {
+		t.Errorf("v1 is deprecated on %v, after its sunset on %v", deprecated, sunset)
+	}
+
+	t.Setenv("V1_SUNSET", "2027-12-31")
+	if got := dateOrEnv("V1_SUNSET", v1Sunset); got.Format(time.DateOnly) != "2027-12-31" {
+		t.Errorf("sunset = %v, want the date of V1_SUNSET", got)
+	}
+}
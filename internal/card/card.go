@@ -0,0 +1,359 @@
+package card
+
+import (
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"text/template"
+
+	"github.com/kyzrfranz/bundestag-api/internal/img"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Size of the cards as recommended for Open Graph images
+const (
+	Width  = 1200
+	Height = 630
+)
+
+// layout of the cards, the photo fills the left side
+const (
+	photoWidth  = 420
+	accentWidth = 16
+	margin      = 64
+	titleSize   = 64
+	lineSize    = 36
+	labelSize   = 28
+	creditSize  = 16
+)
+
+var (
+	background = color.RGBA{0xf7, 0xf7, 0xf5, 0xff}
+	foreground = color.RGBA{0x1a, 0x1a, 0x1a, 0xff}
+	muted      = color.RGBA{0x5c, 0x5c, 0x5c, 0xff}
+)
+
+// Template describes what a card shows. The texts are text/templates
+// executed with the resource, those that come out empty are left out.
+type Template struct {
+	// Label is set in a badge of the accent color, e.g. the faction.
+	Label string
+	Title string
+	Lines []string
+	// Accent is the key of the accent color in Colors, e.g. the faction.
+	Accent string
+	// Credit is the copyright of the photo, printed on top of it.
+	Credit string
+}
+
+// Politician is the card of an MdB, executed with v1.Politician.
+var Politician = Template{
+	Label: `{{.Bio.Faction}}`,
+	Title: `{{with .Bio.AcademicTitle}}{{.}} {{end}}{{.Bio.FirstName}} {{with .Bio.NobilityTitle}}{{.}} {{end}}{{.Bio.LastName}}`,
+	Lines: []string{
+		`{{with .Bio.Constituency}}{{if .Number}}Wahlkreis {{.Number}}{{with .Name}}: {{.}}{{end}}{{end}}{{end}}`,
+		`{{.Bio.State}}`,
+	},
+	Accent: `{{.Bio.Faction}}`,
+	Credit: `{{with .Media.Foto.Copyright}}Foto: {{.}}{{end}}`,
+}
+
+// Committee is the card of a committee, executed with v1.CommitteeDetails.
+var Committee = Template{
+	Label: `Ausschuss`,
+	Title: `{{.CommitteeName}}`,
+	Lines: []string{
+		`{{with .Members}}{{len .}} Mitglieder{{end}}`,
+	},
+	Credit: `{{with .ImageCopyright}}Foto: {{.}}{{end}}`,
+}
+
+// content is a Template executed with a resource.
+type content struct {
+	label, title, accent, credit string
+	lines                        []string
+}
+
+type parsedTemplate struct {
+	label, title, accent, credit *template.Template
+	lines                        []*template.Template
+}
+
+func (t Template) parse() (*parsedTemplate, error) {
+	parse := func(name, text string) (*template.Template, error) {
+		tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s of card: %w", name, err)
+		}
+		return tmpl, nil
+	}
+
+	p := &parsedTemplate{}
+	var err error
+	if p.label, err = parse("label", t.Label); err != nil {
+		return nil, err
+	}
+	if p.title, err = parse("title", t.Title); err != nil {
+		return nil, err
+	}
+	if p.accent, err = parse("accent", t.Accent); err != nil {
+		return nil, err
+	}
+	if p.credit, err = parse("credit", t.Credit); err != nil {
+		return nil, err
+	}
+	for i, line := range t.Lines {
+		tmpl, err := parse(fmt.Sprintf("line %d", i+1), line)
+		if err != nil {
+			return nil, err
+		}
+		p.lines = append(p.lines, tmpl)
+	}
+	return p, nil
+}
+
+func (p *parsedTemplate) execute(data any) (content, error) {
+	execute := func(tmpl *template.Template) (string, error) {
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			return "", fmt.Errorf("failed to execute %s of card: %w", tmpl.Name(), err)
+		}
+		return strings.Join(strings.Fields(sb.String()), " "), nil
+	}
+
+	var c content
+	var err error
+	if c.label, err = execute(p.label); err != nil {
+		return content{}, err
+	}
+	if c.title, err = execute(p.title); err != nil {
+		return content{}, err
+	}
+	if c.accent, err = execute(p.accent); err != nil {
+		return content{}, err
+	}
+	if c.credit, err = execute(p.credit); err != nil {
+		return content{}, err
+	}
+	for _, tmpl := range p.lines {
+		line, err := execute(tmpl)
+		if err != nil {
+			return content{}, err
+		}
+		if line != "" {
+			c.lines = append(c.lines, line)
+		}
+	}
+	return c, nil
+}
+
+// hash identifies the content of a card and its photo, so it's drawn again
+// once either changes. photo is the zero Original for cards without one.
+func (c content) hash(accent color.RGBA, photo img.Original) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00%v\x00%s\x00%s\x00%d", c.label, c.title, c.credit, strings.Join(c.lines, "\x00"), accent,
+		photo.SourceURL, photo.Version, photo.Fetched.Unix())
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+// Renderer draws cards with the Go fonts, which are embedded and cover
+// the German alphabet.
+type Renderer struct {
+	colors  Colors
+	regular *opentype.Font
+	bold    *opentype.Font
+}
+
+func NewRenderer(colors Colors) (*Renderer, error) {
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse font: %w", err)
+	}
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse font: %w", err)
+	}
+	return &Renderer{colors: colors, regular: regular, bold: bold}, nil
+}
+
+// draw renders c, with photo on the left if there is one.
+func (r *Renderer) draw(c content, photo image.Image) (image.Image, error) {
+	accent := r.colors.Of(c.accent)
+	dst := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	photoRect := image.Rect(0, 0, photoWidth, Height)
+	if photo != nil {
+		m := img.Resize(photo, img.Variant{Width: photoWidth, Height: Height, Fit: img.FitSmart})
+		draw.Draw(dst, photoRect, m, m.Bounds().Min, draw.Src)
+	} else {
+		draw.Draw(dst, photoRect, image.NewUniform(tint(accent)), image.Point{}, draw.Src)
+	}
+	draw.Draw(dst, image.Rect(photoWidth, 0, photoWidth+accentWidth, Height), image.NewUniform(accent), image.Point{}, draw.Src)
+
+	faces, err := r.faces()
+	if err != nil {
+		return nil, err
+	}
+	defer faces.close()
+
+	left := photoWidth + accentWidth + margin
+	textWidth := Width - left - margin
+
+	// the title takes at most three lines, shrinking if it doesn't fit
+	title, titleFace := wrap(faces.title, c.title, textWidth), faces.title
+	if len(title) > 2 {
+		title, titleFace = wrap(faces.smallTitle, c.title, textWidth), faces.smallTitle
+	}
+	if len(title) > 3 {
+		title = append(title[:2], ellipsis(titleFace, strings.Join(title[2:], " "), textWidth))
+	}
+
+	labelHeight := 0
+	if c.label != "" {
+		labelHeight = lineHeight(faces.label)*3/2 + margin/2
+	}
+	blockHeight := labelHeight + len(title)*lineHeight(titleFace)
+	if len(c.lines) > 0 {
+		blockHeight += margin/2 + len(c.lines)*lineHeight(faces.line)
+	}
+	y := max(margin, (Height-blockHeight)/2)
+
+	if c.label != "" {
+		label := ellipsis(faces.label, c.label, textWidth-margin/2)
+		padding := lineHeight(faces.label) / 4
+		badge := image.Rect(left, y, left+font.MeasureString(faces.label, label).Ceil()+2*padding*2, y+lineHeight(faces.label)*3/2)
+		draw.Draw(dst, badge, image.NewUniform(accent), image.Point{}, draw.Src)
+		text(dst, faces.label, contrast(accent), badge.Min.X+padding*2, badge.Min.Y+padding+ascent(faces.label), label)
+		y += labelHeight
+	}
+	for _, line := range title {
+		text(dst, titleFace, foreground, left, y+ascent(titleFace), line)
+		y += lineHeight(titleFace)
+	}
+	if len(c.lines) > 0 {
+		y += margin / 2
+	}
+	for _, line := range c.lines {
+		text(dst, faces.line, muted, left, y+ascent(faces.line), ellipsis(faces.line, line, textWidth))
+		y += lineHeight(faces.line)
+	}
+
+	if c.credit != "" && photo != nil {
+		credit := ellipsis(faces.credit, c.credit, photoWidth-2*creditSize)
+		w := font.MeasureString(faces.credit, credit).Ceil()
+		box := image.Rect(photoWidth-w-creditSize, Height-lineHeight(faces.credit)-creditSize/2, photoWidth, Height)
+		draw.Draw(dst, box, image.NewUniform(color.RGBA{0, 0, 0, 0x99}), image.Point{}, draw.Over)
+		text(dst, faces.credit, color.White, box.Min.X+creditSize/2, box.Min.Y+creditSize/4+ascent(faces.credit), credit)
+	}
+	return dst, nil
+}
+
+type faces struct {
+	title, smallTitle, line, label, credit font.Face
+}
+
+func (r *Renderer) faces() (*faces, error) {
+	face := func(f *opentype.Font, size float64) (font.Face, error) {
+		face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create font face: %w", err)
+		}
+		return face, nil
+	}
+
+	fs := &faces{}
+	var err error
+	for _, f := range []struct {
+		face *font.Face
+		font *opentype.Font
+		size float64
+	}{
+		{&fs.title, r.bold, titleSize},
+		{&fs.smallTitle, r.bold, titleSize * 3 / 4},
+		{&fs.line, r.regular, lineSize},
+		{&fs.label, r.bold, labelSize},
+		{&fs.credit, r.regular, creditSize},
+	} {
+		if *f.face, err = face(f.font, f.size); err != nil {
+			fs.close()
+			return nil, err
+		}
+	}
+	return fs, nil
+}
+
+func (fs *faces) close() {
+	for _, f := range []font.Face{fs.title, fs.smallTitle, fs.line, fs.label, fs.credit} {
+		if f != nil {
+			_ = f.Close()
+		}
+	}
+}
+
+func text(dst draw.Image, face font.Face, c color.Color, x, y int, s string) {
+	d := font.Drawer{Dst: dst, Src: image.NewUniform(c), Face: face, Dot: fixed.P(x, y)}
+	d.DrawString(s)
+}
+
+func ascent(face font.Face) int {
+	return face.Metrics().Ascent.Ceil()
+}
+
+func lineHeight(face font.Face) int {
+	return face.Metrics().Height.Ceil()
+}
+
+// wrap breaks s into lines no wider than width, words wider than that stay
+// whole.
+func wrap(face font.Face, s string, width int) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(s) {
+		if line == "" {
+			line = word
+		} else if font.MeasureString(face, line+" "+word).Ceil() <= width {
+			line += " " + word
+		} else {
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// ellipsis cuts s off to fit into width.
+func ellipsis(face font.Face, s string, width int) string {
+	if font.MeasureString(face, s).Ceil() <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && font.MeasureString(face, string(runes)+"…").Ceil() > width {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimSpace(string(runes)) + "…"
+}
+
+// tint lightens c, for cards without photo.
+func tint(c color.RGBA) color.RGBA {
+	mix := func(v uint8) uint8 { return uint8((int(v) + 3*0xff) / 4) }
+	return color.RGBA{mix(c.R), mix(c.G), mix(c.B), 0xff}
+}
+
+// contrast returns black or white, whichever is easier to read on c.
+func contrast(c color.RGBA) color.Color {
+	if 299*int(c.R)+587*int(c.G)+114*int(c.B) > 150_000 {
+		return color.Black
+	}
+	return color.White
+}
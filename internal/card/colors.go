@@ -0,0 +1,78 @@
+package card
+
+import (
+	"fmt"
+	"image/color"
+	"maps"
+	"strconv"
+	"strings"
+)
+
+// Colors maps factions to their colors on cards and charts. Keys are
+// compared case-insensitively, "default" is used for everything else.
+type Colors map[string]color.RGBA
+
+// DefaultColors are the colors the factions use themselves.
+var DefaultColors = Colors{
+	"default":               {0x00, 0x5a, 0x9c, 0xff},
+	"spd":                   {0xe3, 0x00, 0x0f, 0xff},
+	"cdu/csu":               {0x15, 0x15, 0x18, 0xff},
+	"bündnis 90/die grünen": {0x1a, 0xa0, 0x37, 0xff},
+	"fdp":                   {0xff, 0xed, 0x00, 0xff},
+	"afd":                   {0x00, 0x9e, 0xe0, 0xff},
+	"die linke":             {0xbe, 0x30, 0x75, 0xff},
+	"bsw":                   {0x79, 0x23, 0x51, 0xff},
+	"fraktionslos":          {0x99, 0x99, 0x99, 0xff},
+}
+
+// ParseColors overrides DefaultColors with a list like
+// "SPD=#e3000f,default=#005a9c".
+func ParseColors(s string) (Colors, error) {
+	return DefaultColors.With(s)
+}
+
+// With returns a copy of c overridden with a list like ParseColors takes.
+func (c Colors) With(s string) (Colors, error) {
+	colors := maps.Clone(c)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, hex, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid color %q, expected faction=#rrggbb", pair)
+		}
+		c, err := parseHex(strings.TrimSpace(hex))
+		if err != nil {
+			return nil, fmt.Errorf("invalid color of %s: %w", key, err)
+		}
+		colors[strings.ToLower(strings.TrimSpace(key))] = c
+	}
+	return colors, nil
+}
+
+// Of returns the color of key, or the default color.
+func (c Colors) Of(key string) color.RGBA {
+	if col, ok := c[strings.ToLower(key)]; ok && key != "" {
+		return col
+	}
+	if col, ok := c["default"]; ok {
+		return col
+	}
+	return DefaultColors["default"]
+}
+
+func parseHex(s string) (color.RGBA, error) {
+	s, ok := strings.CutPrefix(s, "#")
+	if !ok || len(s) != 6 && len(s) != 3 {
+		return color.RGBA{}, fmt.Errorf("expected #rrggbb, got %q", s)
+	}
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("expected #rrggbb, got %q", s)
+	}
+	return color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 0xff}, nil
+}
@@ -0,0 +1,49 @@
+package card
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestParseColors(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		key     string
+		want    color.RGBA
+		wantErr bool
+	}{
+		{"empty keeps the defaults", "", "SPD", DefaultColors["spd"], false},
+		{"override", "SPD=#112233", "spd", color.RGBA{0x11, 0x22, 0x33, 0xff}, false},
+		{"case-insensitive keys", "Die Linke=#ABCDEF", "DIE LINKE", color.RGBA{0xab, 0xcd, 0xef, 0xff}, false},
+		{"three digits", "fdp=#f0a", "FDP", color.RGBA{0xff, 0x00, 0xaa, 0xff}, false},
+		{"spaces and empty pairs", " afd = #000000 ,, ", "AfD", color.RGBA{0, 0, 0, 0xff}, false},
+		{"default", "default=#fff", "Unbekannt", color.RGBA{0xff, 0xff, 0xff, 0xff}, false},
+		{"without default", "", "", DefaultColors["default"], false},
+		{"no hash", "spd=112233", "", color.RGBA{}, true},
+		{"no value", "spd", "", color.RGBA{}, true},
+		{"wrong length", "spd=#12345", "", color.RGBA{}, true},
+		{"not hex", "spd=#12345g", "", color.RGBA{}, true},
+		{"signed", "spd=#+12345", "", color.RGBA{}, true},
+		{"name", "spd=red", "", color.RGBA{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			colors, err := ParseColors(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseColors = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := colors.Of(tt.key); got != tt.want {
+				t.Errorf("Of(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+
+	// the defaults aren't changed by overrides
+	if _, err := ParseColors("spd=#000"); err != nil || DefaultColors["spd"] != (color.RGBA{0xe3, 0x00, 0x0f, 0xff}) {
+		t.Errorf("DefaultColors changed to %v, %v", DefaultColors["spd"], err)
+	}
+}
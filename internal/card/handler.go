@@ -0,0 +1,109 @@
+package card
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"net/http"
+
+	"github.com/kyzrfranz/bundestag-api/internal/img"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+// Data returns what the template of a card is executed with.
+type Data[T any] func(req *http.Request, res *T) (any, error)
+
+// Handler serves the cards of the resources of a repository as PNG. They
+// are cached with the variants of the photo of the resource, and drawn
+// again once their text or the photo changes.
+type Handler[T img.Source] struct {
+	repo     resources.Repository[T]
+	pipeline *img.Pipeline
+	renderer *Renderer
+	tmpl     *parsedTemplate
+	data     Data[T]
+}
+
+type Option[T img.Source] func(h *Handler[T])
+
+// WithData executes the template with something else than the resource,
+// e.g. the details of a catalog entry.
+func WithData[T img.Source](data Data[T]) Option[T] {
+	return func(h *Handler[T]) {
+		h.data = data
+	}
+}
+
+func NewHandler[T img.Source](repo resources.Repository[T], pipeline *img.Pipeline, renderer *Renderer, tmpl Template, opts ...Option[T]) (*Handler[T], error) {
+	parsed, err := tmpl.parse()
+	if err != nil {
+		return nil, err
+	}
+	h := &Handler[T]{repo: repo, pipeline: pipeline, renderer: renderer, tmpl: parsed}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h, nil
+}
+
+// Get serves the card of the resource {id}.
+func (h *Handler[T]) Get(w http.ResponseWriter, req *http.Request) {
+	id := req.PathValue("id")
+	res, err := h.repo.Get(req.Context(), id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var data any = *res
+	if h.data != nil {
+		if data, err = h.data(req, res); err != nil {
+			fmt.Printf("failed to get card data: %v\n", err)
+			http.Error(w, "Failed to get card data", http.StatusBadGateway)
+			return
+		}
+	}
+	c, err := h.tmpl.execute(data)
+	if err != nil {
+		fmt.Printf("failed to execute card template: %v\n", err)
+		http.Error(w, "Failed to render card", http.StatusInternalServerError)
+		return
+	}
+
+	// a card without photo is better than none, but not if upstream failed
+	hasPhoto := true
+	orig, err := h.pipeline.Original(req.Context(), *res, id)
+	if errors.Is(err, img.ErrNoImage) {
+		hasPhoto = false
+	} else if err != nil {
+		cardError(w, err)
+		return
+	}
+
+	name := fmt.Sprintf("%s-card-%s.png", id, c.hash(h.renderer.colors.Of(c.accent), orig))
+	im, err := h.pipeline.Render(req.Context(), name, orig, img.FormatPNG, func() (image.Image, error) {
+		var photo image.Image
+		if hasPhoto {
+			if photo, _, err = h.pipeline.Load(req.Context(), *res, id); err != nil {
+				return nil, err
+			}
+		}
+		return h.renderer.draw(c, photo)
+	})
+	if err != nil {
+		cardError(w, err)
+		return
+	}
+	rest.ServeImage(w, req, h.pipeline, im)
+}
+
+func cardError(w http.ResponseWriter, err error) {
+	if errors.Is(err, img.ErrUpstream) {
+		fmt.Printf("failed to fetch image: %v\n", err)
+		http.Error(w, "Failed to fetch image", http.StatusBadGateway)
+		return
+	}
+	fmt.Printf("failed to render card: %v\n", err)
+	http.Error(w, "Failed to render card", http.StatusInternalServerError)
+}
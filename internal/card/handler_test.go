@@ -0,0 +1,146 @@
+package card
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/img"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+type item struct {
+	Name     string
+	Faction  string
+	PhotoURL string
+	Version  string
+}
+
+func (i item) Images() []v1.Image {
+	if i.PhotoURL == "" {
+		return nil
+	}
+	return []v1.Image{{Size: 2, URL: i.PhotoURL}}
+}
+
+func (i item) ImageInfo() v1.ImageInfo { return v1.ImageInfo{LastChanged: i.Version} }
+
+// items serves the current item as 1.
+type items struct {
+	resources.Repository[item]
+	current item
+}
+
+func (r *items) Get(ctx context.Context, id string) (*item, error) {
+	if id != "1" {
+		return nil, errors.New("not found")
+	}
+	res := r.current
+	return &res, nil
+}
+
+func TestHandlerCacheName(t *testing.T) {
+	m := image.NewRGBA(image.Rect(0, 0, 40, 50))
+	for y := range 50 {
+		for x := range 40 {
+			m.Set(x, y, color.RGBA{uint8(x * 6), uint8(y * 5), 128, 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	store, err := img.NewFileStore(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	renderer, err := NewRenderer(DefaultColors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &items{current: item{Name: "Erika Musterfrau", Faction: "SPD"}}
+	h, err := NewHandler[item](repo, img.NewPipeline(store, img.DefaultOptions), renderer, Template{
+		Label:  `{{.Faction}}`,
+		Title:  `{{.Name}}`,
+		Accent: `{{.Faction}}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/items/{id}/card", h.Get)
+	// card returns the name the card was cached under
+	card := func() string {
+		t.Helper()
+		before, _ := filepath.Glob(filepath.Join(dir, "1-card-*.png"))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/1/card", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", rec.Code, rec.Body)
+		}
+		if cfg, err := png.DecodeConfig(rec.Body); err != nil || cfg.Width != Width || cfg.Height != Height {
+			t.Fatalf("card %dx%d, %v", cfg.Width, cfg.Height, err)
+		}
+		after, _ := filepath.Glob(filepath.Join(dir, "1-card-*.png"))
+		for _, name := range after {
+			if !slices.Contains(before, name) {
+				return filepath.Base(name)
+			}
+		}
+		return ""
+	}
+
+	first := card()
+	if first == "" {
+		t.Fatal("card wasn't cached")
+	}
+	if again := card(); again != "" {
+		t.Errorf("unchanged card cached again as %s", again)
+	}
+
+	names := map[string]string{"first": first}
+	changes := []struct {
+		name   string
+		change func(*item)
+	}{
+		{"title", func(i *item) { i.Name = "Erika Mustermann" }},
+		{"accent", func(i *item) { i.Faction = "FDP" }},
+		{"photo", func(i *item) { i.PhotoURL, i.Version = srv.URL+"/1.png", "01.01.2025" }},
+		{"photo version", func(i *item) { i.Version = "02.01.2025" }},
+	}
+	for _, c := range changes {
+		c.change(&repo.current)
+		name := card()
+		if name == "" {
+			t.Fatalf("card wasn't drawn again after a change of the %s", c.name)
+		}
+		for prev, n := range names {
+			if n == name {
+				t.Errorf("card after a change of the %s cached as the %s card %s", c.name, prev, name)
+			}
+		}
+		names[c.name] = name
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/2/card", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status of an unknown item %d", rec.Code)
+	}
+}
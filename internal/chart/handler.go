@@ -0,0 +1,160 @@
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/card"
+	"github.com/kyzrfranz/bundestag-api/internal/img"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+const (
+	// svgWidth is the width of the viewBox, SVGs scale anyway
+	svgWidth = 800
+
+	defaultPNGWidth = 1200
+	maxPNGWidth     = 1600
+)
+
+// Handler serves hemicycle charts of the Bundestag from the MdB catalog, or
+// of a committee from its members.
+type Handler struct {
+	politicians resources.Repository[v1.PersonListEntry]
+	committees  resources.Repository[v1.CommitteeDetails]
+	colors      card.Colors
+}
+
+func NewHandler(politicians resources.Repository[v1.PersonListEntry], committees resources.Repository[v1.CommitteeDetails], colors card.Colors) *Handler {
+	return &Handler{politicians: politicians, committees: committees, colors: colors}
+}
+
+// SVG serves the hemicycle as SVG, see hemicycle for the parameters.
+func (h *Handler) SVG(w http.ResponseWriter, req *http.Request) {
+	hc, ok := h.hemicycle(w, req)
+	if !ok {
+		return
+	}
+	var buf bytes.Buffer
+	if err := hc.SVG(&buf, svgWidth); err != nil {
+		fmt.Printf("failed to draw hemicycle: %v\n", err)
+		http.Error(w, "Failed to draw chart", http.StatusInternalServerError)
+		return
+	}
+	serve(w, req, "image/svg+xml", buf.Bytes())
+}
+
+// PNG serves the hemicycle as PNG, ?w= pixels wide.
+func (h *Handler) PNG(w http.ResponseWriter, req *http.Request) {
+	width := defaultPNGWidth
+	if s := req.URL.Query().Get("w"); s != "" {
+		var err error
+		if width, err = strconv.Atoi(s); err != nil || width < 100 || width > maxPNGWidth {
+			http.Error(w, fmt.Sprintf("Invalid width, expected 100 to %d", maxPNGWidth), http.StatusBadRequest)
+			return
+		}
+	}
+	hc, ok := h.hemicycle(w, req)
+	if !ok {
+		return
+	}
+	m, err := hc.PNG(width)
+	if err != nil {
+		fmt.Printf("failed to draw hemicycle: %v\n", err)
+		http.Error(w, "Failed to draw chart", http.StatusInternalServerError)
+		return
+	}
+	var buf bytes.Buffer
+	if err := (&img.Pipeline{}).Encode(&buf, m, img.FormatPNG); err != nil {
+		fmt.Printf("failed to encode hemicycle: %v\n", err)
+		http.Error(w, "Failed to draw chart", http.StatusInternalServerError)
+		return
+	}
+	serve(w, req, img.FormatPNG.MediaType(), buf.Bytes())
+}
+
+// hemicycle lays out the chart requested by
+//
+//	?committee=  the members of a committee instead of the Bundestag
+//	?colors=     faction colors like FACTION_COLORS, e.g. SPD=%23e3000f
+//	?order=      factions from left to right, comma-separated
+//	?labels=     false leaves out the total and the legend
+//	?highlight=  ids of MdBs to emphasize, comma-separated or repeated
+//
+// and writes the error response if it fails.
+func (h *Handler) hemicycle(w http.ResponseWriter, req *http.Request) (*Hemicycle, bool) {
+	q := req.URL.Query()
+	opts := Options{Colors: h.colors, Labels: true}
+
+	if s := q.Get("colors"); s != "" {
+		colors, err := h.colors.With(s)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return nil, false
+		}
+		opts.Colors = colors
+	}
+	if s := q.Get("labels"); s != "" {
+		labels, err := strconv.ParseBool(s)
+		if err != nil {
+			http.Error(w, "Invalid labels parameter, expected true or false", http.StatusBadRequest)
+			return nil, false
+		}
+		opts.Labels = labels
+	}
+	opts.Order = list(q["order"])
+	opts.Highlight = list(q["highlight"])
+
+	var members []Member
+	if id := q.Get("committee"); id != "" {
+		committee, err := h.committees.Get(req.Context(), id)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return nil, false
+		}
+		for _, p := range committee.Members {
+			members = append(members, member(p))
+		}
+	} else {
+		for _, p := range h.politicians.List(req.Context()) {
+			if p.Id.Active() {
+				members = append(members, member(p))
+			}
+		}
+	}
+	return NewHemicycle(members, opts), true
+}
+
+func member(p v1.PersonListEntry) Member {
+	return Member{ID: p.Id.Value, Name: p.Name.Value, Faction: p.Faction}
+}
+
+// list splits comma-separated values, which may also be repeated.
+func list(values []string) []string {
+	var result []string
+	for _, v := range values {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				result = append(result, s)
+			}
+		}
+	}
+	return result
+}
+
+// serve writes a chart with an ETag of its content. Charts change with the
+// catalog, so clients should revalidate.
+func serve(w http.ResponseWriter, req *http.Request, mediaType string, data []byte) {
+	hash := fnv.New64a()
+	hash.Write(data)
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("ETag", fmt.Sprintf(`"%x"`, hash.Sum64()))
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeContent(w, req, "", time.Time{}, bytes.NewReader(data))
+}
@@ -0,0 +1,97 @@
+package chart
+
+import (
+	"context"
+	"errors"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/card"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+type catalog struct {
+	resources.Repository[v1.PersonListEntry]
+	entries []v1.PersonListEntry
+}
+
+func (c catalog) List(ctx context.Context) []v1.PersonListEntry { return c.entries }
+
+type committees struct {
+	resources.Repository[v1.CommitteeDetails]
+}
+
+func (committees) Get(ctx context.Context, id string) (*v1.CommitteeDetails, error) {
+	return nil, errors.New("not found")
+}
+
+func entry(id, status, faction string) v1.PersonListEntry {
+	return v1.PersonListEntry{Id: v1.ID{Value: id, Status: status}, Faction: faction}
+}
+
+func newHandler() *Handler {
+	return NewHandler(catalog{entries: []v1.PersonListEntry{
+		entry("1", "Aktiv", "SPD"),
+		entry("2", "", "CDU/CSU"),
+		entry("3", "aktiv ", "AfD"),
+		// left during the legislative period
+		entry("4", "Inaktiv", "SPD"),
+		entry("5", "Ausgeschieden", "FDP"),
+	}}, committees{}, card.DefaultColors)
+}
+
+func TestSVGSeatsActiveMembers(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newHandler().SVG(rec, httptest.NewRequest(http.MethodGet, "/charts/bundestag.svg", nil))
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, "<title>3 Sitze</title>") {
+		t.Fatalf("status %d, body %.200s", rec.Code, body)
+	}
+	for _, id := range []string{"1", "2", "3"} {
+		if !strings.Contains(body, `data-id="`+id+`"`) {
+			t.Errorf("no seat of %s", id)
+		}
+	}
+	for _, id := range []string{"4", "5"} {
+		if strings.Contains(body, `data-id="`+id+`"`) {
+			t.Errorf("seat of the former MdB %s", id)
+		}
+	}
+}
+
+func TestPNGWidth(t *testing.T) {
+	tests := []struct {
+		query  string
+		status int
+		width  int
+	}{
+		{"", http.StatusOK, defaultPNGWidth},
+		{"?w=100", http.StatusOK, 100},
+		{"?w=1600", http.StatusOK, 1600},
+		{"?w=1601", http.StatusBadRequest, 0},
+		{"?w=99", http.StatusBadRequest, 0},
+		{"?w=wide", http.StatusBadRequest, 0},
+	}
+	h := newHandler()
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.PNG(rec, httptest.NewRequest(http.MethodGet, "/charts/bundestag.png"+tt.query, nil))
+			if rec.Code != tt.status {
+				t.Fatalf("status %d, want %d", rec.Code, tt.status)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			cfg, err := png.DecodeConfig(rec.Body)
+			if err != nil || cfg.Width != tt.width {
+				t.Errorf("width %d, %v, want %d", cfg.Width, err, tt.width)
+			}
+		})
+	}
+}
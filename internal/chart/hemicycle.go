@@ -0,0 +1,219 @@
+package chart
+
+import (
+	"cmp"
+	"image/color"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/kyzrfranz/bundestag-api/internal/card"
+)
+
+// DefaultOrder lists the factions from left to right as they are seated in
+// the plenary hall. Factions not listed follow by size.
+var DefaultOrder = []string{"Die Linke", "BSW", "BÜNDNIS 90/DIE GRÜNEN", "SPD", "FDP", "CDU/CSU", "AfD", "fraktionslos"}
+
+// innerRadius is the share of the radius left empty in the middle
+const innerRadius = 0.4
+
+// Member takes a seat in a hemicycle.
+type Member struct {
+	ID      string
+	Name    string
+	Faction string
+}
+
+// Options of a hemicycle chart
+type Options struct {
+	Colors card.Colors
+	// Order lists factions from left to right, the others follow as in
+	// DefaultOrder.
+	Order []string
+	// Labels adds the total and a legend with the seats per faction.
+	Labels bool
+	// Highlight are the ids of members whose seats are emphasized, the
+	// others are faded.
+	Highlight []string
+}
+
+// Group are the seats of a faction.
+type Group struct {
+	Faction string
+	Color   color.RGBA
+	Members []Member
+}
+
+// Seat is a member placed in a hemicycle of radius 1 around the origin,
+// y pointing up.
+type Seat struct {
+	Member
+	Color       color.RGBA
+	X, Y        float64
+	Highlighted bool
+}
+
+// Hemicycle is the layout of a chart, drawn by SVG and PNG.
+type Hemicycle struct {
+	Groups []Group
+	Seats  []Seat
+	// SeatRadius is the radius of every seat, relative to the hemicycle.
+	SeatRadius float64
+	Labels     bool
+	// Dimmed is set if some seats are highlighted.
+	Dimmed bool
+}
+
+// NewHemicycle groups members by faction and seats the groups from left to
+// right, each in a wedge spanning all rows.
+func NewHemicycle(members []Member, opts Options) *Hemicycle {
+	h := &Hemicycle{Labels: opts.Labels}
+	h.Groups = groups(members, opts)
+
+	positions, seatRadius := seats(len(members))
+	h.SeatRadius = seatRadius
+	i := 0
+	for _, g := range h.Groups {
+		for _, m := range g.Members {
+			highlighted := slices.Contains(opts.Highlight, m.ID)
+			h.Dimmed = h.Dimmed || highlighted
+			h.Seats = append(h.Seats, Seat{Member: m, Color: g.Color, X: positions[i][0], Y: positions[i][1], Highlighted: highlighted})
+			i++
+		}
+	}
+	return h
+}
+
+// Total is the number of seats.
+func (h *Hemicycle) Total() int {
+	return len(h.Seats)
+}
+
+func groups(members []Member, opts Options) []Group {
+	byFaction := map[string]*Group{}
+	var groups []*Group
+	for _, m := range members {
+		key := strings.ToLower(m.Faction)
+		g, ok := byFaction[key]
+		if !ok {
+			g = &Group{Faction: m.Faction, Color: opts.Colors.Of(m.Faction)}
+			byFaction[key] = g
+			groups = append(groups, g)
+		}
+		g.Members = append(g.Members, m)
+	}
+
+	rank := func(g *Group) int {
+		for i, f := range slices.Concat(opts.Order, DefaultOrder) {
+			if strings.EqualFold(f, g.Faction) {
+				return i
+			}
+		}
+		return math.MaxInt
+	}
+	slices.SortStableFunc(groups, func(a, b *Group) int {
+		if c := cmp.Compare(rank(a), rank(b)); c != 0 {
+			return c
+		}
+		return cmp.Compare(len(b.Members), len(a.Members))
+	})
+
+	result := make([]Group, len(groups))
+	for i, g := range groups {
+		slices.SortFunc(g.Members, func(a, b Member) int { return cmp.Compare(a.Name, b.Name) })
+		result[i] = *g
+	}
+	return result
+}
+
+// seats places n seats in as few rows as they fit in, the outer rows
+// taking more than the inner ones. The positions are sorted from left to
+// right, so consecutive seats form wedges.
+func seats(n int) ([][2]float64, float64) {
+	if n == 0 {
+		return nil, 0
+	}
+
+	rows := 1
+	for capacity(rows, spacing(rows)) < n {
+		rows++
+	}
+	d := spacing(rows)
+	radii := make([]float64, rows)
+	for i := range radii {
+		radii[i] = 1 - float64(i)*d
+	}
+
+	// seats per row proportional to its length, by largest remainder
+	sum := 0.0
+	for _, r := range radii {
+		sum += r
+	}
+	counts := make([]int, rows)
+	left := n
+	for i, r := range radii {
+		counts[i] = min(int(float64(n)*r/sum), rowCapacity(r, d))
+		left -= counts[i]
+	}
+	for ; left > 0; left-- {
+		best := -1
+		for i, r := range radii {
+			if counts[i] < rowCapacity(r, d) && (best < 0 || float64(n)*r/sum-float64(counts[i]) > float64(n)*radii[best]/sum-float64(counts[best])) {
+				best = i
+			}
+		}
+		counts[best]++
+	}
+
+	type position struct {
+		angle, radius float64
+	}
+	var positions []position
+	for i, r := range radii {
+		for j := range counts[i] {
+			angle := math.Pi / 2
+			if counts[i] > 1 {
+				angle = math.Pi * (1 - float64(j)/float64(counts[i]-1))
+			}
+			positions = append(positions, position{angle, r})
+		}
+	}
+	slices.SortStableFunc(positions, func(a, b position) int {
+		if c := cmp.Compare(b.angle, a.angle); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.radius, a.radius)
+	})
+
+	result := make([][2]float64, len(positions))
+	for i, p := range positions {
+		result[i] = [2]float64{p.radius * math.Cos(p.angle), p.radius * math.Sin(p.angle)}
+	}
+
+	// seats of a single row are as far apart as the row allows
+	seatRadius := 0.4 * d
+	if rows == 1 && n > 1 {
+		seatRadius = min(seatRadius, 0.4*math.Pi/float64(n-1))
+	}
+	return result, seatRadius
+}
+
+// spacing is the distance between rows, and between the seats of a row.
+func spacing(rows int) float64 {
+	if rows == 1 {
+		return 1 - innerRadius
+	}
+	return (1 - innerRadius) / float64(rows-1)
+}
+
+func capacity(rows int, d float64) int {
+	n := 0
+	for i := range rows {
+		n += rowCapacity(1-float64(i)*d, d)
+	}
+	return n
+}
+
+func rowCapacity(r, d float64) int {
+	return int(math.Pi*r/d) + 1
+}
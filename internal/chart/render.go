@@ -0,0 +1,233 @@
+package chart
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+	"math"
+	"strconv"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+var (
+	regular, _ = opentype.Parse(goregular.TTF)
+	bold, _    = opentype.Parse(gobold.TTF)
+
+	foreground = color.RGBA{0x1a, 0x1a, 0x1a, 0xff}
+)
+
+// dimmed is the opacity of seats that aren't highlighted
+const dimmed = 0.25
+
+// frame places a Hemicycle on a canvas of a given width, the height
+// follows from the legend.
+type frame struct {
+	width, height float64
+	// seats are at cx+x*scale, cy-y*scale
+	cx, cy, scale float64
+	seatRadius    float64
+	totalSize     float64
+	fontSize      float64
+	legend        []legendEntry
+}
+
+type legendEntry struct {
+	x, y   float64 // top left of the swatch
+	swatch float64
+	text   string
+	color  color.RGBA
+}
+
+func newFrame(h *Hemicycle, width float64) (*frame, error) {
+	pad := width / 40
+	f := &frame{width: width}
+	f.scale = (width/2 - pad) / (1 + h.SeatRadius)
+	f.cx, f.cy = width/2, pad+f.scale*(1+h.SeatRadius)
+	f.seatRadius = h.SeatRadius * f.scale
+	f.height = f.cy + f.seatRadius + pad
+	if !h.Labels {
+		return f, nil
+	}
+
+	f.totalSize = f.scale * innerRadius * 0.6
+	f.fontSize = width / 40
+	face, err := opentype.NewFace(regular, &opentype.FaceOptions{Size: f.fontSize, DPI: 72})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create font face: %w", err)
+	}
+	defer face.Close()
+
+	// entries flow from left to right, wrapping at the width
+	swatch, gap := f.fontSize, f.fontSize*1.5
+	lineHeight := f.fontSize * 1.6
+	x, y := pad, f.height+pad/2
+	for _, g := range h.Groups {
+		text := fmt.Sprintf("%s %d", g.Faction, len(g.Members))
+		w := swatch + f.fontSize/2 + fixedFloat(font.MeasureString(face, text))
+		if x > pad && x+w > width-pad {
+			x, y = pad, y+lineHeight
+		}
+		f.legend = append(f.legend, legendEntry{x: x, y: y, swatch: swatch, text: text, color: g.Color})
+		x += w + gap
+	}
+	if len(f.legend) > 0 {
+		f.height = y + lineHeight + pad/2
+	}
+	return f, nil
+}
+
+func (f *frame) seat(s Seat) (x, y float64) {
+	return f.cx + s.X*f.scale, f.cy - s.Y*f.scale
+}
+
+// SVG writes the chart width units wide, every seat with the name of the
+// member as title.
+func (h *Hemicycle) SVG(w io.Writer, width float64) error {
+	f, err := newFrame(h, width)
+	if err != nil {
+		return err
+	}
+
+	ew := &errWriter{w: w}
+	ew.printf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %s %s" width="%s" height="%s" font-family="Go, Helvetica, Arial, sans-serif">`,
+		num(f.width), num(f.height), num(f.width), num(f.height))
+	ew.printf(`<title>%d Sitze</title>`, h.Total())
+	for _, s := range h.Seats {
+		x, y := f.seat(s)
+		ew.printf(`<circle cx="%s" cy="%s" r="%s" fill="%s"`, num(x), num(y), num(f.seatRadius), hex(s.Color))
+		if s.Highlighted {
+			ew.printf(` stroke="%s" stroke-width="%s"`, hex(foreground), num(f.seatRadius/3))
+		} else if h.Dimmed {
+			ew.printf(` fill-opacity="%s"`, num(dimmed))
+		}
+		ew.printf(` data-id="%s"><title>%s (%s)</title></circle>`, html.EscapeString(s.ID), html.EscapeString(s.Name), html.EscapeString(s.Faction))
+	}
+	if h.Labels {
+		ew.printf(`<text x="%s" y="%s" font-size="%s" font-weight="bold" text-anchor="middle" fill="%s">%d</text>`,
+			num(f.cx), num(f.cy), num(f.totalSize), hex(foreground), h.Total())
+		for _, e := range f.legend {
+			ew.printf(`<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`, num(e.x), num(e.y), num(e.swatch), num(e.swatch), hex(e.color))
+			ew.printf(`<text x="%s" y="%s" font-size="%s" fill="%s">%s</text>`,
+				num(e.x+e.swatch+f.fontSize/2), num(e.y+e.swatch*0.85), num(f.fontSize), hex(foreground), html.EscapeString(e.text))
+		}
+	}
+	ew.printf(`</svg>`)
+	return ew.err
+}
+
+// PNG draws the chart width pixels wide on white.
+func (h *Hemicycle) PNG(width int) (image.Image, error) {
+	f, err := newFrame(h, float64(width))
+	if err != nil {
+		return nil, err
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, int(math.Ceil(f.height))))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+
+	for _, s := range h.Seats {
+		x, y := f.seat(s)
+		c := s.Color
+		if s.Highlighted {
+			circle(dst, x, y, f.seatRadius+f.seatRadius/6, foreground)
+			circle(dst, x, y, f.seatRadius-f.seatRadius/6, c)
+			continue
+		}
+		if h.Dimmed {
+			c = fade(c, dimmed)
+		}
+		circle(dst, x, y, f.seatRadius, c)
+	}
+
+	if h.Labels {
+		total, err := opentype.NewFace(bold, &opentype.FaceOptions{Size: f.totalSize, DPI: 72})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create font face: %w", err)
+		}
+		defer total.Close()
+		s := strconv.Itoa(h.Total())
+		text(dst, total, f.cx-fixedFloat(font.MeasureString(total, s))/2, f.cy, s)
+
+		face, err := opentype.NewFace(regular, &opentype.FaceOptions{Size: f.fontSize, DPI: 72})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create font face: %w", err)
+		}
+		defer face.Close()
+		for _, e := range f.legend {
+			r := image.Rect(int(e.x), int(e.y), int(e.x+e.swatch), int(e.y+e.swatch))
+			draw.Draw(dst, r, image.NewUniform(e.color), image.Point{}, draw.Src)
+			text(dst, face, e.x+e.swatch+f.fontSize/2, e.y+e.swatch*0.85, e.text)
+		}
+	}
+	return dst, nil
+}
+
+// circle fills a circle, anti-aliased, with four cubic Béziers.
+func circle(dst draw.Image, x, y, r float64, c color.Color) {
+	const k = 0.5522847498
+	// rasterized within its bounds only, charts have hundreds of seats
+	b := image.Rect(int(x-r)-1, int(y-r)-1, int(x+r)+2, int(y+r)+2)
+	z := vector.NewRasterizer(b.Dx(), b.Dy())
+	p := func(dx, dy float64) (float32, float32) {
+		return float32(x + dx - float64(b.Min.X)), float32(y + dy - float64(b.Min.Y))
+	}
+	z.MoveTo(p(r, 0))
+	for _, q := range [][3][2]float64{
+		{{r, k * r}, {k * r, r}, {0, r}},
+		{{-k * r, r}, {-r, k * r}, {-r, 0}},
+		{{-r, -k * r}, {-k * r, -r}, {0, -r}},
+		{{k * r, -r}, {r, -k * r}, {r, 0}},
+	} {
+		bx, by := p(q[0][0], q[0][1])
+		cx, cy := p(q[1][0], q[1][1])
+		dx, dy := p(q[2][0], q[2][1])
+		z.CubeTo(bx, by, cx, cy, dx, dy)
+	}
+	z.ClosePath()
+	z.Draw(dst, b, image.NewUniform(c), image.Point{})
+}
+
+func text(dst draw.Image, face font.Face, x, y float64, s string) {
+	d := font.Drawer{Dst: dst, Src: image.NewUniform(foreground), Face: face, Dot: fixed.Point26_6{X: fixed.Int26_6(x * 64), Y: fixed.Int26_6(y * 64)}}
+	d.DrawString(s)
+}
+
+// fade blends c into white.
+func fade(c color.RGBA, opacity float64) color.RGBA {
+	mix := func(v uint8) uint8 { return uint8(float64(v)*opacity + 0xff*(1-opacity) + 0.5) }
+	return color.RGBA{mix(c.R), mix(c.G), mix(c.B), 0xff}
+}
+
+func fixedFloat(v fixed.Int26_6) float64 {
+	return float64(v) / 64
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// num formats SVG coordinates, to two decimals at most.
+func num(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// errWriter keeps the first error, so writing the elements doesn't need to
+// check every time.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...any) {
+	if ew.err == nil {
+		_, ew.err = fmt.Fprintf(ew.w, format, args...)
+	}
+}
@@ -0,0 +1,210 @@
+package contact
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/img"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+	"rsc.io/qr"
+)
+
+const mediaTypeVCard = "text/vcard"
+
+// photoVariant is the photo embedded in vCards, small enough for a list of
+// them.
+var photoVariant = img.Variant{Width: 192, Height: 192, Fit: img.FitCover, Format: img.FormatJPEG}
+
+// maxListPhotos is the most cards of a list with ?photo=true, every photo is
+// converted in the request.
+const maxListPhotos = 50
+
+// Handler serves the vCards of MdBs and QR codes of them. The photos are
+// converted and cached by the image pipeline, like those of /photo.
+type Handler struct {
+	politicians resources.Repository[v1.PersonListEntry]
+	bios        resources.DetailRepository[v1.Politician]
+	images      *img.Pipeline
+}
+
+func NewHandler(politicians resources.Repository[v1.PersonListEntry], bios resources.DetailRepository[v1.Politician], images *img.Pipeline) *Handler {
+	return &Handler{politicians: politicians, bios: bios, images: images}
+}
+
+// VCard serves the vCard of {id}, ?photo=false leaves out the photo.
+func (h *Handler) VCard(w http.ResponseWriter, req *http.Request) {
+	politician, err := h.bios.Get(req.Context(), req.PathValue("id"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var photo *Photo
+	if embed, err := strconv.ParseBool(req.URL.Query().Get("photo")); err != nil || embed {
+		photo = h.photo(req, req.PathValue("id"))
+	}
+	card := VCard(politician, photo)
+
+	w.Header().Set("Content-Type", mediaTypeVCard+"; charset=utf-8")
+	rest.Attachment(w, req, "vcf")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.WriteString(w, card); err != nil {
+		fmt.Printf("failed to write vcard: %v\n", err)
+	}
+}
+
+// QR serves the vCard of {id} without photo as QR code, as PNG or SVG
+// depending on ?format= or the Accept header.
+func (h *Handler) QR(w http.ResponseWriter, req *http.Request) {
+	mediaType := ""
+	switch strings.ToLower(req.URL.Query().Get("format")) {
+	case "png":
+		mediaType = "image/png"
+	case "svg":
+		mediaType = "image/svg+xml"
+	case "":
+		var ok bool
+		if mediaType, ok = rest.Negotiate(w, req, "image/png", "image/svg+xml"); !ok {
+			return
+		}
+	default:
+		http.Error(w, "Invalid format", http.StatusBadRequest)
+		return
+	}
+
+	politician, err := h.bios.Get(req.Context(), req.PathValue("id"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	code, err := qr.Encode(VCard(politician, nil), qr.M)
+	if err != nil {
+		// long cards still fit with less error correction
+		if code, err = qr.Encode(VCard(politician, nil), qr.L); err != nil {
+			http.Error(w, "Failed to encode QR code", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	var body []byte
+	if mediaType == "image/png" {
+		body = code.PNG()
+	} else {
+		body = []byte(svg(code))
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		fmt.Printf("failed to write qr code: %v\n", err)
+	}
+}
+
+// Representation offers lists of MdBs, e.g. the members of a committee, as a
+// single file of vCards. The bios are read in one batch, the cards are
+// written one after another. Photos are left out unless ?photo=true, which
+// is refused for lists of more than maxListPhotos.
+func (h *Handler) Representation() rest.Representation[v1.PersonListEntry] {
+	write := func(w http.ResponseWriter, req *http.Request, entries iter.Seq[v1.PersonListEntry]) {
+		var ids []string
+		for e := range entries {
+			ids = append(ids, e.Id.Value)
+		}
+		photos, _ := strconv.ParseBool(req.URL.Query().Get("photo"))
+		if photos && len(ids) > maxListPhotos {
+			http.Error(w, fmt.Sprintf("Photos are only embedded in lists of up to %d members", maxListPhotos), http.StatusBadRequest)
+			return
+		}
+		bio := h.bios.Batch(req.Context(), ids)
+
+		w.Header().Set("Content-Type", mediaTypeVCard+"; charset=utf-8")
+		rest.Attachment(w, req, "vcf")
+		w.WriteHeader(http.StatusOK)
+
+		for _, id := range ids {
+			politician, err := bio(id)
+			if err != nil {
+				fmt.Printf("failed to read bio of %s: %v\n", id, err)
+				continue
+			}
+			var photo *Photo
+			if photos {
+				photo = h.photo(req, id)
+			}
+			if _, err := io.WriteString(w, VCard(politician, photo)); err != nil {
+				fmt.Printf("failed to write vcard: %v\n", err)
+				return
+			}
+		}
+	}
+
+	return rest.Representation[v1.PersonListEntry]{
+		MediaType: mediaTypeVCard,
+		Format:    "vcf",
+		One: func(w http.ResponseWriter, req *http.Request, res *v1.PersonListEntry) {
+			write(w, req, slices.Values([]v1.PersonListEntry{*res}))
+		},
+		Many: write,
+	}
+}
+
+// photo returns the photo of the MdB id. A card without photo is better than
+// none, so errors are only logged.
+func (h *Handler) photo(req *http.Request, id string) *Photo {
+	// the photos of the catalog are the ones the pipeline caches
+	entry, err := h.politicians.Get(req.Context(), id)
+	if err != nil {
+		return nil
+	}
+	im, err := h.images.EnsureImage(req.Context(), *entry, id, photoVariant)
+	if errors.Is(err, img.ErrNoImage) {
+		return nil
+	}
+	if err != nil {
+		fmt.Printf("failed to convert photo of %s: %v\n", id, err)
+		return nil
+	}
+	data, err := h.images.Read(req.Context(), im)
+	if err != nil {
+		fmt.Printf("failed to read photo of %s: %v\n", id, err)
+		return nil
+	}
+	return &Photo{Data: data, MediaType: im.Format.MediaType()}
+}
+
+// svg draws the code with one path, including the quiet zone of four modules.
+func svg(code *qr.Code) string {
+	const quiet = 4
+	size := code.Size + 2*quiet
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, size, size)
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; {
+			if !code.Black(x, y) {
+				x++
+				continue
+			}
+			// one rectangle per horizontal run
+			run := 1
+			for code.Black(x+run, y) {
+				run++
+			}
+			fmt.Fprintf(&sb, "M%d %dh%dv1h-%dz", x+quiet, y+quiet, run, run)
+			x += run
+		}
+	}
+	sb.WriteString(`"/></svg>`)
+	return sb.String()
+}
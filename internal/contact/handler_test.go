@@ -0,0 +1,202 @@
+package contact
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/img"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+// catalog serves the catalog entries of MdBs by id.
+type catalog struct {
+	resources.Repository[v1.PersonListEntry]
+	entries map[string]v1.PersonListEntry
+}
+
+func (c catalog) Get(ctx context.Context, id string) (*v1.PersonListEntry, error) {
+	if e, ok := c.entries[id]; ok {
+		return &e, nil
+	}
+	return nil, errors.New("not found")
+}
+
+// bios serves bios by id, Get and Batch are counted.
+type bios struct {
+	resources.DetailRepository[v1.Politician]
+	byID           map[string]*v1.Politician
+	gets, batches  int
+	batchRequested []string
+}
+
+func (b *bios) Get(ctx context.Context, id string) (*v1.Politician, error) {
+	b.gets++
+	if p, ok := b.byID[id]; ok {
+		return p, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (b *bios) Batch(ctx context.Context, ids []string) func(id string) (*v1.Politician, error) {
+	b.batches++
+	b.batchRequested = ids
+	return func(id string) (*v1.Politician, error) {
+		if p, ok := b.byID[id]; ok {
+			return p, nil
+		}
+		return nil, errors.New("not found")
+	}
+}
+
+type contactTest struct {
+	handler  *Handler
+	catalog  catalog
+	bios     *bios
+	photoURL string
+	fetched  *atomic.Int32
+}
+
+func newContactTest(t *testing.T) *contactTest {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 300, 400))); err != nil {
+		t.Fatal(err)
+	}
+	var fetched atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched.Add(1)
+		w.Write(buf.Bytes())
+	}))
+	t.Cleanup(upstream.Close)
+
+	store, err := img.NewFileStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct := &contactTest{
+		catalog:  catalog{entries: map[string]v1.PersonListEntry{}},
+		bios:     &bios{byID: map[string]*v1.Politician{}},
+		photoURL: upstream.URL + "/photo.png",
+		fetched:  &fetched,
+	}
+	for _, id := range []string{"1001", "1002", "1003"} {
+		ct.catalog.entries[id] = v1.PersonListEntry{Id: v1.ID{Value: id}, PhotoLargeURL: ct.photoURL, PhotoChangedDateTime: "1"}
+		ct.bios.byID[id] = &v1.Politician{Bio: v1.PoliticianBio{Id: v1.ID{Value: id}, LastName: "MdB " + id}}
+	}
+	// without photo
+	ct.catalog.entries["1003"] = v1.PersonListEntry{Id: v1.ID{Value: "1003"}}
+	ct.handler = NewHandler(ct.catalog, ct.bios, img.NewPipeline(store, img.DefaultOptions))
+	return ct
+}
+
+func (ct *contactTest) vcard(id, query string) string {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/politicians/{id}/vcard", ct.handler.VCard)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/politicians/"+id+"/vcard"+query, nil))
+	return rec.Body.String()
+}
+
+func TestVCardPhoto(t *testing.T) {
+	ct := newContactTest(t)
+
+	card := ct.vcard("1001", "")
+	if !strings.Contains(card, "PHOTO:data:image/jpeg;base64,") {
+		t.Fatalf("no photo in %q", card)
+	}
+	if strings.Contains(ct.vcard("1001", "?photo=false"), "PHOTO") {
+		t.Error("photo despite ?photo=false")
+	}
+	if strings.Contains(ct.vcard("1003", ""), "PHOTO") {
+		t.Error("photo of an MdB without")
+	}
+
+	// the photo is cached until the catalog lists another
+	ct.vcard("1001", "")
+	if ct.fetched.Load() != 1 {
+		t.Errorf("photo fetched %d times, want 1", ct.fetched.Load())
+	}
+	e := ct.catalog.entries["1001"]
+	e.PhotoChangedDateTime = "2"
+	ct.catalog.entries["1001"] = e
+	ct.vcard("1001", "")
+	if ct.fetched.Load() != 2 {
+		t.Errorf("changed photo fetched %d times, want 2", ct.fetched.Load()-1)
+	}
+}
+
+func TestRepresentationBatchesBios(t *testing.T) {
+	ct := newContactTest(t)
+	h := rest.NewHandler[v1.PersonListEntry](sliceRepo{
+		{Id: v1.ID{Value: "1001"}}, {Id: v1.ID{Value: "unknown"}}, {Id: v1.ID{Value: "1002"}},
+	}, rest.WithRepresentation(ct.handler.Representation()))
+
+	req := httptest.NewRequest(http.MethodGet, "/politicians?photo=false", nil)
+	req.Header.Set("Accept", mediaTypeVCard)
+	rec := httptest.NewRecorder()
+	h.List(rec, req)
+
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || strings.Count(body, "BEGIN:VCARD") != 2 {
+		t.Fatalf("status %d, body %q", rec.Code, body)
+	}
+	if strings.Index(body, "MdB 1001") > strings.Index(body, "MdB 1002") {
+		t.Error("cards are not in the order of the list")
+	}
+	if ct.bios.batches != 1 || ct.bios.gets != 0 || len(ct.bios.batchRequested) != 3 {
+		t.Errorf("%d batches of %v and %d gets", ct.bios.batches, ct.bios.batchRequested, ct.bios.gets)
+	}
+}
+
+type sliceRepo []v1.PersonListEntry
+
+func (s sliceRepo) List(ctx context.Context) []v1.PersonListEntry { return s }
+func (s sliceRepo) Get(ctx context.Context, id string) (*v1.PersonListEntry, error) {
+	return nil, errors.New("not found")
+}
+func (s sliceRepo) Delete(ctx context.Context, id string) error { return nil }
+func (s sliceRepo) Create(ctx context.Context, e *v1.PersonListEntry) (*v1.PersonListEntry, error) {
+	return e, nil
+}
+func (s sliceRepo) Update(ctx context.Context, _ *v1.PersonListEntry, e *v1.PersonListEntry) (*v1.PersonListEntry, error) {
+	return e, nil
+}
+func (s sliceRepo) Name() string { return "politicians" }
+
+func TestRepresentationPhotos(t *testing.T) {
+	ct := newContactTest(t)
+	list := func(entries sliceRepo, query string) *httptest.ResponseRecorder {
+		h := rest.NewHandler[v1.PersonListEntry](entries, rest.WithRepresentation(ct.handler.Representation()))
+		rec := httptest.NewRecorder()
+		h.List(rec, httptest.NewRequest(http.MethodGet, "/committees/a11/members?format=vcf"+query, nil))
+		return rec
+	}
+	members := sliceRepo{{Id: v1.ID{Value: "1001"}}, {Id: v1.ID{Value: "1002"}}}
+
+	if rec := list(members, ""); strings.Contains(rec.Body.String(), "PHOTO") || ct.fetched.Load() != 0 {
+		t.Errorf("photos in a list without ?photo=true, %d fetched", ct.fetched.Load())
+	}
+	if rec := list(members, "&photo=true"); strings.Count(rec.Body.String(), "PHOTO") != 2 {
+		t.Errorf("%d photos with ?photo=true, want 2", strings.Count(rec.Body.String(), "PHOTO"))
+	}
+
+	many := make(sliceRepo, maxListPhotos+1)
+	for i := range many {
+		many[i] = v1.PersonListEntry{Id: v1.ID{Value: "1001"}}
+	}
+	if rec := list(many, "&photo=true"); rec.Code != http.StatusBadRequest {
+		t.Errorf("%d members with photos = %d, want %d", len(many), rec.Code, http.StatusBadRequest)
+	}
+	if rec := list(many, ""); rec.Code != http.StatusOK {
+		t.Errorf("%d members without photos = %d", len(many), rec.Code)
+	}
+}
@@ -0,0 +1,161 @@
+package contact
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+)
+
+// Photo is embedded into the vCard as data URI.
+type Photo struct {
+	Data      []byte
+	MediaType string
+}
+
+var (
+	digitsRe = regexp.MustCompile(`\D`)
+	genders  = map[string]string{v1.GenderMale: "M", v1.GenderFemale: "F", v1.GenderDiverse: "O"}
+)
+
+// VCard builds a vCard 4.0 (RFC 6350) of an MdB. photo may be nil.
+func VCard(p *v1.Politician, photo *Photo) string {
+	bio := p.Bio
+	var c card
+
+	c.line("BEGIN", "VCARD")
+	c.line("VERSION", "4.0")
+	c.line("KIND", "individual")
+	c.line("UID", uid(bio.Id.Value))
+	c.line("FN", text(fullName(bio)))
+	c.line("N;SORT-AS="+quote(strings.ReplaceAll(bio.LastName, ",", " ")+","+strings.ReplaceAll(bio.FirstName, ",", " ")),
+		text(strings.TrimSpace(bio.NobilityTitle+" "+bio.LastName)), text(bio.FirstName), "", text(bio.AcademicTitle), "")
+
+	if gender, ok := genders[strings.ToLower(bio.Gender)]; ok {
+		c.line("GENDER", gender)
+	}
+	if birth, ok := bio.BirthDate(); ok {
+		c.line("BDAY", birth.Format("20060102"))
+	}
+
+	c.line("TITLE", "Mitglied des Deutschen Bundestages")
+	if bio.Faction != "" {
+		c.line("ORG", text(bio.Faction))
+	}
+	if bio.Party != "" {
+		c.line("CATEGORIES", text("Deutscher Bundestag")+","+text(bio.Party))
+	}
+
+	// all MdBs share the postal address of the Bundestag
+	office := v1.Office
+	c.line("ADR;TYPE=work;LABEL="+quote(office.Street+"\n"+office.PostalCode+" "+office.Locality),
+		"", "", office.Street, office.Locality, "", office.PostalCode, office.Country)
+	if tel := telURI(bio.Phone); tel != "" {
+		c.line("TEL;TYPE=work,voice;VALUE=uri", tel)
+	} else if bio.Phone != "" {
+		c.line("TEL;TYPE=work,voice", text(bio.Phone))
+	}
+
+	if bio.Homepage != "" {
+		c.line("URL;TYPE=work", bio.Homepage)
+	}
+	// grouped properties carry a label most address books display
+	for i, site := range bio.OtherWebsite.Website {
+		group := fmt.Sprintf("item%d.", i+1)
+		c.line(group+"URL", site.URL)
+		if site.Title != "" {
+			c.line(group+"X-ABLabel", text(site.Title))
+		}
+	}
+	if bio.BioURL != "" {
+		c.line("SOURCE", bio.BioURL)
+	}
+
+	if photo != nil && len(photo.Data) > 0 {
+		c.line("PHOTO", "data:"+photo.MediaType+";base64,"+base64.StdEncoding.EncodeToString(photo.Data))
+	}
+
+	c.line("END", "VCARD")
+	return c.String()
+}
+
+func fullName(bio v1.PoliticianBio) string {
+	parts := []string{bio.AcademicTitle, bio.FirstName, bio.NobilityTitle, bio.LastName}
+	return strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+}
+
+// uid is a name based UUID (RFC 4122 version 5 in the URL namespace), so
+// importing a card again updates the existing contact.
+func uid(id string) string {
+	namespace := []byte{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	h := sha1.New()
+	h.Write(namespace)
+	h.Write([]byte("https://www.bundestag.de/abgeordnete/" + id))
+	u := h.Sum(nil)[:16]
+	u[6] = (u[6] & 0x0f) | 0x50
+	u[8] = (u[8] & 0x3f) | 0x80
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
+
+// telURI converts German numbers like "030 227-71001" into tel:+493022771001.
+func telURI(phone string) string {
+	digits := digitsRe.ReplaceAllString(phone, "")
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(phone), "+"):
+		return "tel:+" + digits
+	case strings.HasPrefix(digits, "00"):
+		return "tel:+" + digits[2:]
+	case strings.HasPrefix(digits, "0") && len(digits) > 5:
+		return "tel:+49" + digits[1:]
+	}
+	return ""
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`)
+
+// text escapes a value for a text property or a component of a structured one.
+func text(s string) string {
+	return textEscaper.Replace(strings.TrimSpace(s))
+}
+
+var paramEscaper = strings.NewReplacer("^", "^^", "\n", "^n", `"`, "^'")
+
+// quote encodes a parameter value as described in RFC 6868.
+func quote(s string) string {
+	return `"` + paramEscaper.Replace(s) + `"`
+}
+
+type card struct {
+	sb strings.Builder
+}
+
+// line writes a property, joining the components of structured values by ";".
+// Lines are folded after 75 octets without splitting UTF-8 sequences.
+func (c *card) line(name string, components ...string) {
+	l := name + ":" + strings.Join(components, ";")
+	for first := true; ; first = false {
+		limit := 75
+		if !first {
+			c.sb.WriteString(" ")
+			limit = 74
+		}
+		if len(l) <= limit {
+			c.sb.WriteString(l + "\r\n")
+			return
+		}
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(l[cut]) {
+			cut--
+		}
+		c.sb.WriteString(l[:cut] + "\r\n")
+		l = l[cut:]
+	}
+}
+
+func (c *card) String() string {
+	return c.sb.String()
+}
@@ -35,6 +35,16 @@ func (r *CatalogReader[C, E]) GetEntry(id string) (*resources.Entry, error) {
 	return &e, err
 }
 
+func (r *CatalogReader[C, E]) GetEntries() ([]resources.Entry, error) {
+	c, err := r.GetCatalog()
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(c, func(e E, _ int) resources.Entry {
+		return e
+	}), nil
+}
+
 func (r *CatalogReader[C, E]) GetCatalog() ([]E, error) {
 	c, err := r.readCatalog()
 	if err != nil {
@@ -0,0 +1,170 @@
+package disclosure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/events"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+	"github.com/samber/lo"
+)
+
+// Handler serves the parsed disclosures of single MdBs and an index over
+// all of them, which is built in the background and rebuilt after changes.
+type Handler struct {
+	politicians resources.Repository[v1.PersonListEntry]
+	bios        resources.DetailRepository[v1.Politician]
+
+	// settle is the time to wait for more changes before a rebuild, retry the
+	// time before another attempt after a failed one
+	settle time.Duration
+	retry  time.Duration
+
+	mu    sync.RWMutex
+	index []v1.PoliticianDisclosure
+}
+
+func NewHandler(politicians resources.Repository[v1.PersonListEntry], bios resources.DetailRepository[v1.Politician]) *Handler {
+	return &Handler{politicians: politicians, bios: bios, settle: 10 * time.Second, retry: time.Minute}
+}
+
+// Run builds the index right away and again whenever MdBs changed, until ctx
+// is done. A build that fails is retried; until the catalog can be read the
+// previous index is served.
+func (h *Handler) Run(ctx context.Context, ch <-chan events.Event) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-ch:
+			switch e.Type {
+			case events.PoliticianAdded, events.PoliticianLeft, events.PoliticianUpdated, events.PoliticianFactionChanged:
+				timer.Reset(h.settle)
+			}
+		case <-timer.C:
+			if err := h.build(ctx); err != nil {
+				fmt.Printf("failed to build disclosure index: %v\n", err)
+				timer.Reset(h.retry)
+			}
+		}
+	}
+}
+
+func (h *Handler) Politician(w http.ResponseWriter, req *http.Request) {
+	politician, err := h.bios.Get(req.Context(), req.PathValue("id"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	disclosures := Parse(politician.Bio.MandatedPublishableInfo)
+	if disclosures == nil {
+		disclosures = []v1.Disclosure{}
+	}
+
+	if err := rest.MarshalResponse(w, http.StatusOK, disclosures); err != nil {
+		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Search filters the disclosures of all MdBs by ?organisation= (substring),
+// ?category= and ?faction=.
+func (h *Handler) Search(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	category := 0
+	if c := query.Get("category"); c != "" {
+		var err error
+		if category, err = strconv.Atoi(c); err != nil {
+			http.Error(w, "Invalid category", http.StatusBadRequest)
+			return
+		}
+	}
+	organisation := strings.ToLower(query.Get("organisation"))
+	faction := query.Get("faction")
+
+	index, ok := h.all()
+	if !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(h.retry.Seconds())))
+		http.Error(w, "The disclosure index is being built, try again later", http.StatusServiceUnavailable)
+		return
+	}
+
+	result := lo.Filter(index, func(d v1.PoliticianDisclosure, _ int) bool {
+		return (category == 0 || d.Category == category) &&
+			(faction == "" || strings.EqualFold(d.Faction, faction)) &&
+			(organisation == "" || strings.Contains(strings.ToLower(d.Organisation), organisation) ||
+				(d.Organisation == "" && strings.Contains(strings.ToLower(d.Raw), organisation)))
+	})
+
+	if err := rest.MarshalResponse(w, http.StatusOK, result); err != nil {
+		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+		return
+	}
+}
+
+// build reads all bios and replaces the index, unless the catalog couldn't be
+// read. A bio that can't be read keeps its entries of the previous index; the
+// index is replaced anyway and an error returned, so the build is retried.
+func (h *Handler) build(ctx context.Context) error {
+	politicians := h.politicians.List(ctx)
+	if len(politicians) == 0 {
+		return errors.New("empty catalog")
+	}
+
+	ids := lo.Map(politicians, func(p v1.PersonListEntry, _ int) string { return p.Id.Value })
+	bios := h.bios.Batch(ctx, ids)
+
+	previous, _ := h.all()
+	index := []v1.PoliticianDisclosure{}
+	failed := 0
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		p, err := bios(id)
+		if err != nil {
+			fmt.Printf("failed to read bio of %s, keeping its previous disclosures: %v\n", id, err)
+			index = append(index, lo.Filter(previous, func(d v1.PoliticianDisclosure, _ int) bool { return d.PoliticianID == id })...)
+			failed++
+			continue
+		}
+		for _, d := range Parse(p.Bio.MandatedPublishableInfo) {
+			index = append(index, v1.PoliticianDisclosure{
+				PoliticianID: p.Bio.Id.Value,
+				Name:         p.Bio.LastName + ", " + p.Bio.FirstName,
+				Faction:      p.Bio.Faction,
+				Disclosure:   d,
+			})
+		}
+	}
+
+	h.mu.Lock()
+	h.index = index
+	h.mu.Unlock()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d bios failed", failed, len(ids))
+	}
+	return nil
+}
+
+// all returns the index, false until it was built.
+func (h *Handler) all() ([]v1.PoliticianDisclosure, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.index, h.index != nil
+}
@@ -0,0 +1,155 @@
+package disclosure
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/events"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+type catalog struct {
+	resources.Repository[v1.PersonListEntry]
+	ids []string
+}
+
+func (c catalog) List(ctx context.Context) []v1.PersonListEntry {
+	var result []v1.PersonListEntry
+	for _, id := range c.ids {
+		result = append(result, v1.PersonListEntry{Id: v1.ID{Value: id}})
+	}
+	return result
+}
+
+// bios serves a bio with the same disclosure for every id but failing.
+type bios struct {
+	resources.DetailRepository[v1.Politician]
+
+	mu      sync.Mutex
+	failing string
+	batches int
+}
+
+func (b *bios) fail(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failing = id
+}
+
+func (b *bios) count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.batches
+}
+
+func (b *bios) Batch(ctx context.Context, ids []string) func(id string) (*v1.Politician, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.batches++
+	failing := b.failing
+	return func(id string) (*v1.Politician, error) {
+		if id == failing {
+			return nil, errors.New("unavailable")
+		}
+		p := &v1.Politician{}
+		p.Bio.Id.Value, p.Bio.LastName, p.Bio.FirstName, p.Bio.Faction = id, "Muster", "Erika", "SPD"
+		p.Bio.MandatedPublishableInfo = "<p><strong>3. Funktionen in Unternehmen</strong></p><p>Beirat, Stadtwerke GmbH, Kiel</p>"
+		return p, nil
+	}
+}
+
+func search(h *Handler, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.Search(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w
+}
+
+func TestBuild(t *testing.T) {
+	repo := &bios{failing: "2"}
+	h := NewHandler(catalog{ids: []string{"1", "2", "3"}}, repo)
+
+	if w := search(h, "/disclosures"); w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "60" {
+		t.Errorf("search before the first build = %d, want 503 with Retry-After", w.Code)
+	}
+
+	// a failing bio is skipped, the build is retried
+	if err := h.build(context.Background()); err == nil {
+		t.Error("build with a failing bio succeeded")
+	}
+	if index, ok := h.all(); !ok || len(index) != 2 {
+		t.Errorf("index = %d entries, want 2 without the failing bio", len(index))
+	}
+
+	repo.fail("")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := h.build(ctx); err == nil {
+		t.Error("cancelled build succeeded")
+	}
+	if index, _ := h.all(); len(index) != 2 {
+		t.Errorf("index = %d entries, want the previous 2 after a cancelled build", len(index))
+	}
+
+	if err := h.build(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	w := search(h, "/disclosures?organisation=stadtwerke&faction=spd&category=3")
+	if w.Code != http.StatusOK || strings.Count(w.Body.String(), `"politicianId"`) != 3 {
+		t.Errorf("search = %d %s", w.Code, w.Body)
+	}
+	if w := search(h, "/disclosures?category=2"); w.Body.String() != "[]" {
+		t.Errorf("search = %s, want []", w.Body)
+	}
+
+	// a bio failing in a rebuild keeps its previous entries
+	repo.fail("3")
+	if err := h.build(context.Background()); err == nil {
+		t.Error("build with a failing bio succeeded")
+	}
+	w = search(h, "/disclosures")
+	if strings.Count(w.Body.String(), `"politicianId":"3"`) != 1 || strings.Count(w.Body.String(), `"politicianId"`) != 3 {
+		t.Errorf("search = %s, want the previous entry of 3", w.Body)
+	}
+
+	if err := (&Handler{politicians: catalog{}, bios: repo}).build(context.Background()); err == nil {
+		t.Error("build of an empty catalog succeeded")
+	}
+}
+
+func TestRun(t *testing.T) {
+	repo := &bios{failing: "2"}
+	h := NewHandler(catalog{ids: []string{"1", "2"}}, repo)
+	h.settle, h.retry = time.Millisecond, time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan events.Event)
+	go h.Run(ctx, ch)
+
+	waitFor(t, func() bool { return repo.count() >= 2 }) // retried
+
+	repo.fail("")
+	waitFor(t, func() bool { _, ok := h.all(); return ok })
+
+	batches := repo.count()
+	ch <- events.Event{Type: events.CommitteeAdded}
+	ch <- events.Event{Type: events.PoliticianUpdated}
+	waitFor(t, func() bool { return repo.count() == batches+1 })
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if cond() {
+			return
+		}
+	}
+	t.Fatal("timed out")
+}
@@ -0,0 +1,250 @@
+package disclosure
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"golang.org/x/net/html"
+)
+
+var (
+	headingRe = regexp.MustCompile(`^(\d{1,2})\.\s*(\S.*)$`)
+	levelRe   = regexp.MustCompile(`(?i)^stufe\s*(\d{1,2})$`)
+	amountRe  = regexp.MustCompile(`(?i)^(?:einkünfte:?\s*)?(?:über\s+)?(\d{1,3}(?:\.\d{3})*(?:,\d{1,2})?|\d+(?:,\d{1,2})?)\s*(?:€|eur|euro)$`)
+	periodRe  = regexp.MustCompile(`(?i)^(?:(?:seit|bis|ab|jahr)\s+)?(?:\d{1,2}\.){0,2}\d{4}(?:\s*(?:-|–|bis)\s*(?:(?:\d{1,2}\.){0,2}\d{4}|heute))?$|^(?:monatlich|jährlich|vierteljährlich|einmalig)$`)
+	// \b only knows ASCII letters, so words are delimited by anything but a
+	// letter or digit here
+	orgRe     = regexp.MustCompile(`(?i)(?:^|[^\p{L}\p{N}])(?:gmbh|mbh|ag|se|kg|kgaa|ohg|gbr|eg|aör|partg|ltd|inc|llc|e\.\s?v\.|stiftung|verband|verein|kanzlei|universität|hochschule|bank|sparkasse|gesellschaft|institut|kammer|anstalt|holding|genossenschaft|akademie|rundfunk|partei)(?:$|[^\p{L}\p{N}])|(?:verband|verein|stiftung|gesellschaft|kammer|bank|werke|betriebe)(?:$|[^\p{L}\p{N}])`)
+	placeRe   = regexp.MustCompile(`^\p{Lu}[\p{L}.\-/ ]*$`)
+	decimalRe = regexp.MustCompile(`(\d),(\d)`)
+)
+
+// income levels (Stufen) of the Verhaltensregeln in EUR, the last one is open
+var levels = [][2]float64{
+	{1000, 3500}, {3500, 7000}, {7000, 15000}, {15000, 30000}, {30000, 50000},
+	{50000, 75000}, {75000, 100000}, {100000, 150000}, {150000, 250000}, {250000, 0},
+}
+
+type block struct {
+	text string
+	bold bool
+}
+
+// Parse splits the mandated publishable information of an MdB into entries.
+// The categories are taken from the numbered headings of the document.
+func Parse(raw string) []v1.Disclosure {
+	var (
+		result       []v1.Disclosure
+		category     int
+		categoryName string
+	)
+
+	for _, b := range blocks(raw) {
+		if m := headingRe.FindStringSubmatch(b.text); m != nil && !strings.Contains(b.text, "\n") {
+			n, _ := strconv.Atoi(m[1])
+			if n >= 1 && n <= 10 && (b.bold || !containsIncome(b.text)) {
+				category, categoryName = n, m[2]
+				continue
+			}
+		}
+
+		d := parseEntry(b.text)
+		d.Category, d.CategoryName = category, categoryName
+
+		// a line with only a period or income amends the previous entry
+		if d.Organisation == "" && d.Role == "" && d.Location == "" && len(d.Unparsed) == 0 && len(result) > 0 {
+			prev := &result[len(result)-1]
+			if prev.Category == category && prev.Income == nil {
+				prev.Raw += "\n" + d.Raw
+				prev.Income = d.Income
+				prev.Period = strings.Trim(prev.Period+", "+d.Period, ", ")
+				continue
+			}
+		}
+
+		result = append(result, d)
+	}
+
+	return result
+}
+
+func parseEntry(text string) v1.Disclosure {
+	d := v1.Disclosure{Raw: text}
+
+	var (
+		fields  []string
+		periods []string
+	)
+	// protect decimal commas of amounts like "5.000,00 €" from the split
+	protected := decimalRe.ReplaceAllString(text, "$1\x00$2")
+	for _, token := range strings.FieldsFunc(protected, func(r rune) bool { return r == ',' || r == '\n' || r == ';' }) {
+		token = strings.TrimSpace(strings.ReplaceAll(token, "\x00", ","))
+		if token == "" {
+			continue
+		}
+		if d.Income == nil {
+			if d.Income = parseIncome(token); d.Income != nil {
+				continue
+			}
+		}
+		switch {
+		case periodRe.MatchString(token):
+			periods = append(periods, token)
+		default:
+			fields = append(fields, token)
+		}
+	}
+	d.Period = strings.Join(periods, ", ")
+
+	org := -1
+	for i, f := range fields {
+		if orgRe.MatchString(f) {
+			org = i
+			break
+		}
+	}
+
+	switch {
+	case org >= 0:
+		d.Organisation = fields[org]
+		if org > 0 {
+			d.Role = fields[org-1]
+		}
+		if org+1 < len(fields) && placeRe.MatchString(fields[org+1]) {
+			d.Location = fields[org+1]
+			fields = append(fields[:org+1], fields[org+2:]...)
+		}
+		fields = removeAt(fields, org, org > 0)
+	case len(fields) >= 3:
+		d.Role, d.Organisation = fields[0], fields[1]
+		if placeRe.MatchString(fields[2]) {
+			d.Location = fields[2]
+			fields = fields[3:]
+		} else {
+			fields = fields[2:]
+		}
+	case len(fields) == 2 && placeRe.MatchString(fields[1]):
+		d.Organisation, d.Location = fields[0], fields[1]
+		fields = nil
+	}
+
+	d.Unparsed = fields
+	return d
+}
+
+// removeAt drops the organisation and, if withRole, the role before it.
+func removeAt(fields []string, org int, withRole bool) []string {
+	start := org
+	if withRole {
+		start = org - 1
+	}
+	return append(fields[:start:start], fields[org+1:]...)
+}
+
+func parseIncome(token string) *v1.Income {
+	if m := levelRe.FindStringSubmatch(token); m != nil {
+		level, _ := strconv.Atoi(m[1])
+		if level < 1 || level > len(levels) {
+			return nil
+		}
+		return &v1.Income{Level: level, Min: levels[level-1][0], Max: levels[level-1][1], Raw: token}
+	}
+
+	if m := amountRe.FindStringSubmatch(token); m != nil {
+		amount, err := strconv.ParseFloat(strings.ReplaceAll(strings.ReplaceAll(m[1], ".", ""), ",", "."), 64)
+		if err != nil {
+			return nil
+		}
+		return &v1.Income{Amount: &amount, Raw: token}
+	}
+
+	return nil
+}
+
+func containsIncome(text string) bool {
+	for _, token := range strings.Split(text, ",") {
+		if parseIncome(strings.TrimSpace(token)) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// blocks flattens the HTML (or plain text) into paragraphs. Line breaks within
+// a paragraph are kept, bold marks paragraphs that are entirely emphasised.
+func blocks(raw string) []block {
+	var (
+		result   []block
+		current  strings.Builder
+		boldText strings.Builder
+		bold     int
+	)
+
+	flush := func() {
+		text := normalize(current.String())
+		if text != "" {
+			result = append(result, block{text: text, bold: normalize(boldText.String()) == text})
+		}
+		current.Reset()
+		boldText.Reset()
+	}
+
+	// plain text documents separate entries by line, in HTML a line break is
+	// only formatting
+	plain := !strings.Contains(raw, "<")
+
+	z := html.NewTokenizer(strings.NewReader(raw))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			flush()
+			return result
+		case html.TextToken:
+			text := string(z.Text())
+			if !plain {
+				text = strings.ReplaceAll(text, "\n", " ")
+			}
+			for i, line := range strings.Split(text, "\n") {
+				if i > 0 {
+					flush()
+				}
+				current.WriteString(line)
+				if bold > 0 {
+					boldText.WriteString(line)
+				}
+			}
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "br":
+				current.WriteString("\n")
+				if bold > 0 {
+					boldText.WriteString("\n")
+				}
+			case "strong", "b":
+				if tt == html.StartTagToken {
+					bold++
+				} else if tt == html.EndTagToken && bold > 0 {
+					bold--
+				}
+			case "p", "div", "li", "ul", "ol", "h1", "h2", "h3", "h4", "h5", "h6", "tr", "table":
+				flush()
+			}
+		}
+	}
+}
+
+func normalize(s string) string {
+	lines := strings.Split(s, "\n")
+	kept := lines[:0]
+	for _, l := range lines {
+		l = strings.Join(strings.Fields(strings.ReplaceAll(l, " ", " ")), " ")
+		if l != "" {
+			kept = append(kept, l)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,169 @@
+package disclosure
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+)
+
+// entry is the parsed part of a disclosure, without Raw and CategoryName.
+type entry struct {
+	category       int
+	role, org, loc string
+	level          int
+	amount         float64
+	period         string
+	unparsed       []string
+}
+
+func entries(disclosures []v1.Disclosure) []entry {
+	var result []entry
+	for _, d := range disclosures {
+		e := entry{category: d.Category, role: d.Role, org: d.Organisation, loc: d.Location, period: d.Period}
+		if len(d.Unparsed) > 0 {
+			e.unparsed = d.Unparsed
+		}
+		if d.Income != nil {
+			e.level = d.Income.Level
+			if d.Income.Amount != nil {
+				e.amount = *d.Income.Amount
+			}
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// The samples follow the layout of the Veröffentlichungspflichtige Angaben in
+// the MdB bios: numbered bold headings, one paragraph per entry and the
+// period and income level on a line of their own.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		sample string
+		want   []entry
+	}{
+		{"lawyer.html", []entry{
+			{category: 1, role: "Rechtsanwältin", org: "Kanzlei Müller & Partner", loc: "Berlin"},
+			{category: 2, role: "Rechtsanwältin", org: "Kanzlei Müller & Partner", loc: "Berlin", level: 3, period: "2023"},
+			{category: 2, role: "Vortrag", org: "Deutsche Bank AG", loc: "Frankfurt am Main", amount: 5000, period: "15.03.2024"},
+			{category: 3, role: "Mitglied des Aufsichtsrates", org: "Berliner Wasserbetriebe AöR", loc: "Berlin"},
+			{category: 5, role: "Mitglied des Vorstandes", org: "Arbeiterwohlfahrt Landesverband Berlin e. V.", loc: "Berlin", unparsed: []string{"ehrenamtlich"}},
+			{category: 5, unparsed: []string{"irgendwas unklar"}},
+		}},
+		// income lines in a paragraph of their own amend the entry above
+		{"farmer.html", []entry{
+			{category: 2, role: "Landwirt", org: "Ackerbaubetrieb", loc: "Kleinstadt", level: 1, period: "monatlich"},
+			{category: 2, role: "Mitglied des Beirates", org: "Raiffeisen Agrar Genossenschaft eG", loc: "Oldenburg", level: 2, period: "2022"},
+			{category: 4, role: "Mitglied des Verwaltungsrates", org: "Sparkasse Leer-Wittmund", loc: "Leer"},
+			{category: 7, org: "Agrar Holding GmbH & Co. KG", loc: "Leer"},
+		}},
+		{"plain.txt", []entry{
+			{category: 1, role: "Lehrer", org: "Gymnasium am Markt", loc: "Kassel"},
+			{category: 2, role: "Autor", org: "Buchverlag Nord GmbH", loc: "Hamburg", amount: 1250, period: "2024"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sample, func(t *testing.T) {
+			raw, err := os.ReadFile(filepath.Join("testdata", tt.sample))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := entries(Parse(string(raw)))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d entries, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if !reflect.DeepEqual(got[i], tt.want[i]) {
+					t.Errorf("entry %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseCategoryNames(t *testing.T) {
+	got := Parse(`<p><strong>3. Funktionen in Unternehmen</strong></p><p>Beirat, Stadtwerke GmbH, Kiel</p>`)
+	if len(got) != 1 || got[0].CategoryName != "Funktionen in Unternehmen" || got[0].Raw != "Beirat, Stadtwerke GmbH, Kiel" {
+		t.Errorf("Parse = %+v", got)
+	}
+
+	// a numbered line that isn't bold and contains an amount is an entry
+	got = Parse("<p><strong>2. Entgeltliche Tätigkeiten neben dem Mandat</strong></p><p>1. Vortrag, Sparkasse Kiel, 2024, 800 €</p>")
+	if len(got) != 1 || got[0].Category != 2 || got[0].Income == nil {
+		t.Errorf("Parse = %+v", got)
+	}
+
+	if got := Parse(""); got != nil {
+		t.Errorf("Parse of nothing = %+v", got)
+	}
+}
+
+func TestParseIncome(t *testing.T) {
+	tests := []struct {
+		token    string
+		level    int
+		min, max float64
+		amount   float64
+		ok       bool
+	}{
+		{"Stufe 1", 1, 1000, 3500, 0, true},
+		{"Stufe 10", 10, 250000, 0, 0, true},
+		{"stufe 4", 4, 15000, 30000, 0, true},
+		{"Stufe 0", 0, 0, 0, 0, false},
+		{"Stufe 11", 0, 0, 0, 0, false},
+		{"5.000,00 €", 0, 0, 0, 5000, true},
+		{"1.250 €", 0, 0, 0, 1250, true},
+		{"800 EUR", 0, 0, 0, 800, true},
+		{"Einkünfte: 12.000 Euro", 0, 0, 0, 12000, true},
+		{"über 100.000 €", 0, 0, 0, 100000, true},
+		{"2024", 0, 0, 0, 0, false},
+		{"Berlin", 0, 0, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			got := parseIncome(tt.token)
+			if (got != nil) != tt.ok {
+				t.Fatalf("parseIncome = %+v, want ok %v", got, tt.ok)
+			}
+			if got == nil {
+				return
+			}
+			amount := 0.0
+			if got.Amount != nil {
+				amount = *got.Amount
+			}
+			if got.Level != tt.level || got.Min != tt.min || got.Max != tt.max || amount != tt.amount || got.Raw != tt.token {
+				t.Errorf("parseIncome = %+v with amount %v", got, amount)
+			}
+		})
+	}
+}
+
+func TestOrgRe(t *testing.T) {
+	tests := []struct {
+		field string
+		org   bool
+	}{
+		{"Klärwerk AöR", true},
+		{"Anstalt des öffentlichen Rechts", true},
+		{"Förderverein Schule e. V.", true},
+		{"Freunde des Hauses e.V.", true},
+		{"Stadtwerke Kiel", true},
+		{"Müller GmbH & Co. KG", true},
+		// abbreviations within words with umlauts
+		{"Fräse", false},
+		{"Bürokag", false},
+		{"Rechtsanwältin", false},
+		{"Kiel", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			if got := orgRe.MatchString(tt.field); got != tt.org {
+				t.Errorf("orgRe.MatchString = %v, want %v", got, tt.org)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package events
+
+import (
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+const replaySize = 1000
+
+// Bus fans published events out to all current subscribers. Slow subscribers
+// don't block publishing, events that don't fit into their buffer are dropped
+// and counted. The most recent events are kept so that subscribers can resume after a
+// reconnect.
+type Bus struct {
+	logger *slog.Logger
+
+	epoch uint32
+
+	mu          sync.Mutex
+	lastID      ID
+	nextSub     int
+	subscribers map[int]*subscriber
+	replay      []Event
+	dropped     uint64
+}
+
+type subscriber struct {
+	ch chan Event
+	// dropped counts the events lost since the subscriber last kept up
+	dropped uint64
+}
+
+func NewBus(logger *slog.Logger) *Bus {
+	epoch := rand.Uint32()
+	for epoch == 0 {
+		epoch = rand.Uint32()
+	}
+	return &Bus{
+		logger:      logger,
+		epoch:       epoch,
+		lastID:      newID(epoch, 0),
+		subscribers: map[int]*subscriber{},
+	}
+}
+
+// Publish assigns an ID and timestamp to e and delivers it to all subscribers.
+func (b *Bus) Publish(e Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID = newID(b.epoch, b.lastID.Seq()+1)
+	e.ID = b.lastID
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	b.replay = append(b.replay, e)
+	if len(b.replay) > replaySize {
+		b.replay = b.replay[len(b.replay)-replaySize:]
+	}
+
+	for id, sub := range b.subscribers {
+		select {
+		case sub.ch <- e:
+			if sub.dropped > 0 {
+				b.logger.Info("event subscriber caught up", "subscriber", id, "dropped", sub.dropped)
+				sub.dropped = 0
+			}
+		default:
+			if sub.dropped == 0 {
+				b.logger.Warn("event subscriber too slow, dropping events", "subscriber", id, "event", e.ID)
+			}
+			sub.dropped++
+			b.dropped++
+		}
+	}
+
+	return e
+}
+
+// Dropped returns how many events didn't fit into the buffer of a
+// subscriber, in total.
+func (b *Bus) Dropped() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// Subscribe registers a new subscriber. The returned function removes the
+// subscription and closes the channel.
+func (b *Bus) Subscribe(buffer int) (<-chan Event, func()) {
+	_, ch, cancel := b.SubscribeSince(0, buffer)
+	return ch, cancel
+}
+
+// SubscribeSince registers a new subscriber and returns the retained events
+// published after lastID. If events after lastID may be missing, because
+// lastID is of another epoch, e.g. from before a restart or of another
+// instance, or older than the retained events, a StreamResync event comes
+// first. Events of another epoch are unrelated and aren't replayed.
+func (b *Bus) SubscribeSince(lastID ID, buffer int) ([]Event, <-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var missed []Event
+	if lastID != 0 {
+		known := lastID.Epoch() == b.epoch && lastID.Seq() <= b.lastID.Seq()
+		if !known || (len(b.replay) > 0 && b.replay[0].ID.Seq() > lastID.Seq()+1) {
+			missed = append(missed, Event{ID: b.lastID, Type: StreamResync, Time: time.Now().UTC()})
+		}
+		for _, e := range b.replay {
+			if known && e.ID.Seq() > lastID.Seq() {
+				missed = append(missed, e)
+			}
+		}
+	}
+
+	id := b.nextSub
+	b.nextSub++
+	ch := make(chan Event, buffer)
+	b.subscribers[id] = &subscriber{ch: ch}
+
+	var once sync.Once
+	return missed, ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers, id)
+			close(ch)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+func TestSubscribeSince(t *testing.T) {
+	bus := NewBus(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	var published []Event
+	for range 3 {
+		published = append(published, bus.Publish(Event{Type: PoliticianAdded, Topic: TopicPoliticians}))
+	}
+	last := published[2].ID
+	other := bus.epoch + 1
+
+	tests := []struct {
+		name   string
+		lastID ID
+		resync bool
+		seqs   []uint32
+	}{
+		{"new subscriber", 0, false, nil},
+		{"resuming", published[0].ID, false, []uint32{2, 3}},
+		{"up to date", last, false, nil},
+		{"start of the epoch", newID(bus.epoch, 0), false, []uint32{1, 2, 3}},
+		{"ahead of the bus", newID(bus.epoch, 4), true, nil},
+		{"another epoch", newID(other, 1), true, nil},
+		{"before epochs", newID(0, 1), true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missed, _, cancel := bus.SubscribeSince(tt.lastID, 1)
+			defer cancel()
+
+			if tt.resync {
+				if len(missed) == 0 || missed[0].Type != StreamResync || missed[0].ID != last {
+					t.Fatalf("missed = %+v, want a resync to %s first", missed, last)
+				}
+				missed = missed[1:]
+			}
+			var seqs []uint32
+			for _, e := range missed {
+				if e.Type == StreamResync {
+					t.Errorf("unexpected resync %+v", e)
+				}
+				seqs = append(seqs, e.ID.Seq())
+			}
+			if !slices.Equal(seqs, tt.seqs) {
+				t.Errorf("replayed %v, want %v", seqs, tt.seqs)
+			}
+		})
+	}
+
+	// events that aren't retained anymore are missing
+	for range replaySize {
+		bus.Publish(Event{Type: PoliticianUpdated, Topic: TopicPoliticians})
+	}
+	missed, _, cancel := bus.SubscribeSince(published[0].ID, 1)
+	defer cancel()
+	if len(missed) != replaySize+1 || missed[0].Type != StreamResync || missed[1].ID.Seq() != 4 {
+		t.Errorf("missed %d events starting with %+v, want a resync and the retained %d", len(missed), missed[0], replaySize)
+	}
+}
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		s     string
+		id    ID
+		valid bool
+	}{
+		{"3405691582-7", newID(3405691582, 7), true},
+		{"0-0", 0, true},
+		// sent by clients of versions without epochs
+		{"42", newID(0, 42), true},
+		{"", 0, false},
+		{"1-", 0, false},
+		{"x-1", 0, false},
+		{"4294967296-1", 0, false},
+		{"-1", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			id, err := ParseID(tt.s)
+			if (err == nil) != tt.valid || id != tt.id {
+				t.Errorf("ParseID = %v, %v, want %v", id, err, tt.id)
+			}
+		})
+	}
+
+	data, err := json.Marshal(Event{ID: newID(5, 9)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var e Event
+	if err := json.Unmarshal(data, &e); err != nil || e.ID != newID(5, 9) {
+		t.Errorf("round trip of %s = %v, %v", data, e.ID, err)
+	}
+}
@@ -0,0 +1,124 @@
+package events
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Type string
+
+const (
+	PoliticianAdded            Type = "politician.added"
+	PoliticianLeft             Type = "politician.left"
+	PoliticianUpdated          Type = "politician.updated"
+	PoliticianFactionChanged   Type = "politician.faction_changed"
+	PoliticianPhotoChanged     Type = "politician.photo_changed"
+	CommitteeAdded             Type = "committee.added"
+	CommitteeRemoved           Type = "committee.removed"
+	CommitteeMembershipChanged Type = "committee.membership_changed"
+	CommitteeNewsPublished     Type = "committee.news_published"
+	// StreamResync is sent to a resuming subscriber instead of the events it
+	// missed, if they aren't known anymore. Its ID is the latest one, so
+	// subscribers reload what they track and resume from there.
+	StreamResync Type = "stream.resync"
+)
+
+type Topic string
+
+const (
+	TopicPoliticians Topic = "politicians"
+	TopicCommittees  Topic = "committees"
+	TopicNews        Topic = "news"
+)
+
+// ID identifies an event. The upper 32 bits are the epoch of the bus that
+// published it, which is chosen at random when the bus is created, the lower
+// ones count the events of that bus from 1. IDs of another process, e.g. from
+// before a restart or of another instance, are told apart by their epoch.
+// As text an ID is written <epoch>-<seq>.
+type ID uint64
+
+func newID(epoch, seq uint32) ID {
+	return ID(uint64(epoch)<<32 | uint64(seq))
+}
+
+func (id ID) Epoch() uint32 { return uint32(id >> 32) }
+
+func (id ID) Seq() uint32 { return uint32(id) }
+
+func (id ID) String() string {
+	return fmt.Sprintf("%d-%d", id.Epoch(), id.Seq())
+}
+
+// ParseID reads an ID written by String. A plain number, as sent by clients
+// of versions without epochs, is an ID of epoch 0, which no bus has.
+func ParseID(s string) (ID, error) {
+	epoch, seq, ok := strings.Cut(s, "-")
+	if !ok {
+		epoch, seq = "0", s
+	}
+	e, err := strconv.ParseUint(epoch, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid event ID %q", s)
+	}
+	n, err := strconv.ParseUint(seq, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid event ID %q", s)
+	}
+	return newID(uint32(e), uint32(n)), nil
+}
+
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+func (id *ID) UnmarshalText(text []byte) error {
+	parsed, err := ParseID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// Event describes a single change detected between two refreshes of the
+// upstream catalogs.
+type Event struct {
+	ID              ID        `json:"id"`
+	Type            Type      `json:"type"`
+	Topic           Topic     `json:"topic"`
+	Time            time.Time `json:"time"`
+	PoliticianID    string    `json:"politicianId,omitempty"`
+	Faction         string    `json:"faction,omitempty"`
+	PreviousFaction string    `json:"previousFaction,omitempty"`
+	CommitteeID     string    `json:"committeeId,omitempty"`
+	Data            any       `json:"data,omitempty"`
+}
+
+// In tells whether e is of one of topics, which is true for all events if
+// topics is empty and for resyncs, which have no topic.
+func (e Event) In(topics []Topic) bool {
+	return len(topics) == 0 || e.Topic == "" || slices.Contains(topics, e.Topic)
+}
+
+// Change is the payload of events that replace one value with another.
+type Change struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type MembershipAction string
+
+const (
+	MembershipJoined MembershipAction = "joined"
+	MembershipLeft   MembershipAction = "left"
+)
+
+// Membership is the payload of CommitteeMembershipChanged events.
+type Membership struct {
+	Action MembershipAction `json:"action"`
+	Name   string           `json:"name"`
+}
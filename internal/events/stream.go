@@ -0,0 +1,192 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+)
+
+const keepAliveInterval = 25 * time.Second
+
+// Stream serves the events of a Bus as server-sent events or NDJSON.
+type Stream struct {
+	bus      *Bus
+	shutdown <-chan struct{}
+}
+
+// NewStream creates a Stream whose open connections are closed as soon as
+// shutdown is closed.
+func NewStream(bus *Bus, shutdown <-chan struct{}) *Stream {
+	return &Stream{bus: bus, shutdown: shutdown}
+}
+
+// eventFormat frames events for one media type of the stream.
+type eventFormat struct {
+	open      func(w io.Writer) error
+	event     func(w io.Writer, e Event) error
+	keepAlive func(w io.Writer) error
+}
+
+var eventFormats = map[string]eventFormat{
+	"text/event-stream": {
+		open: func(w io.Writer) error {
+			_, err := fmt.Fprintf(w, "retry: %d\n\n", (5 * time.Second).Milliseconds())
+			return err
+		},
+		event: func(w io.Writer, e Event) error {
+			data, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+			return err
+		},
+		keepAlive: func(w io.Writer) error {
+			_, err := fmt.Fprint(w, ": keep-alive\n\n")
+			return err
+		},
+	},
+	// every line is an event, there is no room for keep-alives
+	"application/x-ndjson": {
+		open: func(w io.Writer) error { return nil },
+		event: func(w io.Writer, e Event) error {
+			data, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(append(data, '\n'))
+			return err
+		},
+		keepAlive: func(w io.Writer) error { return nil },
+	},
+}
+
+// Serve streams events until the client disconnects or the server shuts
+// down, as server-sent events or, with Accept: application/x-ndjson or
+// ?format=ndjson, as one JSON object per line. It resumes after the ID in the
+// Last-Event-ID header (or the lastEventId query parameter), or sends a
+// stream.resync event if it can't, and can be limited to topics with
+// ?topic=politicians,committees,news.
+func (s *Stream) Serve(w http.ResponseWriter, req *http.Request) {
+	mediaType := "text/event-stream"
+	switch req.URL.Query().Get("format") {
+	case "ndjson":
+		mediaType = "application/x-ndjson"
+	case "sse", "":
+		var ok bool
+		if mediaType, ok = rest.Negotiate(w, req, "text/event-stream", "application/x-ndjson"); !ok {
+			return
+		}
+	default:
+		http.Error(w, "Invalid format", http.StatusBadRequest)
+		return
+	}
+	format := eventFormats[mediaType]
+
+	topics, err := parseTopics(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	lastID, err := parseLastEventID(req)
+	if err != nil {
+		http.Error(w, "Invalid Last-Event-ID", http.StatusBadRequest)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	missed, ch, cancel := s.bus.SubscribeSince(lastID, 64)
+	defer cancel()
+
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := format.open(w); err != nil {
+		return
+	}
+	for _, e := range missed {
+		if err := writeEvent(w, format, e, topics); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-s.shutdown:
+			return
+		case <-keepAlive.C:
+			if err := format.keepAlive(w); err != nil {
+				return
+			}
+		case e, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, format, e, topics); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w io.Writer, format eventFormat, e Event, topics []Topic) error {
+	if !e.In(topics) {
+		return nil
+	}
+	return format.event(w, e)
+}
+
+func parseTopics(req *http.Request) ([]Topic, error) {
+	var names []string
+	for _, param := range req.URL.Query()["topic"] {
+		names = append(names, strings.Split(param, ",")...)
+	}
+	return ParseTopics(names)
+}
+
+// ParseTopics checks the names of topics, blank names are skipped.
+func ParseTopics(names []string) ([]Topic, error) {
+	var topics []Topic
+	for _, name := range names {
+		topic := Topic(strings.TrimSpace(name))
+		switch topic {
+		case TopicPoliticians, TopicCommittees, TopicNews:
+			topics = append(topics, topic)
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown topic: %s", topic)
+		}
+	}
+	return topics, nil
+}
+
+func parseLastEventID(req *http.Request) (ID, error) {
+	id := req.Header.Get("Last-Event-ID")
+	if id == "" {
+		id = req.URL.Query().Get("lastEventId")
+	}
+	if id == "" {
+		return 0, nil
+	}
+	return ParseID(id)
+}
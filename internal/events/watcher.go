@@ -0,0 +1,304 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/history"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+// Watcher periodically re-reads the upstream catalogs, refreshes the cached
+// details of changed entries and publishes the differences on a Bus.
+type Watcher struct {
+	bus              *Bus
+	interval         time.Duration
+	logger           *slog.Logger
+	politicians      resources.CatalogueGetter[v1.PersonListEntry]
+	bios             resources.DetailRefresher[v1.Politician]
+	committees       resources.CatalogueGetter[v1.CommitteeListEntry]
+	committeeDetails resources.DetailRefresher[v1.CommitteeDetails]
+
+	recorder Recorder
+
+	knownPoliticians map[string]politicianState
+	knownCommittees  map[string]committeeState
+}
+
+// Recorder keeps a history of the catalog entries the watcher observed.
+type Recorder interface {
+	Record(ctx context.Context, kind history.Kind, id string, v any) error
+	Remove(ctx context.Context, kind history.Kind, id string) error
+}
+
+type politicianState struct {
+	entry v1.PersonListEntry
+	// exitDate is from the last bio read, which bioRead tells. Like the
+	// catalogs, the first bio read only establishes the baseline.
+	exitDate string
+	bioRead  bool
+}
+
+type committeeState struct {
+	entry   v1.CommitteeListEntry
+	members map[string]v1.PersonListEntry
+	news    map[string]v1.NewsItem
+}
+
+func NewWatcher(
+	bus *Bus,
+	interval time.Duration,
+	logger *slog.Logger,
+	politicians resources.CatalogueGetter[v1.PersonListEntry],
+	bios resources.DetailRefresher[v1.Politician],
+	committees resources.CatalogueGetter[v1.CommitteeListEntry],
+	committeeDetails resources.DetailRefresher[v1.CommitteeDetails],
+) *Watcher {
+	return &Watcher{
+		bus:              bus,
+		interval:         interval,
+		logger:           logger,
+		politicians:      politicians,
+		bios:             bios,
+		committees:       committees,
+		committeeDetails: committeeDetails,
+	}
+}
+
+// RecordTo makes the watcher record every observed catalog entry.
+func (w *Watcher) RecordTo(r Recorder) {
+	w.recorder = r
+}
+
+// Run refreshes immediately and then once per interval until ctx is done.
+// The first refresh only establishes the baseline and publishes nothing.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.Refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watcher) Refresh(ctx context.Context) {
+	if err := w.refreshPoliticians(ctx); err != nil {
+		w.logger.Error("failed to refresh politicians", "error", err)
+	}
+	if err := w.refreshCommittees(ctx); err != nil {
+		w.logger.Error("failed to refresh committees", "error", err)
+	}
+}
+
+func (w *Watcher) refreshPoliticians(ctx context.Context) error {
+	catalog, err := w.politicians.GetCatalog()
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]politicianState, len(catalog))
+	for _, p := range catalog {
+		current[p.GetId()] = politicianState{entry: p}
+		w.record(ctx, history.KindPolitician, p.GetId(), p)
+	}
+
+	if w.knownPoliticians == nil {
+		w.knownPoliticians = current
+		return nil
+	}
+
+	for id, state := range current {
+		old, known := w.knownPoliticians[id]
+		if !known {
+			w.publishPolitician(PoliticianAdded, state.entry, nil)
+			continue
+		}
+		current[id] = w.comparePolitician(ctx, old, state.entry)
+	}
+
+	for id, old := range w.knownPoliticians {
+		if _, ok := current[id]; !ok {
+			w.publishPolitician(PoliticianLeft, old.entry, nil)
+			w.remove(ctx, history.KindPolitician, id)
+		}
+	}
+
+	w.knownPoliticians = current
+	return nil
+}
+
+// comparePolitician publishes the changes from state to p and returns the
+// new state. The bio is only read if the catalog lists a change.
+func (w *Watcher) comparePolitician(ctx context.Context, state politicianState, p v1.PersonListEntry) politicianState {
+	old := state.entry
+	state.entry = p
+	if old.Faction != p.Faction {
+		w.bus.Publish(Event{
+			Type:            PoliticianFactionChanged,
+			Topic:           TopicPoliticians,
+			PoliticianID:    p.GetId(),
+			Faction:         p.Faction,
+			PreviousFaction: old.Faction,
+			Data:            Change{From: old.Faction, To: p.Faction},
+		})
+	}
+
+	if old.PhotoChangedDateTime != p.PhotoChangedDateTime || old.PhotoLargeURL != p.PhotoLargeURL {
+		w.publishPolitician(PoliticianPhotoChanged, p, Change{From: old.PhotoLargeURL, To: p.PhotoLargeURL})
+	}
+
+	if old.ChangedDateTime == p.ChangedDateTime {
+		return state
+	}
+
+	bio, err := w.bios.Refresh(ctx, p)
+	if err != nil {
+		w.logger.Error("failed to refresh politician bio", "id", p.GetId(), "error", err)
+		// compared again with the next refresh
+		state.entry.ChangedDateTime = old.ChangedDateTime
+		return state
+	}
+	w.publishPolitician(PoliticianUpdated, p, bio)
+
+	if state.bioRead && state.exitDate == "" && bio.Bio.ExitDate != "" {
+		w.publishPolitician(PoliticianLeft, p, Change{To: bio.Bio.ExitDate})
+	}
+	state.exitDate, state.bioRead = bio.Bio.ExitDate, true
+	return state
+}
+
+func (w *Watcher) publishPolitician(t Type, p v1.PersonListEntry, data any) {
+	w.bus.Publish(Event{
+		Type:         t,
+		Topic:        TopicPoliticians,
+		PoliticianID: p.GetId(),
+		Faction:      p.Faction,
+		Data:         data,
+	})
+}
+
+func (w *Watcher) refreshCommittees(ctx context.Context) error {
+	catalog, err := w.committees.GetCatalog()
+	if err != nil {
+		return err
+	}
+
+	primed := w.knownCommittees != nil
+	current := make(map[string]committeeState, len(catalog))
+
+	for _, c := range catalog {
+		old, known := w.knownCommittees[c.GetId()]
+		if known && old.entry == c {
+			// the details only change along with the entry
+			current[c.GetId()] = old
+			continue
+		}
+
+		details, err := w.committeeDetails.Refresh(ctx, c)
+		if err != nil {
+			w.logger.Error("failed to refresh committee details", "id", c.GetId(), "error", err)
+			if known {
+				current[c.GetId()] = old
+			}
+			continue
+		}
+
+		state := committeeState{
+			entry:   c,
+			members: make(map[string]v1.PersonListEntry, len(details.Members)),
+			news:    make(map[string]v1.NewsItem, len(details.NewsItems)),
+		}
+		for _, m := range details.Members {
+			state.members[m.GetId()] = m
+		}
+		for _, n := range details.NewsItems {
+			state.news[newsKey(n)] = n
+		}
+		current[c.GetId()] = state
+
+		if !primed {
+			continue
+		}
+		if !known {
+			w.bus.Publish(Event{Type: CommitteeAdded, Topic: TopicCommittees, CommitteeID: c.GetId(), Data: c})
+			continue
+		}
+		w.compareMembers(c.GetId(), old.members, state.members)
+		w.compareNews(c.GetId(), old.news, state.news)
+	}
+
+	for id, old := range w.knownCommittees {
+		if _, ok := current[id]; !ok {
+			w.bus.Publish(Event{Type: CommitteeRemoved, Topic: TopicCommittees, CommitteeID: id, Data: old.entry})
+			w.remove(ctx, history.KindCommittee, id)
+		}
+	}
+
+	w.knownCommittees = current
+	return nil
+}
+
+func (w *Watcher) compareMembers(committeeID string, old map[string]v1.PersonListEntry, current map[string]v1.PersonListEntry) {
+	for id, m := range current {
+		if _, ok := old[id]; !ok {
+			w.publishMembership(committeeID, m, MembershipJoined)
+		}
+	}
+	for id, m := range old {
+		if _, ok := current[id]; !ok {
+			w.publishMembership(committeeID, m, MembershipLeft)
+		}
+	}
+}
+
+func (w *Watcher) publishMembership(committeeID string, m v1.PersonListEntry, action MembershipAction) {
+	w.bus.Publish(Event{
+		Type:         CommitteeMembershipChanged,
+		Topic:        TopicCommittees,
+		PoliticianID: m.GetId(),
+		Faction:      m.Faction,
+		CommitteeID:  committeeID,
+		Data:         Membership{Action: action, Name: m.Name.Value},
+	})
+}
+
+func (w *Watcher) compareNews(committeeID string, old map[string]v1.NewsItem, current map[string]v1.NewsItem) {
+	for key, n := range current {
+		if _, ok := old[key]; !ok {
+			w.bus.Publish(Event{Type: CommitteeNewsPublished, Topic: TopicNews, CommitteeID: committeeID, Data: n})
+		}
+	}
+}
+
+func newsKey(n v1.NewsItem) string {
+	if n.URL != "" {
+		return n.URL
+	}
+	return n.PublicationDate + "|" + n.Title
+}
+
+func (w *Watcher) record(ctx context.Context, kind history.Kind, id string, v any) {
+	if w.recorder == nil {
+		return
+	}
+	if err := w.recorder.Record(ctx, kind, id, v); err != nil {
+		w.logger.Error("failed to record history", "kind", kind, "id", id, "error", err)
+	}
+}
+
+func (w *Watcher) remove(ctx context.Context, kind history.Kind, id string) {
+	if w.recorder == nil {
+		return
+	}
+	if err := w.recorder.Remove(ctx, kind, id); err != nil {
+		w.logger.Error("failed to record history", "kind", kind, "id", id, "error", err)
+	}
+}
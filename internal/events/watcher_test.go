@@ -0,0 +1,223 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+type catalog[T any] []T
+
+func (c *catalog[T]) GetCatalog() ([]T, error) { return *c, nil }
+
+// details serves details by id and counts the refreshes.
+type details[T any] struct {
+	byID      map[string]*T
+	fail      map[string]bool
+	refreshed map[string]int
+}
+
+func newDetails[T any]() *details[T] {
+	return &details[T]{byID: map[string]*T{}, fail: map[string]bool{}, refreshed: map[string]int{}}
+}
+
+func (d *details[T]) Refresh(ctx context.Context, entry resources.Entry) (*T, error) {
+	d.refreshed[entry.GetId()]++
+	if d.fail[entry.GetId()] {
+		return nil, errors.New("upstream unavailable")
+	}
+	return d.byID[entry.GetId()], nil
+}
+
+func (d *details[T]) total() int {
+	var n int
+	for _, c := range d.refreshed {
+		n += c
+	}
+	return n
+}
+
+func politician(id, faction, changed string) v1.PersonListEntry {
+	return v1.PersonListEntry{Id: v1.ID{Value: id}, Faction: faction, ChangedDateTime: changed}
+}
+
+func bio(exitDate string) *v1.Politician {
+	return &v1.Politician{Bio: v1.PoliticianBio{ExitDate: exitDate}}
+}
+
+type watcherTest struct {
+	t          *testing.T
+	watcher    *Watcher
+	events     <-chan Event
+	politician *catalog[v1.PersonListEntry]
+	bios       *details[v1.Politician]
+	committee  *catalog[v1.CommitteeListEntry]
+	committees *details[v1.CommitteeDetails]
+}
+
+func newWatcherTest(t *testing.T) *watcherTest {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	bus := NewBus(logger)
+	ch, cancel := bus.Subscribe(100)
+	t.Cleanup(cancel)
+	wt := &watcherTest{
+		t:          t,
+		events:     ch,
+		politician: &catalog[v1.PersonListEntry]{},
+		bios:       newDetails[v1.Politician](),
+		committee:  &catalog[v1.CommitteeListEntry]{},
+		committees: newDetails[v1.CommitteeDetails](),
+	}
+	wt.watcher = NewWatcher(bus, time.Hour, logger, wt.politician, wt.bios, wt.committee, wt.committees)
+	return wt
+}
+
+// refresh runs the watcher once and returns the types of the events it
+// published, sorted.
+func (wt *watcherTest) refresh() []Type {
+	wt.watcher.Refresh(context.Background())
+	var types []Type
+	for {
+		select {
+		case e := <-wt.events:
+			types = append(types, e.Type)
+		default:
+			slices.Sort(types)
+			return types
+		}
+	}
+}
+
+func (wt *watcherTest) expect(want ...Type) {
+	wt.t.Helper()
+	slices.Sort(want)
+	if got := wt.refresh(); !slices.Equal(got, want) {
+		wt.t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestWatcherPoliticians(t *testing.T) {
+	wt := newWatcherTest(t)
+	*wt.politician = catalog[v1.PersonListEntry]{politician("1", "SPD", "t1"), politician("2", "CDU/CSU", "t1")}
+	wt.bios.byID["1"] = bio("")
+	wt.bios.byID["2"] = bio("")
+
+	// the baseline publishes nothing and reads no bios
+	wt.expect()
+	if wt.bios.total() != 0 {
+		t.Errorf("baseline refreshed %d bios", wt.bios.total())
+	}
+
+	// nothing changed
+	wt.expect()
+
+	(*wt.politician)[0] = politician("1", "BSW", "t2")
+	wt.expect(PoliticianFactionChanged, PoliticianUpdated)
+
+	// a failed refresh of the bio is tried again
+	(*wt.politician)[1] = politician("2", "CDU/CSU", "t2")
+	wt.bios.fail["2"] = true
+	wt.expect()
+	wt.bios.fail["2"] = false
+	wt.expect(PoliticianUpdated)
+
+	// leaving is published once, when the exit date appears
+	wt.bios.byID["1"] = bio("31.03.2026")
+	(*wt.politician)[0] = politician("1", "BSW", "t3")
+	wt.expect(PoliticianUpdated, PoliticianLeft)
+	(*wt.politician)[0] = politician("1", "BSW", "t4")
+	wt.expect(PoliticianUpdated)
+
+	*wt.politician = append((*wt.politician)[1:], politician("3", "Die Linke", "t1"))
+	wt.expect(PoliticianAdded, PoliticianLeft)
+
+	if got := wt.bios.refreshed; got["1"] != 3 || got["2"] != 2 || got["3"] != 0 {
+		t.Errorf("bios refreshed %v", got)
+	}
+}
+
+func TestWatcherExitDateBaseline(t *testing.T) {
+	wt := newWatcherTest(t)
+	*wt.politician = catalog[v1.PersonListEntry]{politician("1", "SPD", "t1")}
+	wt.bios.byID["1"] = bio("01.01.2020")
+	wt.expect()
+
+	// an exit date in the first bio read may be old, it's not published
+	(*wt.politician)[0] = politician("1", "SPD", "t2")
+	wt.expect(PoliticianUpdated)
+	(*wt.politician)[0] = politician("1", "SPD", "t3")
+	wt.expect(PoliticianUpdated)
+}
+
+func TestWatcherCommittees(t *testing.T) {
+	wt := newWatcherTest(t)
+	a11 := v1.CommitteeListEntry{Id: "a11", ChangedDateTime: "t1"}
+	a12 := v1.CommitteeListEntry{Id: "a12", ChangedDateTime: "t1"}
+	*wt.committee = catalog[v1.CommitteeListEntry]{a11, a12}
+	wt.committees.byID["a11"] = &v1.CommitteeDetails{Members: []v1.PersonListEntry{politician("1", "SPD", "t1")}}
+	wt.committees.byID["a12"] = &v1.CommitteeDetails{}
+
+	wt.expect()
+	if wt.committees.total() != 2 {
+		t.Errorf("baseline refreshed %d committees, want 2", wt.committees.total())
+	}
+
+	// unchanged entries aren't refreshed
+	wt.expect()
+	if wt.committees.total() != 2 {
+		t.Errorf("refreshed %d unchanged committees", wt.committees.total()-2)
+	}
+
+	a11.ChangedDateTime = "t2"
+	(*wt.committee)[0] = a11
+	wt.committees.byID["a11"] = &v1.CommitteeDetails{
+		Members:   []v1.PersonListEntry{politician("2", "CDU/CSU", "t1")},
+		NewsItems: []v1.NewsItem{{Title: "Anhörung", URL: "https://www.bundestag.de/a11/1"}},
+	}
+	wt.expect(CommitteeMembershipChanged, CommitteeMembershipChanged, CommitteeNewsPublished)
+	if got := wt.committees.refreshed; got["a11"] != 2 || got["a12"] != 1 {
+		t.Errorf("committees refreshed %v", got)
+	}
+
+	// a failed refresh keeps the old state and is tried again
+	a12.ChangedDateTime = "t2"
+	*wt.committee = catalog[v1.CommitteeListEntry]{a11, a12}
+	wt.committees.fail["a12"] = true
+	wt.expect()
+	wt.committees.fail["a12"] = false
+	wt.committees.byID["a12"] = &v1.CommitteeDetails{NewsItems: []v1.NewsItem{{Title: "Sitzung", PublicationDate: "2026-10-01"}}}
+	wt.expect(CommitteeNewsPublished)
+
+	*wt.committee = catalog[v1.CommitteeListEntry]{a12, {Id: "a13"}}
+	wt.committees.byID["a13"] = &v1.CommitteeDetails{}
+	wt.expect(CommitteeRemoved, CommitteeAdded)
+}
+
+func TestBusDropped(t *testing.T) {
+	bus := NewBus(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	slow, cancel := bus.Subscribe(1)
+	defer cancel()
+	fast, cancelFast := bus.Subscribe(10)
+	defer cancelFast()
+
+	for range 3 {
+		bus.Publish(Event{Type: PoliticianAdded})
+	}
+	if bus.Dropped() != 2 || len(slow) != 1 || len(fast) != 3 {
+		t.Errorf("dropped %d, slow got %d, fast got %d", bus.Dropped(), len(slow), len(fast))
+	}
+
+	// once the subscriber keeps up again, it gets new events
+	<-slow
+	e := bus.Publish(Event{Type: PoliticianLeft})
+	if got := <-slow; got.ID != e.ID || bus.Dropped() != 2 {
+		t.Errorf("got event %d, want %d, dropped %d", got.ID, e.ID, bus.Dropped())
+	}
+}
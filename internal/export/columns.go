@@ -0,0 +1,95 @@
+package export
+
+import (
+	"strconv"
+	"strings"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/samber/lo"
+)
+
+// The column sets below are part of the API. Append new columns at the end
+// and never rename or reorder existing ones, spreadsheets refer to them.
+
+var PersonListEntries = Columns[v1.PersonListEntry]{
+	{"id", func(p *v1.PersonListEntry) string { return p.Id.Value }},
+	{"name", func(p *v1.PersonListEntry) string { return p.Name.Value }},
+	{"lastName", func(p *v1.PersonListEntry) string { return lastName(p.Name.Value) }},
+	{"firstName", func(p *v1.PersonListEntry) string { return firstName(p.Name.Value) }},
+	{"faction", func(p *v1.PersonListEntry) string { return p.Faction }},
+	{"state", func(p *v1.PersonListEntry) string { return p.State }},
+	{"constituency.number", func(p *v1.PersonListEntry) string { return p.Constituency.Number }},
+	{"constituency.name", func(p *v1.PersonListEntry) string { return p.Constituency.Name }},
+	{"constituency.url", func(p *v1.PersonListEntry) string { return p.Constituency.Url }},
+	{"elected", func(p *v1.PersonListEntry) string { return p.Elected }},
+	{"bioUrl", func(p *v1.PersonListEntry) string { return p.BioURL }},
+	{"photoUrl", func(p *v1.PersonListEntry) string { return p.PhotoURL }},
+	{"photoLargeUrl", func(p *v1.PersonListEntry) string { return p.PhotoLargeURL }},
+	{"lastChanged", func(p *v1.PersonListEntry) string { return p.LastChanged }},
+}
+
+var Politicians = Columns[v1.Politician]{
+	{"id", func(p *v1.Politician) string { return p.Bio.Id.Value }},
+	{"lastName", func(p *v1.Politician) string { return p.Bio.LastName }},
+	{"firstName", func(p *v1.Politician) string { return p.Bio.FirstName }},
+	{"academicTitle", func(p *v1.Politician) string { return p.Bio.AcademicTitle }},
+	{"nobilityTitle", func(p *v1.Politician) string { return p.Bio.NobilityTitle }},
+	{"dateOfBirth", func(p *v1.Politician) string { return p.Bio.DateOfBirth }},
+	{"gender", func(p *v1.Politician) string { return p.Bio.Gender }},
+	{"faction", func(p *v1.Politician) string { return p.Bio.Faction }},
+	{"party", func(p *v1.Politician) string { return p.Bio.Party }},
+	{"state", func(p *v1.Politician) string { return p.Bio.State }},
+	{"constituency.number", func(p *v1.Politician) string { return p.Bio.Constituency.Number }},
+	{"constituency.name", func(p *v1.Politician) string { return p.Bio.Constituency.Name }},
+	{"elected", func(p *v1.Politician) string { return p.Bio.Elected }},
+	{"exitDate", func(p *v1.Politician) string { return p.Bio.ExitDate }},
+	{"profession", func(p *v1.Politician) string { return p.Bio.Profession.Value }},
+	{"homepage", func(p *v1.Politician) string { return p.Bio.Homepage }},
+	{"phone", func(p *v1.Politician) string { return p.Bio.Phone }},
+	{"memberships.leadCommittees", func(p *v1.Politician) string { return committeeNames(p.Bio.Memberships.LeadCommittees) }},
+	{"memberships.regularMemberCommittees", func(p *v1.Politician) string {
+		return committeeNames(p.Bio.Memberships.RegularMemberCommittees)
+	}},
+	{"memberships.substituteMemberCommittees", func(p *v1.Politician) string {
+		return committeeNames(p.Bio.Memberships.SubstituteMemberCommittees)
+	}},
+	{"memberships.viceChairOtherCommittees", func(p *v1.Politician) string {
+		return committeeNames(p.Bio.Memberships.ViceChairOtherCommittees)
+	}},
+	{"bioUrl", func(p *v1.Politician) string { return p.Bio.BioURL }},
+	{"photo.url", func(p *v1.Politician) string { return p.Media.Foto.URL }},
+	{"photo.copyright", func(p *v1.Politician) string { return p.Media.Foto.Copyright }},
+}
+
+var Committees = Columns[v1.CommitteeListEntry]{
+	{"id", func(c *v1.CommitteeListEntry) string { return c.Id }},
+	{"committeeName", func(c *v1.CommitteeListEntry) string { return c.Name }},
+	{"committeeShortName", func(c *v1.CommitteeListEntry) string { return c.ShortName }},
+	{"committeeTeaser", func(c *v1.CommitteeListEntry) string { return c.Teaser }},
+	{"live", func(c *v1.CommitteeListEntry) string { return strconv.Itoa(c.Live) }},
+	{"imageUrl", func(c *v1.CommitteeListEntry) string { return c.ImageURL }},
+	{"imageCopyright", func(c *v1.CommitteeListEntry) string { return c.ImageCopyright }},
+	{"lastChanged", func(c *v1.CommitteeListEntry) string { return c.LastChanged }},
+}
+
+var Constituencies = Columns[v1.Constituency]{
+	{"number", func(c *v1.Constituency) string { return c.Number }},
+	{"name", func(c *v1.Constituency) string { return c.Name }},
+	{"url", func(c *v1.Constituency) string { return c.Url }},
+}
+
+// names in the catalog are listed as "Last, First"
+func lastName(name string) string {
+	last, _, _ := strings.Cut(name, ", ")
+	return last
+}
+
+func firstName(name string) string {
+	_, first, _ := strings.Cut(name, ", ")
+	return first
+}
+
+// committeeNames joins multiple memberships into one cell.
+func committeeNames(committees []v1.Committee) string {
+	return strings.Join(lo.Map(committees, func(c v1.Committee, _ int) string { return c.Name }), "; ")
+}
@@ -0,0 +1,52 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"regexp"
+	"strings"
+)
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, bom bool) (*csvWriter, error) {
+	if bom {
+		if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+			return nil, err
+		}
+	}
+	return &csvWriter{w: csv.NewWriter(w)}, nil
+}
+
+func (c *csvWriter) WriteRow(row []string) error {
+	for i := range row {
+		row[i] = defuse(row[i])
+	}
+	// csv.Writer buffers and flushes whenever its buffer is full
+	return c.w.Write(row)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+var (
+	numberRe = regexp.MustCompile(`^[+-]?(?:\d+(?:[.,]\d*)?|[.,]\d+)(?:[eE][+-]?\d+)?$`)
+	phoneRe  = regexp.MustCompile(`^\+\d[\d /()-]*\d$`)
+)
+
+// defuse keeps spreadsheets from evaluating a cell as formula. Only numbers
+// like "-1,5" and phone numbers like "+49 30 227-0" may start with a sign,
+// everything else that starts like a formula is prefixed with '.
+func defuse(value string) string {
+	if value == "" || !strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return value
+	}
+	if numberRe.MatchString(value) || phoneRe.MatchString(value) {
+		return value
+	}
+	return "'" + value
+}
@@ -0,0 +1,46 @@
+package export
+
+import "testing"
+
+func TestDefuse(t *testing.T) {
+	tests := []struct {
+		value, want string
+	}{
+		{"", ""},
+		{"Müller", "Müller"},
+		{"1970", "1970"},
+		{"=1+1", "'=1+1"},
+		{"=HYPERLINK(\"https://example.org\")", "'=HYPERLINK(\"https://example.org\")"},
+		{"@SUM(A1:A2)", "'@SUM(A1:A2)"},
+		{"\tTab", "'\tTab"},
+		{"\rReturn", "'\rReturn"},
+		{"-", "'-"},
+		{"+", "'+"},
+		{"-cmd", "'-cmd"},
+		// sign and digit, but formulas nonetheless
+		{"-1+cmd|' /C calc'!A0", "'-1+cmd|' /C calc'!A0"},
+		{"+1+HYPERLINK(\"https://example.org\")", "'+1+HYPERLINK(\"https://example.org\")"},
+		{"- 1", "'- 1"},
+		{"+ 49", "'+ 49"},
+		{"-1-1", "'-1-1"},
+		{"+49 30 227-0 =1", "'+49 30 227-0 =1"},
+		// numbers
+		{"-1", "-1"},
+		{"+12", "+12"},
+		{"-1,5", "-1,5"},
+		{"-0.25", "-0.25"},
+		{"-.5", "-.5"},
+		{"+1e5", "+1e5"},
+		// phone numbers
+		{"+49 30 227-0", "+49 30 227-0"},
+		{"+49 (30) 227/12345", "+49 (30) 227/12345"},
+		{"+493022712345", "+493022712345"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := defuse(tt.value); got != tt.want {
+				t.Errorf("defuse(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
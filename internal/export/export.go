@@ -0,0 +1,156 @@
+package export
+
+import (
+	"fmt"
+	"iter"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+)
+
+// Column is a single column of a flattened resource. Nested fields are named
+// by their dotted JSON path, e.g. "constituency.number".
+type Column[T any] struct {
+	Header string
+	Value  func(*T) string
+}
+
+type Columns[T any] []Column[T]
+
+func (c Columns[T]) header() []string {
+	header := make([]string, len(c))
+	for i, col := range c {
+		header[i] = col.Header
+	}
+	return header
+}
+
+func (c Columns[T]) row(res *T) []string {
+	row := make([]string, len(c))
+	for i, col := range c {
+		row[i] = col.Value(res)
+	}
+	return row
+}
+
+// Expanded applies columns to the resource of an expanded one, see Embed for
+// columns of its embeds.
+func Expanded[T any](columns Columns[T]) Columns[rest.Embedded[T]] {
+	expanded := make(Columns[rest.Embedded[T]], len(columns))
+	for i, col := range columns {
+		expanded[i] = Column[rest.Embedded[T]]{col.Header, func(e *rest.Embedded[T]) string { return col.Value(&e.Resource) }}
+	}
+	return expanded
+}
+
+// Embed applies columns to the embed name of an expanded resource, headed
+// by name, e.g. "bio.memberships.leadCommittees". The cells are empty if the
+// embed failed.
+func Embed[T any, E any](name string, columns Columns[E]) Columns[rest.Embedded[T]] {
+	embedded := make(Columns[rest.Embedded[T]], len(columns))
+	for i, col := range columns {
+		embedded[i] = Column[rest.Embedded[T]]{name + "." + col.Header, func(e *rest.Embedded[T]) string {
+			switch v := e.Embeds[name].(type) {
+			case *E:
+				if v != nil {
+					return col.Value(v)
+				}
+			case E:
+				return col.Value(&v)
+			}
+			return ""
+		}}
+	}
+	return embedded
+}
+
+// tableWriter receives the header and then one row after another.
+type tableWriter interface {
+	WriteRow(row []string) error
+	Close() error
+}
+
+// Representations offers columns as CSV and XLSX.
+func Representations[T any](columns Columns[T]) []rest.Representation[T] {
+	return []rest.Representation[T]{CSV(columns), XLSX(columns)}
+}
+
+// CSV streams the resources as comma separated values with a header row.
+// ?bom=true prefixes a UTF-8 byte order mark, which Excel needs to detect
+// the encoding.
+func CSV[T any](columns Columns[T]) rest.Representation[T] {
+	return representation("text/csv", "csv", columns, func(w http.ResponseWriter, req *http.Request) (tableWriter, error) {
+		bom, _ := strconv.ParseBool(req.URL.Query().Get("bom"))
+		return newCSVWriter(w, bom)
+	})
+}
+
+// XLSX streams the resources as a spreadsheet with a single sheet.
+func XLSX[T any](columns Columns[T]) rest.Representation[T] {
+	return representation("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx", columns, func(w http.ResponseWriter, req *http.Request) (tableWriter, error) {
+		return newXLSXWriter(w, sheetName(req))
+	})
+}
+
+func representation[T any](mediaType, format string, columns Columns[T], open func(w http.ResponseWriter, req *http.Request) (tableWriter, error)) rest.Representation[T] {
+	write := func(w http.ResponseWriter, req *http.Request, res iter.Seq[T]) {
+		contentType := mediaType
+		if format == "csv" {
+			contentType += "; charset=utf-8; header=present"
+		}
+		w.Header().Set("Content-Type", contentType)
+		rest.Attachment(w, req, format)
+		w.WriteHeader(http.StatusOK)
+
+		// the status is sent, errors can only cut the download short
+		tw, err := open(w, req)
+		if err != nil {
+			fmt.Printf("failed to start %s export: %v\n", format, err)
+			return
+		}
+		if err := tw.WriteRow(columns.header()); err != nil {
+			fmt.Printf("failed to write %s export: %v\n", format, err)
+			return
+		}
+		for r := range res {
+			if err := tw.WriteRow(columns.row(&r)); err != nil {
+				fmt.Printf("failed to write %s export: %v\n", format, err)
+				return
+			}
+		}
+		if err := tw.Close(); err != nil {
+			fmt.Printf("failed to finish %s export: %v\n", format, err)
+		}
+	}
+
+	return rest.Representation[T]{
+		MediaType: mediaType,
+		Format:    format,
+		One: func(w http.ResponseWriter, req *http.Request, res *T) {
+			write(w, req, slices.Values([]T{*res}))
+		},
+		Many: write,
+	}
+}
+
+func sheetName(req *http.Request) string {
+	name := path.Base(req.URL.Path)
+	if name == "/" || name == "." {
+		return "export"
+	}
+	// sheet names are limited to 31 characters without []:*?/\
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if len(name) > 31 {
+		name = name[:31]
+	}
+	return name
+}
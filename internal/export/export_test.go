@@ -0,0 +1,59 @@
+package export
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+)
+
+func TestEmbedColumns(t *testing.T) {
+	columns := append(Expanded(PersonListEntries), Embed[v1.PersonListEntry]("bio", Politicians)...)
+	rows := []rest.Embedded[v1.PersonListEntry]{
+		{
+			Resource: v1.PersonListEntry{Id: v1.ID{Value: "1001"}, Name: v1.MdbName{Value: "Müller, Anna"}},
+			Embeds: map[string]any{"bio": &v1.Politician{Bio: v1.PoliticianBio{
+				Party: "SPD",
+				Memberships: v1.Memberships{
+					RegularMemberCommittees: []v1.Committee{{Name: "Haushaltsausschuss"}, {Name: "Innenausschuss"}},
+				},
+			}}},
+		},
+		// the bio failed
+		{Resource: v1.PersonListEntry{Id: v1.ID{Value: "1002"}}, Embeds: map[string]any{"bio": nil}},
+	}
+
+	w := httptest.NewRecorder()
+	CSV(columns).Many(w, httptest.NewRequest(http.MethodGet, "/politicians?embed=bio&format=csv", nil), slices.Values(rows))
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil || len(records) != 3 {
+		t.Fatalf("records = %v, %v", records, err)
+	}
+
+	cell := func(record []string, header string) string {
+		i := slices.Index(records[0], header)
+		if i < 0 {
+			t.Fatalf("no column %s in %v", header, records[0])
+		}
+		return record[i]
+	}
+	if got := cell(records[1], "lastName"); got != "Müller" {
+		t.Errorf("lastName = %q", got)
+	}
+	if got := cell(records[1], "bio.party"); got != "SPD" {
+		t.Errorf("bio.party = %q", got)
+	}
+	if got := cell(records[1], "bio.memberships.regularMemberCommittees"); got != "Haushaltsausschuss; Innenausschuss" {
+		t.Errorf("bio.memberships.regularMemberCommittees = %q", got)
+	}
+	if got := cell(records[2], "id"); got != "1002" {
+		t.Errorf("id = %q", got)
+	}
+	if got := cell(records[2], "bio.party"); got != "" {
+		t.Errorf("bio.party of a failed embed = %q", got)
+	}
+}
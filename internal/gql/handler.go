@@ -0,0 +1,166 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+// maxBodySize limits the size of a POSTed query.
+const maxBodySize = 1 << 20
+
+// ConstituencySearch finds the constituencies of a zipcode.
+type ConstituencySearch interface {
+	Constituencies(ctx context.Context, zipcode string) ([]v1.Constituency, error)
+}
+
+// Handler serves a GraphQL endpoint over politicians, committees and
+// constituencies. The schema is derived from the v1 types.
+type Handler struct {
+	politicians    resources.Repository[v1.PersonListEntry]
+	bios           resources.DetailRepository[v1.Politician]
+	committees     resources.Repository[v1.CommitteeListEntry]
+	details        resources.DetailRepository[v1.CommitteeDetails]
+	constituencies ConstituencySearch
+
+	bioTransform     rest.Transform[v1.Politician]
+	detailsTransform rest.Transform[v1.CommitteeDetails]
+	limits           Limits
+
+	types  *types
+	schema graphql.Schema
+}
+
+type Option func(h *Handler)
+
+func WithLimits(limits Limits) Option {
+	return func(h *Handler) {
+		h.limits = limits
+	}
+}
+
+// WithTransforms applies transforms to bios and committee details before they
+// are resolved, like the REST handlers do.
+func WithTransforms(bio rest.Transform[v1.Politician], details rest.Transform[v1.CommitteeDetails]) Option {
+	return func(h *Handler) {
+		h.bioTransform = bio
+		h.detailsTransform = details
+	}
+}
+
+func NewHandler(politicians resources.Repository[v1.PersonListEntry], bios resources.DetailRepository[v1.Politician],
+	committees resources.Repository[v1.CommitteeListEntry], details resources.DetailRepository[v1.CommitteeDetails],
+	constituencies ConstituencySearch, opts ...Option) (*Handler, error) {
+	h := &Handler{
+		politicians:    politicians,
+		bios:           bios,
+		committees:     committees,
+		details:        details,
+		constituencies: constituencies,
+		limits:         Limits{MaxDepth: 10, MaxComplexity: 5000},
+		types:          newTypes(),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	schema, err := h.buildSchema()
+	if err != nil {
+		return nil, err
+	}
+	h.schema = schema
+	return h, nil
+}
+
+type params struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// ServeHTTP executes a query given as ?query= (with ?variables= and
+// ?operationName=) or POSTed as JSON or application/graphql.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	p, err := readParams(w, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if p.Query == "" {
+		http.Error(w, "Missing query", http.StatusBadRequest)
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(p.Query), Name: "GraphQL request"})})
+	if err != nil {
+		h.respond(w, http.StatusBadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+	if validation := graphql.ValidateDocument(&h.schema, doc, nil); !validation.IsValid {
+		h.respond(w, http.StatusBadRequest, &graphql.Result{Errors: validation.Errors})
+		return
+	}
+	if err := h.limits.check(&h.schema, h.types, doc, p.OperationName, p.Variables); err != nil {
+		h.respond(w, http.StatusBadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: p.OperationName,
+		Args:          p.Variables,
+		Context:       context.WithValue(req.Context(), requestKey{}, h.newRequest(req)),
+	})
+	h.respond(w, http.StatusOK, result)
+}
+
+func (h *Handler) respond(w http.ResponseWriter, status int, result *graphql.Result) {
+	if err := rest.MarshalResponse(w, status, result); err != nil {
+		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+	}
+}
+
+func readParams(w http.ResponseWriter, req *http.Request) (params, error) {
+	var p params
+	if req.Method != http.MethodPost {
+		query := req.URL.Query()
+		p.Query = query.Get("query")
+		p.OperationName = query.Get("operationName")
+		if v := query.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &p.Variables); err != nil {
+				return p, fmt.Errorf("invalid variables: %v", err)
+			}
+		}
+		return p, nil
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxBodySize))
+	if err != nil {
+		return p, fmt.Errorf("failed to read body: %v", err)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/graphql":
+		p.Query = string(body)
+	case "application/json", "":
+		if err := json.Unmarshal(body, &p); err != nil {
+			return p, fmt.Errorf("invalid body: %v", err)
+		}
+	default:
+		return p, fmt.Errorf("unsupported content type %s", mediaType)
+	}
+	return p, nil
+}
@@ -0,0 +1,201 @@
+package gql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// defaultListSize is assumed for lists that are neither limited by a first
+// argument nor have an estimate of their own.
+const defaultListSize = 25
+
+// Limits bound the queries that are executed. Depth counts nested fields,
+// complexity estimates the resolved fields: every field costs one, fetched
+// relations cost more and lists multiply the cost of their selection by their
+// expected size. Introspection only counts towards the depth.
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+type analysis struct {
+	schema    *graphql.Schema
+	types     *types
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+// check measures the operation of doc that is about to be executed.
+func (l Limits) check(schema *graphql.Schema, t *types, doc *ast.Document, operationName string, variables map[string]any) error {
+	depth, complexity := measure(schema, t, doc, operationName, variables)
+	if l.MaxDepth > 0 && depth > l.MaxDepth {
+		return fmt.Errorf("query depth %d exceeds the limit of %d", depth, l.MaxDepth)
+	}
+	if l.MaxComplexity > 0 && complexity > l.MaxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the limit of %d, limit lists with first", complexity, l.MaxComplexity)
+	}
+	return nil
+}
+
+// measure returns the depth and complexity of the operation of doc, zero if
+// there's no query to execute.
+func measure(schema *graphql.Schema, t *types, doc *ast.Document, operationName string, variables map[string]any) (depth, complexity int) {
+	a := analysis{schema: schema, types: t, fragments: map[string]*ast.FragmentDefinition{}, variables: variables}
+
+	var operation *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			a.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				operation = def
+			}
+		}
+	}
+	if operation == nil || operation.Operation != ast.OperationTypeQuery {
+		// nothing to execute, the executor reports why
+		return 0, 0
+	}
+
+	return a.selections(schema.QueryType(), operation.SelectionSet, map[string]bool{})
+}
+
+func (a analysis) selections(parent *graphql.Object, set *ast.SelectionSet, spread map[string]bool) (depth, complexity int) {
+	if parent == nil || set == nil {
+		return 0, 0
+	}
+
+	for _, s := range set.Selections {
+		var d, c int
+		switch s := s.(type) {
+		case *ast.Field:
+			d, c = a.field(parent, s, spread)
+		case *ast.InlineFragment:
+			on := parent
+			if s.TypeCondition != nil {
+				on, _ = a.schema.Type(s.TypeCondition.Name.Value).(*graphql.Object)
+			}
+			d, c = a.selections(on, s.SelectionSet, spread)
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			fragment, ok := a.fragments[name]
+			if !ok || spread[name] {
+				continue
+			}
+			spread[name] = true
+			on, _ := a.schema.Type(fragment.TypeCondition.Name.Value).(*graphql.Object)
+			d, c = a.selections(on, fragment.SelectionSet, spread)
+			delete(spread, name)
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+	return depth, complexity
+}
+
+func (a analysis) field(parent *graphql.Object, f *ast.Field, spread map[string]bool) (depth, complexity int) {
+	name := f.Name.Value
+	if strings.HasPrefix(name, "__") {
+		return a.depth(f.SelectionSet, spread) + 1, 0
+	}
+	def, ok := parent.Fields()[name]
+	if !ok {
+		return 0, 0
+	}
+
+	key := parent.Name() + "." + name
+	obj, list := unwrap(def.Type)
+	d, c := a.selections(obj, f.SelectionSet, spread)
+
+	size := 1
+	if list {
+		size = a.listSize(key, f.Arguments)
+	}
+	return d + 1, 1 + a.types.costs[key] + size*c
+}
+
+// depth measures a selection without a type in the schema, like that of
+// the introspection fields.
+func (a analysis) depth(set *ast.SelectionSet, spread map[string]bool) int {
+	if set == nil {
+		return 0
+	}
+
+	depth := 0
+	for _, s := range set.Selections {
+		switch s := s.(type) {
+		case *ast.Field:
+			depth = max(depth, a.depth(s.SelectionSet, spread)+1)
+		case *ast.InlineFragment:
+			depth = max(depth, a.depth(s.SelectionSet, spread))
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			fragment, ok := a.fragments[name]
+			if !ok || spread[name] {
+				continue
+			}
+			spread[name] = true
+			depth = max(depth, a.depth(fragment.SelectionSet, spread))
+			delete(spread, name)
+		}
+	}
+	return depth
+}
+
+// listSize is the expected size of a list: its first argument, but no more
+// than its estimate, or the estimate. A negative first (rejected by the
+// resolver) counts as zero, so it can't offset the cost of other fields.
+func (a analysis) listSize(key string, args []*ast.Argument) int {
+	size, estimated := a.types.sizes[key]
+	if !estimated {
+		size = defaultListSize
+	}
+
+	for _, arg := range args {
+		if arg.Name.Value != "first" {
+			continue
+		}
+		first, ok := 0, false
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			n, err := strconv.Atoi(v.Value)
+			first, ok = n, err == nil
+		case *ast.Variable:
+			switch n := a.variables[v.Name.Value].(type) {
+			case float64:
+				first, ok = int(n), true
+			case int:
+				first, ok = n, true
+			}
+		}
+		if !ok {
+			continue
+		}
+		if estimated {
+			first = min(first, size)
+		}
+		return max(0, first)
+	}
+	return size
+}
+
+// unwrap returns the object type of a field, if any, and whether it's a list.
+func unwrap(t graphql.Type) (obj *graphql.Object, list bool) {
+	for {
+		switch tt := t.(type) {
+		case *graphql.NonNull:
+			t = tt.OfType
+		case *graphql.List:
+			list = true
+			t = tt.OfType
+		default:
+			obj, _ = t.(*graphql.Object)
+			return obj, list
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package gql
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+func newTestHandler(t *testing.T, limits Limits) *Handler {
+	t.Helper()
+	h, err := NewHandler(nil, nil, nil, nil, nil, WithLimits(limits))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestMeasure(t *testing.T) {
+	h := newTestHandler(t, Limits{})
+
+	tests := []struct {
+		name       string
+		query      string
+		variables  map[string]any
+		depth      int
+		complexity int
+	}{
+		{"first", `{ politicians(first: 2) { faction } }`, nil, 2, 3},
+		{"estimate", `{ politicians { faction } }`, nil, 2, 751},
+		{"first above the estimate", `{ politicians(first: 100000) { faction } }`, nil, 2, 751},
+		{"negative first", `{ politicians(first: -5) { faction } }`, nil, 2, 1},
+		{"negative first variable", `query($n: Int) { politicians(first: $n) { faction } }`, map[string]any{"n": float64(-100000)}, 2, 1},
+		{"missing variable", `query($n: Int) { politicians(first: $n) { faction } }`, nil, 2, 751},
+		{"negative alias doesn't offset", `{ a: politicians(first: -100000) { bio { bio { lastName } } } b: politicians { faction } }`, nil, 4, 752},
+		{"relations", `{ committees(first: 1) { details { members { faction } } } }`, nil, 4, 53},
+		{"nested estimate", `{ politicians(first: 1) { bio { bio { memberships { regularMemberCommittees { name } } } } } }`, nil, 6, 20},
+		{"fragment", `query { ...F } fragment F on Query { politicians(first: 1) { faction } }`, nil, 2, 2},
+		{"inline fragment", `{ ... on Query { committees { id } } }`, nil, 2, 31},
+		{"recursive fragment", `query { ...F } fragment F on Query { ...F }`, nil, 0, 0},
+		{"introspection", `{ __schema { types { fields { type { name } } } } }`, nil, 5, 0},
+		{"introspection fragment", `{ __schema { ...T } } fragment T on __Schema { types { name } }`, nil, 3, 0},
+		{"typename", `{ __typename }`, nil, 1, 0},
+		{"mutation", `mutation { politicians { faction } }`, nil, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(tt.query)})})
+			if err != nil {
+				t.Fatal(err)
+			}
+			depth, complexity := measure(&h.schema, h.types, doc, "", tt.variables)
+			if depth != tt.depth || complexity != tt.complexity {
+				t.Errorf("measure = %d, %d, want %d, %d", depth, complexity, tt.depth, tt.complexity)
+			}
+		})
+	}
+}
+
+func TestServeHTTPLimits(t *testing.T) {
+	h := newTestHandler(t, Limits{MaxDepth: 4, MaxComplexity: 100})
+
+	tests := []struct {
+		name  string
+		req   *http.Request
+		code  int
+		error string
+	}{
+		{"depth", httptest.NewRequest(http.MethodGet, "/graphql?query="+urlEncode(`{ __schema { types { fields { type { name } } } } }`), nil),
+			http.StatusBadRequest, "query depth 5 exceeds the limit of 4"},
+		{"complexity", httptest.NewRequest(http.MethodGet, "/graphql?query="+urlEncode(`{ a: politicians(first: -1000) { faction } b: politicians { faction } }`), nil),
+			http.StatusBadRequest, "query complexity 752 exceeds the limit of 100"},
+		{"body too large", httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query": "`+strings.Repeat(" ", maxBodySize)+`"}`)),
+			http.StatusBadRequest, "failed to read body"},
+		{"within limits", httptest.NewRequest(http.MethodGet, "/graphql?query="+urlEncode(`{ __typename }`), nil),
+			http.StatusOK, `"__typename":"Query"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, tt.req)
+			if w.Code != tt.code || !strings.Contains(w.Body.String(), tt.error) {
+				t.Errorf("ServeHTTP = %d %s, want %d with %q", w.Code, w.Body, tt.code, tt.error)
+			}
+		})
+	}
+}
+
+func urlEncode(s string) string {
+	return strings.NewReplacer(" ", "%20", "{", "%7B", "}", "%7D", "#", "%23", "&", "%26").Replace(s)
+}
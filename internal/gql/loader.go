@@ -0,0 +1,81 @@
+package gql
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+// loader batches the lookups of one request. graphql-go resolves a whole
+// level of the query before it calls the thunks returned by load, so by the
+// time the first thunk runs every id of that level is known and read with a
+// single batch. Results are kept until the request ends, every id is read at
+// most once.
+type loader[T any] struct {
+	ctx       context.Context
+	batcher   resources.DetailBatcher[T]
+	transform func(*T) error
+
+	mu      sync.Mutex
+	pending map[string]struct{}
+	results map[string]result[T]
+}
+
+type result[T any] struct {
+	value *T
+	err   error
+}
+
+// newLoader reads through batcher and applies transform, if any, to every
+// resource read.
+func newLoader[T any](ctx context.Context, batcher resources.DetailBatcher[T], transform func(*T) error) *loader[T] {
+	return &loader[T]{ctx: ctx, batcher: batcher, transform: transform, pending: map[string]struct{}{}, results: map[string]result[T]{}}
+}
+
+// load queues id and returns a thunk that yields its resource.
+func (l *loader[T]) load(id string) func() (any, error) {
+	l.mu.Lock()
+	if _, ok := l.results[id]; !ok {
+		l.pending[id] = struct{}{}
+	}
+	l.mu.Unlock()
+
+	return func() (any, error) {
+		l.dispatch()
+
+		l.mu.Lock()
+		r := l.results[id]
+		l.mu.Unlock()
+		if r.err != nil {
+			return nil, r.err
+		}
+		return r.value, nil
+	}
+}
+
+// dispatch reads all queued ids with one batch.
+func (l *loader[T]) dispatch() {
+	l.mu.Lock()
+	pending := l.pending
+	l.pending = map[string]struct{}{}
+	l.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	ids := slices.Collect(maps.Keys(pending))
+	get := l.batcher.Batch(l.ctx, ids)
+	for _, id := range ids {
+		value, err := get(id)
+		if err == nil && l.transform != nil {
+			err = l.transform(value)
+		}
+
+		l.mu.Lock()
+		l.results[id] = result[T]{value: value, err: err}
+		l.mu.Unlock()
+	}
+}
@@ -0,0 +1,61 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+type doc struct{ ID, Name string }
+
+// batcher records the ids of every batch and fails for "missing".
+type batcher struct {
+	batches [][]string
+}
+
+func (b *batcher) Batch(ctx context.Context, ids []string) func(id string) (*doc, error) {
+	b.batches = append(b.batches, slices.Sorted(slices.Values(ids)))
+	return func(id string) (*doc, error) {
+		if id == "missing" {
+			return nil, errors.New("not found")
+		}
+		return &doc{ID: id, Name: "name of " + id}, nil
+	}
+}
+
+func TestLoaderBatches(t *testing.T) {
+	b := &batcher{}
+	l := newLoader[doc](context.Background(), b, func(d *doc) error {
+		d.Name += "!"
+		return nil
+	})
+
+	var thunks []func() (any, error)
+	for _, id := range []string{"2", "1", "missing", "1"} {
+		thunks = append(thunks, l.load(id))
+	}
+	first, err := thunks[0]()
+	if err != nil || first.(*doc).Name != "name of 2!" {
+		t.Errorf("thunk of 2 = %+v, %v", first, err)
+	}
+	for _, thunk := range thunks[1:] {
+		_, _ = thunk()
+	}
+	if _, err := thunks[2](); err == nil {
+		t.Error("thunk of a missing id succeeded")
+	}
+	if len(b.batches) != 1 || !slices.Equal(b.batches[0], []string{"1", "2", "missing"}) {
+		t.Fatalf("batches = %v, want one of [1 2 missing]", b.batches)
+	}
+
+	// the next level reads only ids not read yet
+	l.load("1")
+	next := l.load("3")
+	if v, err := next(); err != nil || v.(*doc).ID != "3" {
+		t.Errorf("thunk of 3 = %+v, %v", v, err)
+	}
+	if len(b.batches) != 2 || !slices.Equal(b.batches[1], []string{"3"}) {
+		t.Errorf("batches = %v, want a second one of [3]", b.batches)
+	}
+}
@@ -0,0 +1,261 @@
+package gql
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+)
+
+// request holds what the resolvers of a single query share.
+type request struct {
+	politicians func() []v1.PersonListEntry
+	committees  func() []v1.CommitteeListEntry
+	bios        *loader[v1.Politician]
+	details     *loader[v1.CommitteeDetails]
+}
+
+type requestKey struct{}
+
+func (h *Handler) newRequest(req *http.Request) *request {
+	ctx := req.Context()
+	return &request{
+		politicians: sync.OnceValue(func() []v1.PersonListEntry { return h.politicians.List(ctx) }),
+		committees:  sync.OnceValue(func() []v1.CommitteeListEntry { return h.committees.List(ctx) }),
+		bios: newLoader(ctx, h.bios, func(p *v1.Politician) error {
+			if h.bioTransform == nil {
+				return nil
+			}
+			return h.bioTransform(req, p)
+		}),
+		details: newLoader(ctx, h.details, func(c *v1.CommitteeDetails) error {
+			if h.detailsTransform == nil {
+				return nil
+			}
+			return h.detailsTransform(req, c)
+		}),
+	}
+}
+
+func requestOf(p graphql.ResolveParams) *request {
+	return p.Context.Value(requestKey{}).(*request)
+}
+
+// sourceOf returns the object a field is resolved on, lists hold values and
+// single lookups pointers.
+func sourceOf[T any](p graphql.ResolveParams) *T {
+	switch s := p.Source.(type) {
+	case T:
+		return &s
+	case *T:
+		return s
+	}
+	return nil
+}
+
+var pagination = graphql.FieldConfigArgument{
+	"first":  &graphql.ArgumentConfig{Type: graphql.Int, Description: "Maximum number of entries."},
+	"offset": &graphql.ArgumentConfig{Type: graphql.Int, Description: "Number of entries to skip.", DefaultValue: 0},
+}
+
+func (h *Handler) buildSchema() (graphql.Schema, error) {
+	t := h.types
+
+	person := objectOf[v1.PersonListEntry](t)
+	committee := objectOf[v1.CommitteeListEntry](t)
+	details := objectOf[v1.CommitteeDetails](t)
+	people := graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(person)))
+
+	relate[v1.PersonListEntry](t, "bio", 10, &graphql.Field{
+		Type:        objectOf[v1.Politician](t),
+		Description: "Full bio, as served by /politicians/{id}/bio.",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return requestOf(p).bios.load(sourceOf[v1.PersonListEntry](p).Id.Value), nil
+		},
+	})
+	relate[v1.Committee](t, "details", 10, &graphql.Field{
+		Type:        details,
+		Description: "Details of the committee, null for bodies that aren't committees of the Bundestag.",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			c := sourceOf[v1.Committee](p)
+			if c.Id == "" {
+				return nil, nil
+			}
+			return requestOf(p).details.load(c.Id), nil
+		},
+	})
+	relate[v1.CommitteeListEntry](t, "details", 10, &graphql.Field{
+		Type:        details,
+		Description: "Details of the committee, as served by /committees/{id}/detail.",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return requestOf(p).details.load(sourceOf[v1.CommitteeListEntry](p).Id), nil
+		},
+	})
+	relate[v1.CommitteeDetails](t, "chairperson", 0, &graphql.Field{
+		Type: person,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return politician(requestOf(p), sourceOf[v1.CommitteeDetails](p).ChairpersonID), nil
+		},
+	})
+	relate[v1.CommitteeDetails](t, "deputies", 0, &graphql.Field{
+		Type: people,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			var deputies []v1.PersonListEntry
+			for _, id := range sourceOf[v1.CommitteeDetails](p).DeputyChairpersons {
+				if d := politician(requestOf(p), id); d != nil {
+					deputies = append(deputies, *d)
+				}
+			}
+			return deputies, nil
+		},
+	})
+	relate[v1.Constituency](t, "politicians", 0, &graphql.Field{
+		Type:        people,
+		Description: "MdBs elected in the constituency, directly or by list.",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			number := sourceOf[v1.Constituency](p).Number
+			var result []v1.PersonListEntry
+			for _, e := range requestOf(p).politicians() {
+				if number != "" && e.Constituency.Number == number {
+					result = append(result, e)
+				}
+			}
+			return result, nil
+		},
+	})
+
+	t.sizes["Query.politicians"] = 750
+	t.sizes["Query.committees"] = 30
+	t.sizes["Query.constituencies"] = 3
+	t.sizes["CommitteeDetails.members"] = 40
+	t.sizes["CommitteeDetails.deputies"] = 3
+	t.sizes["Constituency.politicians"] = 5
+	for _, list := range []string{"leadCommittees", "regularMemberCommittees", "substituteMemberCommittees", "viceChairOtherCommittees"} {
+		t.sizes["Memberships."+list] = 5
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"politicians": &graphql.Field{
+				Type:        people,
+				Description: "Members of the Bundestag, filtered by all given arguments.",
+				Args: withPagination(graphql.FieldConfigArgument{
+					"faction":      &graphql.ArgumentConfig{Type: graphql.String},
+					"state":        &graphql.ArgumentConfig{Type: graphql.String},
+					"constituency": &graphql.ArgumentConfig{Type: graphql.String, Description: "Number of the constituency."},
+					"name":         &graphql.ArgumentConfig{Type: graphql.String, Description: "Part of the name, case insensitive."},
+				}),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					faction, _ := p.Args["faction"].(string)
+					state, _ := p.Args["state"].(string)
+					constituency, _ := p.Args["constituency"].(string)
+					name, _ := p.Args["name"].(string)
+
+					var result []v1.PersonListEntry
+					for _, e := range requestOf(p).politicians() {
+						if (faction == "" || strings.EqualFold(e.Faction, faction)) &&
+							(state == "" || strings.EqualFold(e.State, state)) &&
+							(constituency == "" || e.Constituency.Number == constituency) &&
+							(name == "" || strings.Contains(strings.ToLower(e.Name.Value), strings.ToLower(name))) {
+							result = append(result, e)
+						}
+					}
+					return paginate(p, result)
+				},
+			},
+			"politician": &graphql.Field{
+				Type: person,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return politician(requestOf(p), p.Args["id"].(string)), nil
+				},
+			},
+			"committees": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(committee))),
+				Args: withPagination(graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.String, Description: "Part of the name or short name, case insensitive."},
+				}),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					name, _ := p.Args["name"].(string)
+					name = strings.ToLower(name)
+
+					var result []v1.CommitteeListEntry
+					for _, c := range requestOf(p).committees() {
+						if strings.Contains(strings.ToLower(c.Name), name) || strings.Contains(strings.ToLower(c.ShortName), name) {
+							result = append(result, c)
+						}
+					}
+					return paginate(p, result)
+				},
+			},
+			"committee": &graphql.Field{
+				Type: committee,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					id := p.Args["id"].(string)
+					for _, c := range requestOf(p).committees() {
+						if c.Id == id {
+							return &c, nil
+						}
+					}
+					return nil, nil
+				},
+			},
+			"constituencies": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(objectOf[v1.Constituency](t)))),
+				Description: "Constituencies covering a zipcode.",
+				Args: graphql.FieldConfigArgument{
+					"zipcode": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return h.constituencies.Constituencies(p.Context, p.Args["zipcode"].(string))
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+func politician(r *request, id string) *v1.PersonListEntry {
+	if id == "" {
+		return nil
+	}
+	for _, e := range r.politicians() {
+		if e.Id.Value == id {
+			return &e
+		}
+	}
+	return nil
+}
+
+func withPagination(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	for name, arg := range pagination {
+		args[name] = arg
+	}
+	return args
+}
+
+func paginate[T any](p graphql.ResolveParams, list []T) ([]T, error) {
+	offset, _ := p.Args["offset"].(int)
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+	list = list[min(offset, len(list)):]
+
+	if first, ok := p.Args["first"].(int); ok {
+		if first < 0 {
+			return nil, errors.New("first must not be negative")
+		}
+		list = list[:min(first, len(list))]
+	}
+	return list, nil
+}
@@ -0,0 +1,148 @@
+package gql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/graphql-go/graphql"
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+)
+
+// type names that would clash with the built-in scalars
+var typeNames = map[reflect.Type]string{
+	reflect.TypeFor[v1.ID](): "MdbID",
+}
+
+// types derives GraphQL object types from the v1 structs. Fields are named
+// like their JSON counterparts, so a query reads like the REST responses.
+// Relations between the resources are added on top with relate.
+type types struct {
+	objects   map[reflect.Type]*graphql.Object
+	relations map[reflect.Type]graphql.Fields
+
+	// estimates for the complexity of a query, by "Type.field"
+	costs map[string]int
+	sizes map[string]int
+}
+
+func newTypes() *types {
+	return &types{
+		objects:   map[reflect.Type]*graphql.Object{},
+		relations: map[reflect.Type]graphql.Fields{},
+		costs:     map[string]int{},
+		sizes:     map[string]int{},
+	}
+}
+
+// object returns the type of T, creating it on first use. The fields are
+// resolved lazily so types can refer to each other.
+func objectOf[T any](t *types) *graphql.Object {
+	return t.object(reflect.TypeFor[T]())
+}
+
+func (t *types) object(rt reflect.Type) *graphql.Object {
+	if obj, ok := t.objects[rt]; ok {
+		return obj
+	}
+
+	name, ok := typeNames[rt]
+	if !ok {
+		name = rt.Name()
+	}
+	obj := graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := t.fields(rt)
+			for name, f := range t.relations[rt] {
+				fields[name] = f
+			}
+			return fields
+		}),
+	})
+	t.objects[rt] = obj
+	return obj
+}
+
+func (t *types) fields(rt reflect.Type) graphql.Fields {
+	fields := graphql.Fields{}
+	for _, sf := range reflect.VisibleFields(rt) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+		name := fieldName(sf)
+		if name == "" {
+			continue
+		}
+		fields[name] = &graphql.Field{
+			Type:    t.output(sf.Type),
+			Resolve: structField(sf.Index),
+		}
+	}
+	return fields
+}
+
+func (t *types) output(rt reflect.Type) graphql.Output {
+	switch rt.Kind() {
+	case reflect.Pointer:
+		return nullable(t.output(rt.Elem()))
+	case reflect.Slice:
+		return graphql.NewNonNull(graphql.NewList(t.output(rt.Elem())))
+	case reflect.Struct:
+		return graphql.NewNonNull(t.object(rt))
+	case reflect.String:
+		return graphql.NewNonNull(graphql.String)
+	case reflect.Bool:
+		return graphql.NewNonNull(graphql.Boolean)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return graphql.NewNonNull(graphql.Int)
+	case reflect.Float32, reflect.Float64:
+		return graphql.NewNonNull(graphql.Float)
+	}
+	panic(fmt.Sprintf("no graphql type for %s", rt))
+}
+
+// relate adds a field to the type of T that isn't part of the struct, e.g. a
+// resource that has to be fetched. cost is added to the complexity of a query
+// for every time the field is resolved.
+func relate[T any](t *types, name string, cost int, field *graphql.Field) {
+	rt := reflect.TypeFor[T]()
+	if t.relations[rt] == nil {
+		t.relations[rt] = graphql.Fields{}
+	}
+	t.relations[rt][name] = field
+	t.costs[t.object(rt).Name()+"."+name] = cost
+}
+
+// structField resolves a field of the source struct by its index.
+func structField(index []int) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		v := reflect.Indirect(reflect.ValueOf(p.Source))
+		if v.Kind() != reflect.Struct {
+			return nil, nil
+		}
+		return v.FieldByIndex(index).Interface(), nil
+	}
+}
+
+func fieldName(sf reflect.StructField) string {
+	tag, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	switch tag {
+	case "-":
+		return ""
+	case "":
+		r := []rune(sf.Name)
+		r[0] = unicode.ToLower(r[0])
+		return string(r)
+	}
+	return tag
+}
+
+func nullable(t graphql.Output) graphql.Output {
+	if nn, ok := t.(*graphql.NonNull); ok {
+		return nn.OfType
+	}
+	return t
+}
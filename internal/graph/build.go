@@ -0,0 +1,200 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+)
+
+func politicianID(id string) string {
+	return "politician-" + id
+}
+
+func committeeID(id string) string {
+	return "committee-" + id
+}
+
+// bipartite connects MdBs with their committees, by the memberships a bio
+// lists and the members a committee lists. Every MdB of politicians is a
+// node, even without committees, members of committees who aren't among
+// them are left out.
+func bipartite(politicians []v1.PersonListEntry, bios []v1.Politician, committees []v1.CommitteeDetails) *v1.Graph {
+	b := &builder{nodes: map[string]*v1.GraphNode{}, edges: map[[2]string]*v1.GraphEdge{}}
+
+	for _, p := range politicians {
+		b.politician(p)
+	}
+	for _, c := range committees {
+		b.node(v1.GraphNode{ID: committeeID(c.Id), Type: v1.NodeCommittee, Label: c.CommitteeName, RefID: c.Id})
+	}
+
+	for _, bio := range bios {
+		id := bio.Bio.Id.Value
+		if n, ok := b.nodes[politicianID(id)]; ok {
+			n.Party = bio.Bio.Party
+		}
+		for _, m := range []struct {
+			committees []v1.Committee
+			role       v1.MembershipRole
+		}{
+			{bio.Bio.Memberships.RegularMemberCommittees, v1.RoleMember},
+			{bio.Bio.Memberships.SubstituteMemberCommittees, v1.RoleSubstitute},
+			{bio.Bio.Memberships.LeadCommittees, v1.RoleLead},
+			{bio.Bio.Memberships.ViceChairOtherCommittees, v1.RoleViceChair},
+		} {
+			for _, c := range m.committees {
+				// bodies without ID can't be told apart reliably
+				if c.Id == "" {
+					continue
+				}
+				b.node(v1.GraphNode{ID: committeeID(c.Id), Type: v1.NodeCommittee, Label: c.Name, RefID: c.Id})
+				b.link(id, c.Id, m.role)
+			}
+		}
+	}
+
+	// the members of a committee include its substitutes, the bios tell them
+	// apart
+	for _, c := range committees {
+		for _, m := range c.Members {
+			if b.edges[[2]string{politicianID(m.Id.Value), committeeID(c.Id)}] == nil {
+				b.link(m.Id.Value, c.Id, v1.RoleMember)
+			}
+		}
+		if c.ChairpersonID != "" {
+			b.link(c.ChairpersonID, c.Id, v1.RoleChair)
+		}
+		for _, id := range c.DeputyChairpersons {
+			b.link(id, c.Id, v1.RoleViceChair)
+		}
+	}
+
+	g := b.graph(v1.GraphBipartite)
+	countMembers(g)
+	return g
+}
+
+// countMembers sets the number of members of the committees in g.
+func countMembers(g *v1.Graph) {
+	members := map[string]int{}
+	for _, e := range g.Edges {
+		members[e.Target]++
+	}
+	for i := range g.Nodes {
+		g.Nodes[i].Members = members[g.Nodes[i].ID]
+	}
+}
+
+// project connects MdBs who share committees in g, weighted by their
+// number. Only memberships in one of roles count, all if there are none.
+func project(g *v1.Graph, roles []v1.MembershipRole) *v1.Graph {
+	b := &builder{nodes: map[string]*v1.GraphNode{}, edges: map[[2]string]*v1.GraphEdge{}}
+	for _, n := range g.Nodes {
+		if n.Type == v1.NodePolitician {
+			b.node(n)
+		}
+	}
+
+	refIDs := map[string]string{}
+	for _, n := range g.Nodes {
+		refIDs[n.ID] = n.RefID
+	}
+	members := map[string][]string{}
+	var committees []string
+	for _, e := range filter(g, roles).Edges {
+		if _, ok := members[e.Target]; !ok {
+			committees = append(committees, e.Target)
+		}
+		members[e.Target] = append(members[e.Target], e.Source)
+	}
+
+	for _, c := range committees {
+		ms := members[c]
+		for i, m1 := range ms {
+			for _, m2 := range ms[i+1:] {
+				key := [2]string{min(m1, m2), max(m1, m2)}
+				e, ok := b.edges[key]
+				if !ok {
+					e = &v1.GraphEdge{Source: key[0], Target: key[1]}
+					b.edges[key] = e
+				}
+				e.Weight++
+				e.Committees = append(e.Committees, refIDs[c])
+			}
+		}
+	}
+	return b.graph(v1.GraphProjected)
+}
+
+// filter keeps the edges of g with one of roles, all if there are none.
+func filter(g *v1.Graph, roles []v1.MembershipRole) *v1.Graph {
+	if len(roles) == 0 {
+		return g
+	}
+	result := &v1.Graph{Mode: g.Mode, Nodes: slices.Clone(g.Nodes), Edges: []v1.GraphEdge{}}
+	for _, e := range g.Edges {
+		if slices.ContainsFunc(e.Roles, func(r v1.MembershipRole) bool { return slices.Contains(roles, r) }) {
+			result.Edges = append(result.Edges, e)
+		}
+	}
+	countMembers(result)
+	return result
+}
+
+type builder struct {
+	nodes map[string]*v1.GraphNode
+	edges map[[2]string]*v1.GraphEdge
+}
+
+func (b *builder) node(n v1.GraphNode) *v1.GraphNode {
+	if existing, ok := b.nodes[n.ID]; ok {
+		return existing
+	}
+	b.nodes[n.ID] = &n
+	return &n
+}
+
+func (b *builder) politician(p v1.PersonListEntry) {
+	b.node(v1.GraphNode{
+		ID:      politicianID(p.Id.Value),
+		Type:    v1.NodePolitician,
+		Label:   p.Name.Value,
+		RefID:   p.Id.Value,
+		Faction: p.Faction,
+		State:   p.State,
+	})
+}
+
+// link adds role to the edge between an MdB and a committee, as long as
+// both are known.
+func (b *builder) link(politician, committee string, role v1.MembershipRole) {
+	key := [2]string{politicianID(politician), committeeID(committee)}
+	if b.nodes[key[0]] == nil || b.nodes[key[1]] == nil {
+		return
+	}
+	e, ok := b.edges[key]
+	if !ok {
+		e = &v1.GraphEdge{Source: key[0], Target: key[1], Weight: 1}
+		b.edges[key] = e
+	}
+	if !slices.Contains(e.Roles, role) {
+		e.Roles = append(e.Roles, role)
+	}
+}
+
+// graph returns the nodes and edges sorted, so exports can be compared.
+func (b *builder) graph(mode v1.GraphMode) *v1.Graph {
+	g := &v1.Graph{Mode: mode, Nodes: []v1.GraphNode{}, Edges: []v1.GraphEdge{}}
+	for _, n := range b.nodes {
+		g.Nodes = append(g.Nodes, *n)
+	}
+	for _, e := range b.edges {
+		g.Edges = append(g.Edges, *e)
+	}
+	slices.SortFunc(g.Nodes, func(a, b v1.GraphNode) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(g.Edges, func(a, b v1.GraphEdge) int {
+		return cmp.Or(cmp.Compare(a.Source, b.Source), cmp.Compare(a.Target, b.Target))
+	})
+	return g
+}
@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+)
+
+func person(id, name, faction string) v1.PersonListEntry {
+	return v1.PersonListEntry{Id: v1.ID{Value: id}, Name: v1.MdbName{Value: name}, Faction: faction}
+}
+
+func bio(id, party string, memberships v1.Memberships) v1.Politician {
+	p := v1.Politician{}
+	p.Bio.Id.Value, p.Bio.Party, p.Bio.Memberships = id, party, memberships
+	return p
+}
+
+// fixture has two active MdBs, a former one who is still listed by a
+// committee and a committee only known from a bio.
+func fixture() *v1.Graph {
+	politicians := []v1.PersonListEntry{person("1001", "Erika Muster", "SPD"), person("1002", "Max Beispiel", "CDU/CSU")}
+	bios := []v1.Politician{
+		bio("1001", "SPD", v1.Memberships{}),
+		bio("1002", "CDU", v1.Memberships{
+			SubstituteMemberCommittees: []v1.Committee{{Id: "a11", Name: "Haushaltsausschuss"}},
+			RegularMemberCommittees:    []v1.Committee{{Id: "a12", Name: "Innenausschuss"}, {Name: "Parlamentarische Versammlung"}},
+		}),
+	}
+	committees := []v1.CommitteeDetails{{
+		Id:                 "a11",
+		CommitteeName:      "Haushaltsausschuss",
+		Members:            []v1.PersonListEntry{person("1001", "Erika Muster", "SPD"), person("1002", "Max Beispiel", "CDU/CSU"), person("9999", "Former Member", "FDP")},
+		ChairpersonID:      "1001",
+		DeputyChairpersons: []string{"9999"},
+	}}
+	return bipartite(politicians, bios, committees)
+}
+
+func TestBipartite(t *testing.T) {
+	g := fixture()
+
+	var nodes []string
+	for _, n := range g.Nodes {
+		nodes = append(nodes, n.ID)
+	}
+	if want := []string{"committee-a11", "committee-a12", "politician-1001", "politician-1002"}; !reflect.DeepEqual(nodes, want) {
+		t.Errorf("nodes = %v, want %v", nodes, want)
+	}
+	if g.Nodes[0].Members != 2 || g.Nodes[1].Members != 1 {
+		t.Errorf("members = %d, %d, want 2, 1", g.Nodes[0].Members, g.Nodes[1].Members)
+	}
+	if g.Nodes[2].Party != "SPD" || g.Nodes[3].Faction != "CDU/CSU" || g.Nodes[3].Label != "Max Beispiel" {
+		t.Errorf("politician nodes = %+v", g.Nodes[2:])
+	}
+
+	want := []v1.GraphEdge{
+		{Source: "politician-1001", Target: "committee-a11", Weight: 1, Roles: []v1.MembershipRole{v1.RoleMember, v1.RoleChair}},
+		{Source: "politician-1002", Target: "committee-a11", Weight: 1, Roles: []v1.MembershipRole{v1.RoleSubstitute}},
+		{Source: "politician-1002", Target: "committee-a12", Weight: 1, Roles: []v1.MembershipRole{v1.RoleMember}},
+	}
+	if !reflect.DeepEqual(g.Edges, want) {
+		t.Errorf("edges = %+v, want %+v", g.Edges, want)
+	}
+}
+
+func TestFilterAndProject(t *testing.T) {
+	g := fixture()
+
+	chairs := filter(g, []v1.MembershipRole{v1.RoleChair})
+	if len(chairs.Edges) != 1 || chairs.Nodes[0].Members != 1 || len(chairs.Nodes) != len(g.Nodes) {
+		t.Errorf("filter = %+v", chairs)
+	}
+	if len(g.Edges) != 3 || g.Nodes[0].Members != 2 {
+		t.Error("filter changed the graph")
+	}
+
+	p := project(g, nil)
+	want := []v1.GraphEdge{{Source: "politician-1001", Target: "politician-1002", Weight: 1, Committees: []string{"a11"}}}
+	if p.Mode != v1.GraphProjected || len(p.Nodes) != 2 || !reflect.DeepEqual(p.Edges, want) {
+		t.Errorf("project = %+v", p)
+	}
+
+	if p := project(g, []v1.MembershipRole{v1.RoleMember}); len(p.Edges) != 0 {
+		t.Errorf("project of regular members = %+v", p.Edges)
+	}
+}
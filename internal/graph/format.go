@@ -0,0 +1,206 @@
+package graph
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+)
+
+// format writes a graph in one file format.
+type format struct {
+	name      string
+	mediaType string
+	write     func(w io.Writer, g *v1.Graph) error
+}
+
+// formats are offered in this order, the first if the client accepts any.
+var formats = []format{
+	{"json", "application/json", writeJSON},
+	{"graphml", "application/graphml+xml", writeGraphML},
+	{"gexf", "application/gexf+xml", writeGEXF},
+	{"dot", "text/vnd.graphviz", writeDOT},
+}
+
+// attribute is a column of the nodes or edges of a graph, written by the
+// formats with a schema.
+type attribute[T any] struct {
+	name  string
+	typ   string // of GraphML and GEXF
+	value func(*T) string
+}
+
+var nodeAttributes = []attribute[v1.GraphNode]{
+	{"type", "string", func(n *v1.GraphNode) string { return string(n.Type) }},
+	{"refId", "string", func(n *v1.GraphNode) string { return n.RefID }},
+	{"faction", "string", func(n *v1.GraphNode) string { return n.Faction }},
+	{"state", "string", func(n *v1.GraphNode) string { return n.State }},
+	{"party", "string", func(n *v1.GraphNode) string { return n.Party }},
+	{"members", "int", func(n *v1.GraphNode) string { return count(n.Members) }},
+}
+
+var edgeAttributes = []attribute[v1.GraphEdge]{
+	{"roles", "string", func(e *v1.GraphEdge) string {
+		roles := make([]string, len(e.Roles))
+		for i, r := range e.Roles {
+			roles[i] = string(r)
+		}
+		return strings.Join(roles, ",")
+	}},
+	{"committees", "string", func(e *v1.GraphEdge) string { return strings.Join(e.Committees, ",") }},
+}
+
+func count(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+func writeJSON(w io.Writer, g *v1.Graph) error {
+	return json.NewEncoder(w).Encode(g)
+}
+
+// writeGraphML writes g as described at http://graphml.graphdrawing.org,
+// empty attributes are left out.
+func writeGraphML(w io.Writer, g *v1.Graph) error {
+	ew := &errWriter{w: w}
+	ew.printf(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	ew.printf(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://graphml.graphdrawing.org/xmlns http://graphml.graphdrawing.org/xmlns/1.0/graphml.xsd">` + "\n")
+	ew.printf(`  <key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n")
+	for _, a := range nodeAttributes {
+		ew.printf(`  <key id="%s" for="node" attr.name="%s" attr.type="%s"/>`+"\n", a.name, a.name, a.typ)
+	}
+	ew.printf(`  <key id="weight" for="edge" attr.name="weight" attr.type="int"/>` + "\n")
+	for _, a := range edgeAttributes {
+		ew.printf(`  <key id="%s" for="edge" attr.name="%s" attr.type="%s"/>`+"\n", a.name, a.name, a.typ)
+	}
+
+	ew.printf(`  <graph id="%s" edgedefault="undirected">`+"\n", g.Mode)
+	for _, n := range g.Nodes {
+		ew.printf(`    <node id="%s">`, xmlEscape(n.ID))
+		ew.printf(`<data key="label">%s</data>`, xmlEscape(n.Label))
+		for _, a := range nodeAttributes {
+			if v := a.value(&n); v != "" {
+				ew.printf(`<data key="%s">%s</data>`, a.name, xmlEscape(v))
+			}
+		}
+		ew.printf("</node>\n")
+	}
+	for _, e := range g.Edges {
+		ew.printf(`    <edge source="%s" target="%s">`, xmlEscape(e.Source), xmlEscape(e.Target))
+		ew.printf(`<data key="weight">%d</data>`, e.Weight)
+		for _, a := range edgeAttributes {
+			if v := a.value(&e); v != "" {
+				ew.printf(`<data key="%s">%s</data>`, a.name, xmlEscape(v))
+			}
+		}
+		ew.printf("</edge>\n")
+	}
+	ew.printf("  </graph>\n</graphml>\n")
+	return ew.err
+}
+
+// writeGEXF writes g as GEXF 1.3, the format of Gephi.
+func writeGEXF(w io.Writer, g *v1.Graph) error {
+	ew := &errWriter{w: w}
+	ew.printf(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	ew.printf(`<gexf xmlns="http://gexf.net/1.3" version="1.3">` + "\n")
+	ew.printf("  <meta>\n    <creator>bundestag-api</creator>\n    <description>%s graph of MdBs and committees</description>\n  </meta>\n", g.Mode)
+	ew.printf(`  <graph defaultedgetype="undirected" mode="static">` + "\n")
+	ew.printf(`    <attributes class="node">` + "\n")
+	for _, a := range nodeAttributes {
+		ew.printf(`      <attribute id="%s" title="%s" type="%s"/>`+"\n", a.name, a.name, gexfType(a.typ))
+	}
+	ew.printf("    </attributes>\n")
+	ew.printf(`    <attributes class="edge">` + "\n")
+	for _, a := range edgeAttributes {
+		ew.printf(`      <attribute id="%s" title="%s" type="%s"/>`+"\n", a.name, a.name, gexfType(a.typ))
+	}
+	ew.printf("    </attributes>\n")
+
+	ew.printf("    <nodes>\n")
+	for _, n := range g.Nodes {
+		ew.printf(`      <node id="%s" label="%s"><attvalues>`, xmlEscape(n.ID), xmlEscape(n.Label))
+		for _, a := range nodeAttributes {
+			if v := a.value(&n); v != "" {
+				ew.printf(`<attvalue for="%s" value="%s"/>`, a.name, xmlEscape(v))
+			}
+		}
+		ew.printf("</attvalues></node>\n")
+	}
+	ew.printf("    </nodes>\n    <edges>\n")
+	for i, e := range g.Edges {
+		ew.printf(`      <edge id="%d" source="%s" target="%s" weight="%d"><attvalues>`, i, xmlEscape(e.Source), xmlEscape(e.Target), e.Weight)
+		for _, a := range edgeAttributes {
+			if v := a.value(&e); v != "" {
+				ew.printf(`<attvalue for="%s" value="%s"/>`, a.name, xmlEscape(v))
+			}
+		}
+		ew.printf("</attvalues></edge>\n")
+	}
+	ew.printf("    </edges>\n  </graph>\n</gexf>\n")
+	return ew.err
+}
+
+func gexfType(typ string) string {
+	if typ == "int" {
+		return "integer"
+	}
+	return typ
+}
+
+// writeDOT writes g in the language of Graphviz, with the attributes as
+// node and edge attributes.
+func writeDOT(w io.Writer, g *v1.Graph) error {
+	ew := &errWriter{w: w}
+	ew.printf("graph %s {\n", g.Mode)
+	for _, n := range g.Nodes {
+		ew.printf("  %s [label=%s", dotQuote(n.ID), dotQuote(n.Label))
+		for _, a := range nodeAttributes {
+			if v := a.value(&n); v != "" {
+				ew.printf(", %s=%s", a.name, dotQuote(v))
+			}
+		}
+		ew.printf("];\n")
+	}
+	for _, e := range g.Edges {
+		ew.printf("  %s -- %s [weight=%d", dotQuote(e.Source), dotQuote(e.Target), e.Weight)
+		for _, a := range edgeAttributes {
+			if v := a.value(&e); v != "" {
+				ew.printf(", %s=%s", a.name, dotQuote(v))
+			}
+		}
+		ew.printf("];\n")
+	}
+	ew.printf("}\n")
+	return ew.err
+}
+
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
+
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// errWriter keeps the first error, so writing the elements doesn't need to
+// check every time.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...any) {
+	if ew.err == nil {
+		_, ew.err = fmt.Fprintf(ew.w, format, args...)
+	}
+}
@@ -0,0 +1,143 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+)
+
+const tricky = "Müller & \"Söhne\" <GmbH>\\\nzweite Zeile"
+
+func trickyGraph() *v1.Graph {
+	return &v1.Graph{
+		Mode: v1.GraphBipartite,
+		Nodes: []v1.GraphNode{
+			{ID: "committee-a11", Type: v1.NodeCommittee, Label: tricky, RefID: "a11", Members: 1},
+			{ID: "politician-1001", Type: v1.NodePolitician, Label: "Erika Muster", RefID: "1001", Faction: "BÜNDNIS 90/DIE GRÜNEN"},
+		},
+		Edges: []v1.GraphEdge{
+			{Source: "politician-1001", Target: "committee-a11", Weight: 1, Roles: []v1.MembershipRole{v1.RoleMember, v1.RoleChair}},
+		},
+	}
+}
+
+func write(t *testing.T, name string) string {
+	t.Helper()
+	for _, f := range formats {
+		if f.name == name {
+			var buf bytes.Buffer
+			if err := f.write(&buf, trickyGraph()); err != nil {
+				t.Fatal(err)
+			}
+			return buf.String()
+		}
+	}
+	t.Fatalf("no format %s", name)
+	return ""
+}
+
+func TestWriteJSON(t *testing.T) {
+	var got v1.Graph
+	if err := json.Unmarshal([]byte(write(t, "json")), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&got, trickyGraph()) {
+		t.Errorf("decoded = %+v", got)
+	}
+}
+
+func TestWriteGraphML(t *testing.T) {
+	type data struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:",chardata"`
+	}
+	var doc struct {
+		Keys []struct {
+			ID string `xml:"id,attr"`
+		} `xml:"key"`
+		Graph struct {
+			ID    string `xml:"id,attr"`
+			Nodes []struct {
+				ID   string `xml:"id,attr"`
+				Data []data `xml:"data"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+				Data   []data `xml:"data"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal([]byte(write(t, "graphml")), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doc.Keys) != 1+len(nodeAttributes)+1+len(edgeAttributes) || doc.Graph.ID != "bipartite" {
+		t.Errorf("keys = %d, graph = %s", len(doc.Keys), doc.Graph.ID)
+	}
+	committee := doc.Graph.Nodes[0]
+	want := []data{{"label", tricky}, {"type", "committee"}, {"refId", "a11"}, {"members", "1"}}
+	if committee.ID != "committee-a11" || !reflect.DeepEqual(committee.Data, want) {
+		t.Errorf("node = %+v, want data %+v", committee, want)
+	}
+	edge := doc.Graph.Edges[0]
+	if edge.Source != "politician-1001" || !reflect.DeepEqual(edge.Data, []data{{"weight", "1"}, {"roles", "member,chair"}}) {
+		t.Errorf("edge = %+v", edge)
+	}
+}
+
+func TestWriteGEXF(t *testing.T) {
+	type attvalue struct {
+		For   string `xml:"for,attr"`
+		Value string `xml:"value,attr"`
+	}
+	var doc struct {
+		Version string `xml:"version,attr"`
+		Graph   struct {
+			Nodes []struct {
+				ID        string     `xml:"id,attr"`
+				Label     string     `xml:"label,attr"`
+				Attvalues []attvalue `xml:"attvalues>attvalue"`
+			} `xml:"nodes>node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Weight int    `xml:"weight,attr"`
+			} `xml:"edges>edge"`
+		} `xml:"graph"`
+	}
+	out := write(t, "gexf")
+	if err := xml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Version != "1.3" || len(doc.Graph.Nodes) != 2 || len(doc.Graph.Edges) != 1 || doc.Graph.Edges[0].Weight != 1 {
+		t.Errorf("gexf = %+v", doc)
+	}
+	// attribute values keep their line breaks only if escaped
+	if n := doc.Graph.Nodes[0]; n.Label != tricky {
+		t.Errorf("label = %q, want %q", n.Label, tricky)
+	}
+	if n := doc.Graph.Nodes[1]; !reflect.DeepEqual(n.Attvalues, []attvalue{{"type", "politician"}, {"refId", "1001"}, {"faction", "BÜNDNIS 90/DIE GRÜNEN"}}) {
+		t.Errorf("attvalues = %+v", n.Attvalues)
+	}
+	if !strings.Contains(out, `type="integer"`) {
+		t.Error("int attributes aren't declared as integer")
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	want := `graph bipartite {
+  "committee-a11" [label="Müller & \"Söhne\" <GmbH>\\\nzweite Zeile", type="committee", refId="a11", members="1"];
+  "politician-1001" [label="Erika Muster", type="politician", refId="1001", faction="BÜNDNIS 90/DIE GRÜNEN"];
+  "politician-1001" -- "committee-a11" [weight=1, roles="member,chair"];
+}
+`
+	if got := write(t, "dot"); got != want {
+		t.Errorf("dot =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,205 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/events"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+	"github.com/samber/lo"
+)
+
+var roles = []v1.MembershipRole{v1.RoleMember, v1.RoleSubstitute, v1.RoleLead, v1.RoleChair, v1.RoleViceChair}
+
+// Handler exports the network of MdBs and committees. It needs every bio
+// and committee, so the graph is built in the background and rebuilt after
+// changes.
+type Handler struct {
+	politicians   resources.Repository[v1.PersonListEntry]
+	bios          resources.DetailRepository[v1.Politician]
+	committeeList resources.Repository[v1.CommitteeListEntry]
+	committees    resources.DetailRepository[v1.CommitteeDetails]
+
+	// settle is the time to wait for more changes before a rebuild, retry the
+	// time before another attempt after a failed one
+	settle time.Duration
+	retry  time.Duration
+
+	mu    sync.RWMutex
+	graph *v1.Graph
+}
+
+func NewHandler(politicians resources.Repository[v1.PersonListEntry], bios resources.DetailRepository[v1.Politician],
+	committeeList resources.Repository[v1.CommitteeListEntry], committees resources.DetailRepository[v1.CommitteeDetails]) *Handler {
+	return &Handler{politicians: politicians, bios: bios, committeeList: committeeList, committees: committees,
+		settle: 10 * time.Second, retry: time.Minute}
+}
+
+// Run builds the graph right away and again whenever MdBs or committees
+// changed, until ctx is done. A build that fails is retried, the previous
+// graph is served until one succeeds.
+func (h *Handler) Run(ctx context.Context, ch <-chan events.Event) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-ch:
+			switch e.Type {
+			case events.PoliticianAdded, events.PoliticianLeft, events.PoliticianUpdated, events.PoliticianFactionChanged,
+				events.CommitteeAdded, events.CommitteeRemoved, events.CommitteeMembershipChanged:
+				timer.Reset(h.settle)
+			}
+		case <-timer.C:
+			if err := h.build(ctx); err != nil {
+				fmt.Printf("failed to build committee graph: %v\n", err)
+				timer.Reset(h.retry)
+			}
+		}
+	}
+}
+
+// Committees serves the graph of MdBs and committees as JSON, GraphML,
+// GEXF or DOT, chosen by ?format= or the Accept header.
+//
+//	?mode=   bipartite (default) links MdBs to committees, projected links
+//	         MdBs sharing committees, weighted by their number
+//	?roles=  memberships to include, comma-separated, e.g. member,chair
+func (h *Handler) Committees(w http.ResponseWriter, req *http.Request) {
+	q := req.URL.Query()
+
+	mode := v1.GraphMode(q.Get("mode"))
+	switch mode {
+	case "":
+		mode = v1.GraphBipartite
+	case v1.GraphBipartite, v1.GraphProjected:
+	default:
+		http.Error(w, "Invalid mode, expected bipartite or projected", http.StatusBadRequest)
+		return
+	}
+
+	var include []v1.MembershipRole
+	for _, s := range strings.Split(q.Get("roles"), ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		role := v1.MembershipRole(s)
+		if !slices.Contains(roles, role) {
+			http.Error(w, fmt.Sprintf("Invalid role %s, expected one of %v", s, roles), http.StatusBadRequest)
+			return
+		}
+		include = append(include, role)
+	}
+
+	f, ok := selectFormat(w, req)
+	if !ok {
+		return
+	}
+
+	h.mu.RLock()
+	g := h.graph
+	h.mu.RUnlock()
+	if g == nil {
+		w.Header().Set("Retry-After", strconv.Itoa(int(h.retry.Seconds())))
+		http.Error(w, "The graph is being built, try again later", http.StatusServiceUnavailable)
+		return
+	}
+
+	g = filter(g, include)
+	if mode == v1.GraphProjected {
+		g = project(g, nil)
+	}
+
+	w.Header().Set("Content-Type", f.mediaType+"; charset=utf-8")
+	if f.name != "json" {
+		rest.Attachment(w, req, f.name)
+	}
+	w.WriteHeader(http.StatusOK)
+	if err := f.write(w, g); err != nil {
+		fmt.Printf("failed to write graph: %v\n", err)
+	}
+}
+
+// build reads the catalogs, all bios of active MdBs and all committees and
+// replaces the graph, unless one of them couldn't be read.
+func (h *Handler) build(ctx context.Context) error {
+	var politicians []v1.PersonListEntry
+	for _, p := range h.politicians.List(ctx) {
+		if p.Id.Active() {
+			politicians = append(politicians, p)
+		}
+	}
+	committeeList := h.committeeList.List(ctx)
+	if len(politicians) == 0 || len(committeeList) == 0 {
+		return errors.New("empty catalog")
+	}
+
+	ids := lo.Map(politicians, func(p v1.PersonListEntry, _ int) string { return p.Id.Value })
+	bios, err := batch(ctx, h.bios, ids)
+	if err != nil {
+		return err
+	}
+	ids = lo.Map(committeeList, func(c v1.CommitteeListEntry, _ int) string { return c.Id })
+	committees, err := batch(ctx, h.committees, ids)
+	if err != nil {
+		return err
+	}
+
+	g := bipartite(politicians, bios, committees)
+	h.mu.Lock()
+	h.graph = g
+	h.mu.Unlock()
+	return nil
+}
+
+// batch reads the details of all ids, failing if any can't be read.
+func batch[T any](ctx context.Context, repo resources.DetailBatcher[T], ids []string) ([]T, error) {
+	get := repo.Batch(ctx, ids)
+	result := make([]T, 0, len(ids))
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		d, err := get(id)
+		if err != nil {
+			return nil, fmt.Errorf("details of %s: %w", id, err)
+		}
+		result = append(result, *d)
+	}
+	return result, nil
+}
+
+func selectFormat(w http.ResponseWriter, req *http.Request) (format, bool) {
+	if name := req.URL.Query().Get("format"); name != "" {
+		for _, f := range formats {
+			if strings.EqualFold(f.name, name) {
+				w.Header().Add("Vary", "Accept")
+				return f, true
+			}
+		}
+		http.Error(w, "Invalid format, expected json, graphml, gexf or dot", http.StatusBadRequest)
+		return format{}, false
+	}
+
+	offers := make([]string, len(formats))
+	for i, f := range formats {
+		offers[i] = f.mediaType
+	}
+	mediaType, ok := rest.Negotiate(w, req, offers...)
+	if !ok {
+		return format{}, false
+	}
+	i := slices.IndexFunc(formats, func(f format) bool { return f.mediaType == mediaType })
+	return formats[i], true
+}
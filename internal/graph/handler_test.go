@@ -0,0 +1,81 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+type list[T any] struct {
+	resources.Repository[T]
+	items []T
+}
+
+func (l list[T]) List(ctx context.Context) []T { return l.items }
+
+// details serves the zero value for every id but failing.
+type details[T any] struct {
+	resources.DetailRepository[T]
+	failing string
+}
+
+func (d *details[T]) Batch(ctx context.Context, ids []string) func(id string) (*T, error) {
+	return func(id string) (*T, error) {
+		if id == d.failing {
+			return nil, errors.New("unavailable")
+		}
+		return new(T), nil
+	}
+}
+
+func TestBuild(t *testing.T) {
+	former := person("9999", "Former Member", "FDP")
+	former.Id.Status = "Ehemalig"
+	bios := &details[v1.Politician]{failing: "1002"}
+	committees := &details[v1.CommitteeDetails]{}
+	h := NewHandler(list[v1.PersonListEntry]{items: []v1.PersonListEntry{person("1001", "Erika Muster", "SPD"), person("1002", "Max Beispiel", "CDU/CSU"), former}},
+		bios, list[v1.CommitteeListEntry]{items: []v1.CommitteeListEntry{{Id: "a11"}}}, committees)
+
+	export := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.Committees(w, httptest.NewRequest(http.MethodGet, "/graphs/committees", nil))
+		return w
+	}
+
+	if err := h.build(context.Background()); err == nil {
+		t.Error("build with a failing bio succeeded")
+	}
+	if w := export(); w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "60" {
+		t.Errorf("export before the first build = %d, want 503 with Retry-After", w.Code)
+	}
+
+	bios.failing = ""
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := h.build(ctx); err == nil {
+		t.Error("cancelled build succeeded")
+	}
+
+	if err := h.build(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if w := export(); w.Code != http.StatusOK {
+		t.Errorf("export = %d, want 200", w.Code)
+	}
+	// the former MdB isn't a node
+	if len(h.graph.Nodes) != 3 {
+		t.Errorf("nodes = %+v, want 2 MdBs and a committee", h.graph.Nodes)
+	}
+
+	// a failed rebuild keeps the previous graph
+	committees.failing = "a11"
+	previous := h.graph
+	if err := h.build(context.Background()); err == nil || h.graph != previous {
+		t.Errorf("build = %v, graph replaced: %v", err, h.graph != previous)
+	}
+}
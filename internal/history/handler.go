@@ -0,0 +1,106 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+)
+
+type Handler struct {
+	store *Store
+}
+
+func NewHandler(store *Store) *Handler {
+	return &Handler{store: store}
+}
+
+// Timeline lists the recorded versions of a resource per kind.
+type Timeline struct {
+	ID       string             `json:"id"`
+	Versions map[Kind][]Version `json:"versions"`
+}
+
+// PointInTime serves the version of kind that was current at ?at= through
+// next, in the representations next offers, and delegates to next for
+// requests without it. at is either a date, meaning the end of that day in
+// UTC, or an RFC 3339 timestamp.
+func PointInTime[T any](h *Handler, kind Kind, next rest.Handler[T]) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		at := req.URL.Query().Get("at")
+		if at == "" {
+			next.Get(w, req)
+			return
+		}
+		if req.URL.Query().Has("embed") {
+			http.Error(w, "The at parameter can't be combined with embed", http.StatusBadRequest)
+			return
+		}
+
+		t, err := parseAt(at)
+		if err != nil {
+			http.Error(w, "Invalid at parameter, expected YYYY-MM-DD or RFC 3339", http.StatusBadRequest)
+			return
+		}
+
+		version, err := h.store.At(req.Context(), kind, req.PathValue("id"), t)
+		if errors.Is(err, ErrNoVersion) {
+			http.Error(w, "No version recorded at that time", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to read history", http.StatusInternalServerError)
+			return
+		}
+
+		var res T
+		if err := json.Unmarshal(version.Data, &res); err != nil {
+			fmt.Printf("failed to unmarshal version of %s %s: %v\n", kind, req.PathValue("id"), err)
+			http.Error(w, "Failed to read history", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Last-Modified", version.ValidFrom.Format(http.TimeFormat))
+		next.Serve(w, req, &res)
+	}
+}
+
+// History serves the Timeline of the resource identified by the id path
+// value across the given kinds.
+func (h *Handler) History(kinds ...Kind) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		id := req.PathValue("id")
+		timeline := Timeline{ID: id, Versions: map[Kind][]Version{}}
+
+		for _, kind := range kinds {
+			versions, err := h.store.Versions(req.Context(), kind, id)
+			if err != nil {
+				http.Error(w, "Failed to read history", http.StatusInternalServerError)
+				return
+			}
+			if len(versions) > 0 {
+				timeline.Versions[kind] = versions
+			}
+		}
+
+		if len(timeline.Versions) == 0 {
+			http.Error(w, "No history recorded", http.StatusNotFound)
+			return
+		}
+
+		if err := rest.MarshalResponse(w, http.StatusOK, timeline); err != nil {
+			http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+func parseAt(s string) (time.Time, error) {
+	if d, err := time.Parse(time.DateOnly, s); err == nil {
+		return d.Add(24*time.Hour - time.Nanosecond), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
@@ -0,0 +1,151 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+type item struct {
+	ID   string `json:"id" xml:"id"`
+	Name string `json:"name" xml:"name"`
+}
+
+// items serves a single current item.
+type items struct {
+	resources.DetailRepository[item]
+	current item
+}
+
+func (r *items) Get(ctx context.Context, id string) (*item, error) {
+	if id != r.current.ID {
+		return nil, errors.New("not found")
+	}
+	res := r.current
+	return &res, nil
+}
+
+func (r *items) Refresh(ctx context.Context, entry resources.Entry) (*item, error) {
+	return r.Get(ctx, entry.GetId())
+}
+
+func (r *items) Name() string { return "items" }
+
+type entry string
+
+func (e entry) GetId() string          { return string(e) }
+func (e entry) GetDetailUrl() *url.URL { return nil }
+
+func TestPointInTime(t *testing.T) {
+	store := NewStore(backend{}, 0)
+	if err := store.Record(context.Background(), KindBio, "1", item{ID: "1", Name: "Old"}); err != nil {
+		t.Fatal(err)
+	}
+	recorded := time.Now().UTC()
+
+	repo := &items{current: item{ID: "1", Name: "current"}}
+	handler := rest.NewHandler[item](repo,
+		rest.WithTransform(func(req *http.Request, res *item) error {
+			if req.URL.Query().Get("markup") == "upper" {
+				res.Name = strings.ToUpper(res.Name)
+			}
+			return nil
+		}),
+		rest.WithLinks(func(req *http.Request, res *item) []rest.Link {
+			return []rest.Link{{Link: "/items/" + res.ID, Rel: "self"}}
+		}))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/items/{id}", PointInTime(NewHandler(store), KindBio, handler))
+	serve := func(target, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	at := recorded.Add(time.Second).Format(time.RFC3339)
+	tests := []struct {
+		name, target, accept string
+		status               int
+		contentType, body    string
+	}{
+		{"current", "/items/1", "", http.StatusOK, "application/json", `"name":"current"`},
+		{"json with links", "/items/1?at=" + at, "", http.StatusOK, "application/json", `{"id":"1","name":"Old","_links":[{"link":"/items/1","rel":"self"}]}`},
+		{"transformed", "/items/1?markup=upper&at=" + at, "", http.StatusOK, "application/json", `"name":"OLD"`},
+		{"xml", "/items/1?at=" + at, "application/xml", http.StatusOK, "application/xml", `<name>Old</name>`},
+		{"not acceptable", "/items/1?at=" + at, "text/csv", http.StatusNotAcceptable, "", ""},
+		{"before the first version", "/items/1?at=2000-01-01", "", http.StatusNotFound, "", ""},
+		{"invalid", "/items/1?at=yesterday", "", http.StatusBadRequest, "", ""},
+		{"embed", "/items/1?embed=bio&at=" + at, "", http.StatusBadRequest, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(tt.target, tt.accept)
+			if rec.Code != tt.status {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, tt.contentType) {
+				t.Errorf("Content-Type %q, want %s", ct, tt.contentType)
+			}
+			if !strings.Contains(rec.Body.String(), tt.body) {
+				t.Errorf("body %s, want %s", rec.Body, tt.body)
+			}
+			if strings.Contains(tt.target, "at=") && rec.Header().Get("Last-Modified") == "" {
+				t.Error("no Last-Modified")
+			}
+		})
+	}
+}
+
+func TestRecordingRepo(t *testing.T) {
+	store := NewStore(backend{}, 0)
+	current := &items{current: item{ID: "1", Name: "first"}}
+	repo := NewRecordingRepo[item](current, store, KindBio)
+	ctx := context.Background()
+
+	for range 3 {
+		if _, err := repo.Get(ctx, "1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := repo.Get(ctx, "2"); err == nil {
+		t.Error("Get of an unknown item succeeded")
+	}
+	versions, _ := store.Versions(ctx, KindBio, "1")
+	if len(versions) != 1 {
+		t.Fatalf("%d versions after the first reads, want 1", len(versions))
+	}
+
+	// only refreshes record changes
+	current.current.Name = "second"
+	if _, err := repo.Get(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+	if versions, _ := store.Versions(ctx, KindBio, "1"); len(versions) != 1 {
+		t.Errorf("%d versions after a read, want 1", len(versions))
+	}
+	if _, err := repo.Refresh(ctx, entry("1")); err != nil {
+		t.Fatal(err)
+	}
+	versions, _ = store.Versions(ctx, KindBio, "1")
+	var last item
+	if len(versions) != 2 || json.Unmarshal(versions[1].Data, &last) != nil || last.Name != "second" {
+		t.Errorf("versions after a refresh = %+v", versions)
+	}
+}
@@ -0,0 +1,58 @@
+package history
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+type recordingRepo[T any] struct {
+	resources.DetailRepository[T]
+	store *Store
+	kind  Kind
+	// recorded holds the ids recorded since the start
+	recorded *sync.Map
+}
+
+// NewRecordingRepo records every detail document refreshed by repo as a
+// version of kind. Documents that are only served are recorded once, the
+// cache only changes when they are refreshed, so the history also covers
+// what was cached before.
+func NewRecordingRepo[T any](repo resources.DetailRepository[T], store *Store, kind Kind) resources.DetailRepository[T] {
+	return recordingRepo[T]{
+		DetailRepository: repo,
+		store:            store,
+		kind:             kind,
+		recorded:         &sync.Map{},
+	}
+}
+
+func (r recordingRepo[T]) Get(ctx context.Context, id string) (*T, error) {
+	res, err := r.DetailRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.recorded.Load(id); !ok {
+		r.record(ctx, id, res)
+	}
+	return res, nil
+}
+
+func (r recordingRepo[T]) Refresh(ctx context.Context, entry resources.Entry) (*T, error) {
+	res, err := r.DetailRepository.Refresh(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	r.record(ctx, entry.GetId(), res)
+	return res, nil
+}
+
+func (r recordingRepo[T]) record(ctx context.Context, id string, res *T) {
+	if err := r.store.Record(ctx, r.kind, id, res); err != nil {
+		slog.Error("failed to record history", "kind", r.kind, "id", id, "error", err)
+		return
+	}
+	r.recorded.Store(id, true)
+}
@@ -0,0 +1,169 @@
+package history
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sync"
+	"time"
+)
+
+type Kind string
+
+const (
+	KindPolitician Kind = "politicians"
+	KindBio        Kind = "bios"
+	KindCommittee  Kind = "committees"
+)
+
+var ErrNoVersion = errors.New("no version recorded at that time")
+
+// Version is the state of a resource from ValidFrom until the ValidFrom of
+// the next version.
+type Version struct {
+	ValidFrom time.Time       `json:"validFrom"`
+	Hash      string          `json:"hash,omitempty"`
+	Removed   bool            `json:"removed,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// Backend holds one document per resource, e.g. an img.FileStore on a
+// mounted volume or an img.S3Store shared by all instances.
+type Backend interface {
+	// Get returns an error matching fs.ErrNotExist if name isn't stored.
+	Get(ctx context.Context, name string) ([]byte, error)
+	Put(ctx context.Context, name string, data []byte) error
+}
+
+// Store persists the distinct versions of a resource as one JSON document per
+// resource in backend. Only the latest maxVersions are kept, older ones are
+// dropped as new ones are recorded.
+type Store struct {
+	mu          sync.Mutex
+	backend     Backend
+	maxVersions int
+	// last holds the hash of the latest version of every resource, without
+	// its data
+	last map[string]Version
+}
+
+func NewStore(backend Backend, maxVersions int) *Store {
+	return &Store{backend: backend, maxVersions: maxVersions, last: map[string]Version{}}
+}
+
+// Record appends v as a new version unless it equals the latest one.
+func (s *Store) Record(ctx context.Context, kind Kind, id string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s %s: %w", kind, id, err)
+	}
+	sum := sha256.Sum256(data)
+
+	return s.append(ctx, kind, id, Version{
+		ValidFrom: time.Now().UTC(),
+		Hash:      hex.EncodeToString(sum[:]),
+		Data:      data,
+	})
+}
+
+// Remove records that the resource disappeared upstream.
+func (s *Store) Remove(ctx context.Context, kind Kind, id string) error {
+	return s.append(ctx, kind, id, Version{ValidFrom: time.Now().UTC(), Removed: true})
+}
+
+// Versions returns all recorded versions, oldest first.
+func (s *Store) Versions(ctx context.Context, kind Kind, id string) ([]Version, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.read(ctx, kind, id)
+}
+
+// At returns the version that was current at t.
+func (s *Store) At(ctx context.Context, kind Kind, id string, t time.Time) (*Version, error) {
+	versions, err := s.Versions(ctx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		if !versions[i].ValidFrom.After(t) {
+			if versions[i].Removed {
+				return nil, ErrNoVersion
+			}
+			return &versions[i], nil
+		}
+	}
+
+	return nil, ErrNoVersion
+}
+
+func (s *Store) append(ctx context.Context, kind Kind, id string, v Version) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := s.name(kind, id)
+	if last, ok := s.last[key]; ok && last.Removed == v.Removed && last.Hash == v.Hash {
+		return nil
+	}
+
+	versions, err := s.read(ctx, kind, id)
+	if err != nil {
+		return err
+	}
+	if len(versions) > 0 {
+		last := versions[len(versions)-1]
+		if last.Removed == v.Removed && last.Hash == v.Hash {
+			s.remember(key, last)
+			return nil
+		}
+	} else if v.Removed {
+		return nil
+	}
+
+	versions = append(versions, v)
+	if s.maxVersions > 0 && len(versions) > s.maxVersions {
+		versions = versions[len(versions)-s.maxVersions:]
+	}
+	data, err := json.Marshal(versions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history of %s: %w", key, err)
+	}
+	if err := s.backend.Put(ctx, key, data); err != nil {
+		return fmt.Errorf("failed to write history of %s: %w", key, err)
+	}
+
+	s.remember(key, v)
+	return nil
+}
+
+func (s *Store) remember(key string, v Version) {
+	v.Data = nil
+	s.last[key] = v
+}
+
+func (s *Store) read(ctx context.Context, kind Kind, id string) ([]Version, error) {
+	data, err := s.backend.Get(ctx, s.name(kind, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history of %s %s: %w", kind, id, err)
+	}
+
+	var versions []Version
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal history of %s %s: %w", kind, id, err)
+	}
+	return versions, nil
+}
+
+func (s *Store) name(kind Kind, id string) string {
+	// ids come from request paths, never let them name another document
+	return string(kind) + "-" + path.Base(path.Clean("/"+id)) + ".json"
+}
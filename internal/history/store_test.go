@@ -0,0 +1,73 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"testing"
+)
+
+// backend keeps the documents in memory.
+type backend map[string][]byte
+
+func (b backend) Get(ctx context.Context, name string) ([]byte, error) {
+	data, ok := b[name]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return data, nil
+}
+
+func (b backend) Put(ctx context.Context, name string, data []byte) error {
+	b[name] = data
+	return nil
+}
+
+func TestStoreMaxVersions(t *testing.T) {
+	ctx := context.Background()
+	b := backend{}
+	store := NewStore(b, 3)
+	for _, name := range []string{"a", "b", "b", "c", "d", "e"} {
+		if err := store.Record(ctx, KindBio, "1", item{ID: "1", Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names := func(s *Store) []string {
+		versions, err := s.Versions(ctx, KindBio, "1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, v := range versions {
+			var it item
+			if err := json.Unmarshal(v.Data, &it); err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, it.Name)
+		}
+		return names
+	}
+	if got := names(store); len(got) != 3 || got[0] != "c" || got[2] != "e" {
+		t.Errorf("versions = %v, want [c d e]", got)
+	}
+	if _, ok := b["bios-1.json"]; !ok || len(b) != 1 {
+		t.Errorf("documents = %v", b)
+	}
+
+	// another instance, or this one after a restart, reads the same history
+	restarted := NewStore(b, 3)
+	if err := restarted.Record(ctx, KindBio, "1", item{ID: "1", Name: "e"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := names(restarted); len(got) != 3 || got[2] != "e" {
+		t.Errorf("versions after a restart = %v, want [c d e]", got)
+	}
+}
+
+func TestStoreName(t *testing.T) {
+	store := NewStore(backend{}, 0)
+	if got := store.name(KindBio, "../../etc/passwd"); got != "bios-passwd.json" {
+		t.Errorf("name = %q", got)
+	}
+}
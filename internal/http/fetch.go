@@ -2,10 +2,12 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 )
 
 const (
@@ -78,29 +80,39 @@ type RWCache interface {
 	Write(data []byte) error
 }
 
+// cacheMu guards the read-modify-write cycle on RWCache files, which are
+// shared between request handlers and the background refresh.
+var cacheMu sync.Mutex
+
 func FetchCachedUrl(url *url.URL, cache RWCache) ([]byte, error) {
-	// Read or create the cache file
-	data, err := cache.Read()
+	cacheMu.Lock()
+	c, err := readCacheMap(cache)
+	cacheMu.Unlock()
 	if err != nil {
 		return nil, err
 	}
 
-	// Initialize the cache map from the file
-	c := make(map[string][]byte)
-	if len(data) > 0 {
-		if err := json.Unmarshal(data, &c); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal cache file: %w", err)
-		}
-	}
-
 	// Check for a cache hit
 	entry, hit := c[url.String()]
 	if hit {
 		return entry, nil
 	}
 
-	// Fetch data if not cached
-	data, err = FetchUrl(url)
+	return RefreshCachedUrl(url, cache)
+}
+
+// RefreshCachedUrl fetches url regardless of the cache state and replaces
+// the cached entry with the fresh response.
+func RefreshCachedUrl(url *url.URL, cache RWCache) ([]byte, error) {
+	data, err := FetchUrl(url)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	c, err := readCacheMap(cache)
 	if err != nil {
 		return nil, err
 	}
@@ -120,3 +132,77 @@ func FetchCachedUrl(url *url.URL, cache RWCache) ([]byte, error) {
 
 	return data, nil
 }
+
+// FetchCachedUrls works like FetchCachedUrl for many urls but reads and
+// writes the cache only once. Urls that fail to fetch are left out of the
+// result and reported in the returned error.
+func FetchCachedUrls(urls []*url.URL, cache RWCache) (map[string][]byte, error) {
+	cacheMu.Lock()
+	c, err := readCacheMap(cache)
+	cacheMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]byte, len(urls))
+	fetched := make(map[string][]byte)
+	var errs []error
+	for _, u := range urls {
+		if entry, hit := c[u.String()]; hit {
+			result[u.String()] = entry
+			continue
+		}
+
+		data, err := FetchUrl(u)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result[u.String()] = data
+		fetched[u.String()] = data
+	}
+
+	if len(fetched) == 0 {
+		return result, errors.Join(errs...)
+	}
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	// re-read, the cache may have been written in the meantime
+	c, err = readCacheMap(cache)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range fetched {
+		c[k] = v
+	}
+
+	cacheData, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cache data: %w", err)
+	}
+	if err := cache.Write(cacheData); err != nil {
+		return nil, err
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func readCacheMap(cache RWCache) (map[string][]byte, error) {
+	// Read or create the cache file
+	data, err := cache.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the cache map from the file
+	c := make(map[string][]byte)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal cache file: %w", err)
+		}
+	}
+
+	return c, nil
+}
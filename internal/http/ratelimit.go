@@ -0,0 +1,176 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Budget is a token bucket, Burst requests at once, refilled by PerMinute
+// requests a minute.
+type Budget struct {
+	PerMinute int
+	Burst     int
+}
+
+func (b Budget) rate() float64 {
+	return float64(b.PerMinute) / 60
+}
+
+// RateLimitOptions configure MiddlewareRateLimit.
+type RateLimitOptions struct {
+	Cheap     Budget
+	Expensive Budget
+	// IsExpensive tells requests that fetch upstream or convert images, e.g.
+	// by their r.Pattern. All others are cheap.
+	IsExpensive func(r *http.Request) bool
+	// Keys are the API keys clients may send in X-API-Key for budgets of
+	// their own, KeyFactor times as large. Unknown keys are limited by IP.
+	Keys      []string
+	KeyFactor int
+	// ProxyHops is the number of proxies in front of the server appending
+	// to X-Forwarded-For, 1 on Cloud Run. With 0 the remote address is the
+	// client.
+	ProxyHops int
+}
+
+// MiddlewareRateLimit limits the requests of every client, identified by
+// API key or IP, IPv6 addresses by their /64 network. Cheap and expensive
+// requests draw from separate buckets. The state of the bucket is sent in
+// the RateLimit-* headers of draft-ietf-httpapi-ratelimit-headers, clients
+// over budget get 429 Too Many Requests with Retry-After.
+//
+// Buckets are kept in memory, every instance of the server limits on its
+// own.
+func MiddlewareRateLimit(opts RateLimitOptions) Middleware {
+	keys := map[string]bool{}
+	for _, k := range opts.Keys {
+		keys[k] = true
+	}
+	l := &limiter{buckets: map[string]*bucket{}}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			budget, class := opts.Cheap, "cheap"
+			if opts.IsExpensive != nil && opts.IsExpensive(r) {
+				budget, class = opts.Expensive, "expensive"
+			}
+
+			client := "ip:" + clientIP(r, opts.ProxyHops)
+			if key := r.Header.Get("X-API-Key"); key != "" && keys[key] {
+				client = "key:" + key
+				budget.PerMinute *= max(opts.KeyFactor, 1)
+				budget.Burst *= max(opts.KeyFactor, 1)
+			}
+			if budget.PerMinute <= 0 || budget.Burst <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			remaining, reset, retry, ok := l.take(class+" "+client, budget, time.Now())
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(budget.Burst))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(remaining))
+			w.Header().Set("RateLimit-Reset", seconds(reset))
+			w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%s", budget.Burst, seconds(time.Duration(float64(budget.Burst)/budget.rate()*float64(time.Second)))))
+			if !ok {
+				w.Header().Set("Retry-After", seconds(retry))
+				http.Error(w, fmt.Sprintf("Too many requests, retry in %s seconds", seconds(retry)), http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// seconds rounds d up to whole seconds.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// clientIP is the address hops proxies in front of the server received the
+// request from. Entries further left in X-Forwarded-For are sent by the
+// client itself and can't be trusted.
+func clientIP(r *http.Request, hops int) string {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	if hops > 0 {
+		var forwarded []string
+		for _, h := range r.Header.Values("X-Forwarded-For") {
+			forwarded = append(forwarded, strings.Split(h, ",")...)
+		}
+		if len(forwarded) >= hops {
+			ip = strings.TrimSpace(forwarded[len(forwarded)-hops])
+		}
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ip
+	}
+	addr = addr.Unmap()
+	// the smallest network a client usually gets
+	if addr.Is6() {
+		if prefix, err := addr.Prefix(64); err == nil {
+			return prefix.String()
+		}
+	}
+	return addr.String()
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	full   time.Time // refilled by then, to drop the bucket
+}
+
+// limiter keeps the buckets of all clients. Full buckets are dropped once
+// a minute, a new one would be full just the same.
+type limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+// take takes a token from the bucket of key. It returns the tokens left, the
+// time until the bucket is full again and, if there was none, the time until
+// there is one.
+func (l *limiter) take(key string, budget Budget, now time.Time) (remaining int, reset, retry time.Duration, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.swept) > time.Minute {
+		for k, b := range l.buckets {
+			if !now.Before(b.full) {
+				delete(l.buckets, k)
+			}
+		}
+		l.swept = now
+	}
+
+	rate, burst := budget.rate(), float64(budget.Burst)
+	b, found := l.buckets[key]
+	if !found {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		ok = true
+	} else {
+		retry = time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+	reset = time.Duration((burst - b.tokens) / rate * float64(time.Second))
+	b.full = now.Add(reset)
+	return int(b.tokens), reset, retry, ok
+}
@@ -0,0 +1,176 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded []string
+		hops      int
+		want      string
+	}{
+		{"direct", "198.51.100.7:4711", nil, 0, "198.51.100.7"},
+		{"direct ignores forwarded", "198.51.100.7:4711", []string{"203.0.113.9"}, 0, "198.51.100.7"},
+		{"one hop", "169.254.1.1:4711", []string{"203.0.113.9"}, 1, "203.0.113.9"},
+		{"one hop spoofed", "169.254.1.1:4711", []string{"10.0.0.1, 192.0.2.1, 203.0.113.9"}, 1, "203.0.113.9"},
+		{"one hop spoofed in own header", "169.254.1.1:4711", []string{"10.0.0.1", "203.0.113.9"}, 1, "203.0.113.9"},
+		{"two hops", "169.254.1.1:4711", []string{"203.0.113.9, 35.191.0.1"}, 2, "203.0.113.9"},
+		{"two hops spoofed", "169.254.1.1:4711", []string{"10.0.0.1,203.0.113.9,35.191.0.1"}, 2, "203.0.113.9"},
+		{"fewer entries than hops", "169.254.1.1:4711", []string{"203.0.113.9"}, 2, "169.254.1.1"},
+		{"no forwarded header", "169.254.1.1:4711", nil, 1, "169.254.1.1"},
+		{"ipv6 by network", "[2001:db8:1:2:3:4:5:6]:4711", nil, 0, "2001:db8:1:2::/64"},
+		{"ipv6 forwarded", "169.254.1.1:4711", []string{"2001:db8:1:2:aaaa::1"}, 1, "2001:db8:1:2::/64"},
+		{"mapped ipv4", "[::ffff:198.51.100.7]:4711", nil, 0, "198.51.100.7"},
+		{"garbage", "169.254.1.1:4711", []string{"not an ip"}, 1, "not an ip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/politicians", nil)
+			r.RemoteAddr = tt.remote
+			for _, f := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", f)
+			}
+			if got := clientIP(r, tt.hops); got != tt.want {
+				t.Errorf("clientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiterTake(t *testing.T) {
+	l := &limiter{buckets: map[string]*bucket{}}
+	budget := Budget{PerMinute: 60, Burst: 3}
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	for i, want := range []int{2, 1, 0} {
+		remaining, _, _, ok := l.take("a", budget, now)
+		if !ok || remaining != want {
+			t.Fatalf("take %d = %d, %v, want %d, true", i, remaining, ok, want)
+		}
+	}
+
+	_, reset, retry, ok := l.take("a", budget, now)
+	if ok {
+		t.Fatal("take from an empty bucket succeeded")
+	}
+	if retry != time.Second || reset != 3*time.Second {
+		t.Errorf("retry = %v, reset = %v, want 1s, 3s", retry, reset)
+	}
+
+	if _, _, _, ok := l.take("b", budget, now); !ok {
+		t.Error("bucket of another key is empty")
+	}
+
+	// one token a second
+	now = now.Add(1500 * time.Millisecond)
+	if _, _, _, ok := l.take("a", budget, now); !ok {
+		t.Error("bucket wasn't refilled")
+	}
+	if _, _, retry, ok := l.take("a", budget, now); ok || retry != 500*time.Millisecond {
+		t.Errorf("take = %v with retry %v, want false with 500ms", ok, retry)
+	}
+
+	// refilled, but never above the burst
+	now = now.Add(time.Hour)
+	if remaining, _, _, _ := l.take("a", budget, now); remaining != 2 {
+		t.Errorf("remaining = %d after an hour, want 2", remaining)
+	}
+}
+
+func TestLimiterSweep(t *testing.T) {
+	l := &limiter{buckets: map[string]*bucket{}}
+	budget := Budget{PerMinute: 60, Burst: 3}
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	l.take("a", budget, now)
+	for range 3 {
+		l.take("b", budget, now.Add(118*time.Second))
+	}
+	l.take("c", budget, now.Add(2*time.Minute))
+
+	if _, ok := l.buckets["a"]; ok {
+		t.Error("full bucket wasn't dropped")
+	}
+	if _, ok := l.buckets["b"]; !ok {
+		t.Error("bucket still refilling was dropped")
+	}
+}
+
+func TestMiddlewareRateLimit(t *testing.T) {
+	mw := MiddlewareRateLimit(RateLimitOptions{
+		Cheap:       Budget{PerMinute: 60, Burst: 2},
+		Expensive:   Budget{PerMinute: 6, Burst: 1},
+		IsExpensive: func(r *http.Request) bool { return r.URL.Path == "/expensive" },
+		Keys:        []string{"secret"},
+		KeyFactor:   10,
+	})
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	serve := func(path, key string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.RemoteAddr = "198.51.100.7:4711"
+		if key != "" {
+			r.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve("/cheap", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	for header, want := range map[string]string{
+		"RateLimit-Limit":     "2",
+		"RateLimit-Remaining": "1",
+		"RateLimit-Reset":     "1",
+		"RateLimit-Policy":    "2;w=2",
+	} {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+
+	serve("/cheap", "")
+	w = serve("/cheap", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("Retry-After = %q, want 1", got)
+	}
+
+	// separate budgets
+	if w := serve("/expensive", ""); w.Code != http.StatusOK || w.Header().Get("RateLimit-Policy") != "1;w=10" {
+		t.Errorf("expensive = %d with policy %q, want 200 with 1;w=10", w.Code, w.Header().Get("RateLimit-Policy"))
+	}
+	if w := serve("/expensive", ""); w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "10" {
+		t.Errorf("expensive = %d with Retry-After %q, want 429 with 10", w.Code, w.Header().Get("Retry-After"))
+	}
+
+	// known keys have a bucket of their own, unknown ones count as the IP
+	if w := serve("/cheap", "secret"); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "20" {
+		t.Errorf("key = %d with limit %q, want 200 with 20", w.Code, w.Header().Get("RateLimit-Limit"))
+	}
+	if w := serve("/cheap", "guessed"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("unknown key = %d, want 429", w.Code)
+	}
+}
+
+func TestMiddlewareRateLimitDisabled(t *testing.T) {
+	h := MiddlewareRateLimit(RateLimitOptions{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for range 100 {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/politicians", nil))
+		if w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+			t.Fatalf("status = %d with limit %q, want 200 without", w.Code, w.Header().Get("RateLimit-Limit"))
+		}
+	}
+}
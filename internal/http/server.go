@@ -21,19 +21,34 @@ type ApiServer struct {
 	mux        *http.ServeMux
 	middleware []Middleware
 	logger     *slog.Logger
+	shutdown   chan struct{}
 }
 
 func NewApiServer(port int, logger *slog.Logger) *ApiServer {
 	mux := http.NewServeMux()
-	return &ApiServer{
+	a := &ApiServer{
 		mux:        mux,
 		middleware: []Middleware{},
 		server: &http.Server{
 			Addr:    fmt.Sprintf(":%d", port),
 			Handler: h2c.NewHandler(mux, &http2.Server{}),
 		},
-		logger: logger,
+		logger:   logger,
+		shutdown: make(chan struct{}),
 	}
+
+	// long-lived responses never become idle, they have to end themselves
+	a.server.RegisterOnShutdown(func() {
+		close(a.shutdown)
+	})
+
+	return a
+}
+
+// ShuttingDown is closed once the server starts to shut down. Handlers that
+// stream responses select on it to finish before the shutdown deadline.
+func (a *ApiServer) ShuttingDown() <-chan struct{} {
+	return a.shutdown
 }
 
 func (a *ApiServer) Use(mw Middleware) {
@@ -47,11 +62,23 @@ func (a *ApiServer) applyMiddleware(h http.Handler) http.Handler {
 	return h
 }
 
-func (a *ApiServer) AddHandler(path string, hFunc func(w http.ResponseWriter, r *http.Request)) {
-	finalHandler := a.applyMiddleware(http.HandlerFunc(hFunc))
+// AddHandler registers hFunc behind the middleware of the server and mw, which
+// only applies to this route.
+func (a *ApiServer) AddHandler(path string, hFunc func(w http.ResponseWriter, r *http.Request), mw ...Middleware) {
+	var h http.Handler = http.HandlerFunc(hFunc)
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	finalHandler := a.applyMiddleware(h)
 	a.mux.Handle(path, finalHandler)
 }
 
+// AddHttpHandler mounts a handler that brings its own routing below path,
+// e.g. the handlers generated for an RPC service.
+func (a *ApiServer) AddHttpHandler(path string, handler http.Handler) {
+	a.mux.Handle(path, a.applyMiddleware(handler))
+}
+
 func (a *ApiServer) AddStaticHandler(urlPath string, dirPath string) {
 	// FileServer to serve static files
 	fileServer := http.FileServer(http.Dir(dirPath))
@@ -1,72 +1,306 @@
 package img
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	_ "image/jpeg"
-	_ "image/png"
-	"io"
-	"net/http"
-	"os"
-	"os/exec"
-	"reflect"
+	"hash/fnv"
+	"image"
+	"io/fs"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
 )
 
-// EnsureImage checks if the image is already cached and fresh.
-func EnsureImage(res any, id string) error {
-	cachePath := fmt.Sprintf(".img/%s.webp", id)
+// maxAge is how long images are kept whose resource lists no last change
+const maxAge = 30 * 24 * time.Hour
+
+// Source is a resource with an image, like a politician or a committee.
+// Images lists the renditions upstream offers, the largest is converted.
+type Source interface {
+	Images() []v1.Image
+	ImageInfo() v1.ImageInfo
+}
+
+// Original describes the upstream image the variants of a resource are made
+// from. It is cached next to the image.
+type Original struct {
+	SourceURL string    `json:"sourceUrl"`
+	Width     int       `json:"width"`
+	Height    int       `json:"height"`
+	Changed   time.Time `json:"changed"`
+	Fetched   time.Time `json:"fetched"`
+	// Version is the last change listed upstream, the image is fetched
+	// again once it differs.
+	Version string `json:"version,omitempty"`
+	// BlurHash and DominantColor stand in for the image while it loads.
+	BlurHash      string `json:"blurHash"`
+	DominantColor string `json:"dominantColor"`
+	// Copyright is the attribution looked up elsewhere, nil until it was.
+	Copyright *string `json:"copyright,omitempty"`
+}
+
+// Image is a variant in the store of the pipeline.
+type Image struct {
+	Name     string
+	Format   Format
+	Original Original
+	// ETag identifies the content without reading it: a variant only
+	// changes along with its name, its original or the encoder settings.
+	ETag string
+
+	// data is the content, if it was read or written already
+	data []byte
+}
 
-	// Check if cached file exists and is fresh
-	if fi, err := os.Stat(cachePath); err == nil {
-		if time.Since(fi.ModTime()) < 30*24*time.Hour { // within 1 month
-			return nil
+// EnsureImage checks if variant v of the image of res is already cached and
+// current, otherwise creates it from the largest rendition of res.
+func (p *Pipeline) EnsureImage(ctx context.Context, res Source, id string, v Variant) (Image, error) {
+	orig, err := p.Original(ctx, res, id)
+	if err != nil {
+		return Image{}, err
+	}
+	im := p.image(v.name(id), v.Format, orig)
+	if ok, err := p.cached(ctx, &im); err != nil || ok {
+		return im, err
+	}
+
+	m, orig, err := p.decode(ctx, res, id, orig)
+	if err != nil {
+		return Image{}, err
+	}
+	im = p.image(im.Name, im.Format, orig)
+	return im, p.put(ctx, &im, Resize(m, v))
+}
+
+// Load returns the decoded image of res as served by upstream.
+func (p *Pipeline) Load(ctx context.Context, res Source, id string) (image.Image, Original, error) {
+	orig, err := p.Original(ctx, res, id)
+	if err != nil {
+		return nil, Original{}, err
+	}
+	return p.decode(ctx, res, id, orig)
+}
+
+// Render caches the image draw returns as name, unless it's cached already.
+// Names should start with the id of the resource, so the image is removed
+// along with its variants once the resource's image changes. orig is the
+// image drawn from, if any.
+func (p *Pipeline) Render(ctx context.Context, name string, orig Original, f Format, draw func() (image.Image, error)) (Image, error) {
+	im := p.image(name, f, orig)
+	if ok, err := p.cached(ctx, &im); err != nil || ok {
+		return im, err
+	}
+	m, err := draw()
+	if err != nil {
+		return Image{}, err
+	}
+	return im, p.put(ctx, &im, m)
+}
+
+func (p *Pipeline) image(name string, f Format, orig Original) Image {
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%s\x00%s\x00%s\x00%d\x00%d\x00%d", name, orig.SourceURL, orig.Version, orig.Fetched.Unix(), p.opts.WebPQuality, p.opts.JPEGQuality)
+	return Image{Name: name, Format: f, Original: orig, ETag: fmt.Sprintf(`"%x"`, hash.Sum64())}
+}
+
+// cached looks im up in the store. Unless it's going to be presigned, the
+// image is read right away, which saves remote stores a round trip.
+func (p *Pipeline) cached(ctx context.Context, im *Image) (bool, error) {
+	if p.presigns() {
+		return p.store.Exists(ctx, im.Name)
+	}
+	data, err := p.store.Get(ctx, im.Name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	im.data = data
+	return true, nil
+}
+
+func (p *Pipeline) decode(ctx context.Context, res Source, id string, orig Original) (image.Image, Original, error) {
+	data, err := p.store.Get(ctx, origName(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		// evicted by the store on its own
+		if orig, err = p.fetch(ctx, res, id); err == nil {
+			data, err = p.store.Get(ctx, origName(id))
 		}
 	}
+	if err != nil {
+		return nil, Original{}, err
+	}
+	m, err := p.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, Original{}, err
+	}
+	return m, orig, nil
+}
+
+func (p *Pipeline) put(ctx context.Context, im *Image, m image.Image) error {
+	var buf bytes.Buffer
+	if err := p.Encode(&buf, m, im.Format); err != nil {
+		return err
+	}
+	if err := p.store.Put(ctx, im.Name, buf.Bytes()); err != nil {
+		return err
+	}
+	im.data = buf.Bytes()
+	return nil
+}
+
+// Read returns the content of im.
+func (p *Pipeline) Read(ctx context.Context, im Image) ([]byte, error) {
+	if im.data != nil {
+		return im.data, nil
+	}
+	return p.store.Get(ctx, im.Name)
+}
+
+// Presign returns a URL clients can download im from themselves, or nil if
+// the store doesn't support that or it isn't enabled.
+func (p *Pipeline) Presign(ctx context.Context, im Image) (*url.URL, time.Duration, error) {
+	if !p.presigns() {
+		return nil, 0, nil
+	}
+	u, err := p.store.(Presigner).Presign(ctx, im.Name, p.opts.Presign)
+	return u, p.opts.Presign, err
+}
 
-	// Get URL from res.PhotoLargeURL using reflection
-	val := reflect.ValueOf(res)
-	if val.Kind() == reflect.Ptr {
-		val = val.Elem()
+func (p *Pipeline) presigns() bool {
+	_, ok := p.store.(Presigner)
+	return ok && p.opts.Presign > 0
+}
+
+// Original returns the description of the image of res as served by
+// upstream. The image is cached as well, so the variants don't fetch it
+// again. Once upstream lists another change or URL, the image is fetched
+// again and the variants made from the old one are removed.
+func (p *Pipeline) Original(ctx context.Context, res Source, id string) (Original, error) {
+	largest, version, err := source(res)
+	if err != nil {
+		return Original{}, err
 	}
-	field := val.FieldByName("PhotoLargeURL")
-	if !field.IsValid() || field.Kind() != reflect.String {
-		return fmt.Errorf("PhotoLargeURL not found or invalid")
+	// originals cached before placeholders were added lack a BlurHash
+	if orig, ok := p.cachedOriginal(ctx, id); ok && orig.SourceURL == largest.URL && orig.BlurHash != "" {
+		if version != "" && orig.Version == version || version == "" && time.Since(orig.Fetched) < maxAge {
+			return orig, nil
+		}
 	}
-	photoURL := field.String()
+	return p.fetch(ctx, res, id)
+}
 
-	// Fetch image
-	resp, err := http.Get(photoURL)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to fetch image: %w", err)
+// fetch gets the image of res from upstream and replaces whatever was cached
+// of it.
+func (p *Pipeline) fetch(ctx context.Context, res Source, id string) (Original, error) {
+	largest, version, err := source(res)
+	if err != nil {
+		return Original{}, err
+	}
+	data, err := p.Fetch(largest.URL)
+	if err != nil {
+		return Original{}, err
+	}
+	m, err := p.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Original{}, err
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	thumb := thumbnail(m)
+	orig := Original{
+		SourceURL:     largest.URL,
+		Width:         m.Bounds().Dx(),
+		Height:        m.Bounds().Dy(),
+		Changed:       res.ImageInfo().Changed(),
+		Fetched:       now,
+		Version:       version,
+		BlurHash:      blurHash(thumb),
+		DominantColor: dominantColor(thumb),
+	}
+	if orig.Changed.IsZero() {
+		orig.Changed = now
 	}
-	defer resp.Body.Close()
 
-	// Ensure .img directory exists
-	if err := os.MkdirAll(".img", 0755); err != nil {
-		return fmt.Errorf("failed to create cache dir: %w", err)
+	// the variants are named {id}-{w}x{h}..., the rest {id}.{ext}
+	for _, prefix := range []string{id + ".", id + "-"} {
+		if err := p.store.Remove(ctx, prefix); err != nil {
+			return Original{}, err
+		}
+	}
+	if err := p.store.Put(ctx, origName(id), data); err != nil {
+		return Original{}, err
 	}
+	return orig, p.describe(ctx, id, orig)
+}
 
-	tmpPath := fmt.Sprintf(".img/%s-tmp.jpg", id)
-	dstPath := fmt.Sprintf(".img/%s.webp", id)
+// Attribute keeps the copyright of the image of id with orig, its
+// description, until the image is fetched again.
+func (p *Pipeline) Attribute(ctx context.Context, id string, orig Original, copyright string) error {
+	current, ok := p.cachedOriginal(ctx, id)
+	if !ok || current.SourceURL != orig.SourceURL || current.Version != orig.Version || !current.Fetched.Equal(orig.Fetched) {
+		// fetched again in the meantime
+		return nil
+	}
+	orig.Copyright = &copyright
+	return p.describe(ctx, id, orig)
+}
 
-	tmpFile, err := os.Create(tmpPath)
+func (p *Pipeline) describe(ctx context.Context, id string, orig Original) error {
+	meta, err := json.Marshal(orig)
 	if err != nil {
-		return fmt.Errorf("failed to create temp image file: %w", err)
+		return err
 	}
-	if _, err := io.Copy(tmpFile, resp.Body); err != nil {
-		tmpFile.Close()
-		return fmt.Errorf("failed to write image: %w", err)
+	if err := p.store.Put(ctx, id+".json", meta); err != nil {
+		return err
 	}
-	tmpFile.Close()
+	p.remember(id, orig)
+	return nil
+}
+
+// cachedOriginal returns the description of the image of id kept in memory,
+// or else in the store. Descriptions stay in memory until the image is
+// fetched again, another instance sharing the store may have done so
+// already, but then the version of the resource tells.
+func (p *Pipeline) cachedOriginal(ctx context.Context, id string) (Original, bool) {
+	p.mu.Lock()
+	orig, ok := p.originals[id]
+	p.mu.Unlock()
+	if ok {
+		return orig, true
+	}
+
+	data, err := p.store.Get(ctx, id+".json")
+	if err != nil || json.Unmarshal(data, &orig) != nil {
+		return Original{}, false
+	}
+	p.remember(id, orig)
+	return orig, true
+}
+
+func (p *Pipeline) remember(id string, orig Original) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.originals[id] = orig
+}
 
-	if err := convertToWebP(tmpPath, dstPath); err != nil {
-		return fmt.Errorf("failed to convert image to WebP: %w", err)
+// source returns the largest rendition of the image of res and its version.
+func source(res Source) (v1.Image, string, error) {
+	images := res.Images()
+	if len(images) == 0 {
+		return v1.Image{}, "", ErrNoImage
 	}
-	os.Remove(tmpPath)
-	return err
+	largest := slices.MaxFunc(images, func(a, b v1.Image) int { return int(a.Size - b.Size) })
+	info := res.ImageInfo()
+	return largest, strings.TrimSpace(info.LastChanged + " " + info.ChangedDateTime), nil
 }
 
-func convertToWebP(srcPath, dstPath string) error {
-	cmd := exec.Command("cwebp", "-quiet", srcPath, "-o", dstPath)
-	return cmd.Run()
+func origName(id string) string {
+	return id + ".orig"
 }
@@ -0,0 +1,173 @@
+package img
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+)
+
+type photo struct {
+	url     string
+	version string
+}
+
+func (p photo) Images() []v1.Image      { return []v1.Image{{Size: 2, URL: p.url}} }
+func (p photo) ImageInfo() v1.ImageInfo { return v1.ImageInfo{LastChanged: p.version} }
+
+// upstream serves a 40x30 PNG and counts the downloads.
+func upstream(t *testing.T) (string, *atomic.Int32) {
+	m := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	for y := range 30 {
+		for x := range 40 {
+			m.Set(x, y, color.RGBA{uint8(x * 6), uint8(y * 8), 128, 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+
+	var fetched atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched.Add(1)
+		w.Write(buf.Bytes())
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL + "/1001.png", &fetched
+}
+
+func TestEnsureImageRoundTrips(t *testing.T) {
+	store, fake := newTestS3Store(t)
+	url, fetched := upstream(t)
+	p := NewPipeline(store, Options{WebPQuality: 75, JPEGQuality: 85})
+	ctx := context.Background()
+	res := photo{url: url, version: "01.02.2025"}
+	v := Variant{Width: 32, Height: 32, Fit: FitCover, Format: FormatPNG}
+
+	im, err := p.EnsureImage(ctx, res, "1001", v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if im.Name != "1001-32x32-cover.png" || im.Original.Width != 40 || im.Original.Height != 30 || fetched.Load() != 1 {
+		t.Errorf("image = %+v after %d downloads", im, fetched.Load())
+	}
+
+	// the new variant is served without reading it back
+	before := fake.count()
+	data, err := p.Read(ctx, im)
+	if err != nil || fake.count() != before {
+		t.Fatalf("Read = %v after %d requests", err, fake.count()-before)
+	}
+	if m, err := png.Decode(bytes.NewReader(data)); err != nil || m.Bounds().Dx() != 32 || m.Bounds().Dy() != 32 {
+		t.Errorf("variant = %v, %v", m.Bounds(), err)
+	}
+
+	// a cached variant takes a single request, the original is remembered
+	before = fake.count()
+	im, err = p.EnsureImage(ctx, res, "1001", v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached, err := p.Read(ctx, im); err != nil || !bytes.Equal(cached, data) {
+		t.Errorf("Read = %d bytes, %v", len(cached), err)
+	}
+	if n := fake.count() - before; n != 1 || fetched.Load() != 1 {
+		t.Errorf("cached variant took %d requests and %d downloads, want 1 and 1", n, fetched.Load())
+	}
+
+	// another pipeline on the same bucket reads the original from there
+	other := NewPipeline(store, DefaultOptions)
+	before = fake.count()
+	if _, err := other.EnsureImage(ctx, res, "1001", v); err != nil {
+		t.Fatal(err)
+	}
+	if n := fake.count() - before; n != 2 || fetched.Load() != 1 {
+		t.Errorf("cached variant took %d requests and %d downloads, want 2 and 1", n, fetched.Load())
+	}
+
+	// a new version upstream replaces the original and its variants
+	res.version = "01.03.2025"
+	if im, err = p.EnsureImage(ctx, res, "1001", v); err != nil || im.Original.Version != "01.03.2025" || fetched.Load() != 2 {
+		t.Errorf("image = %+v, %v after %d downloads", im.Original, err, fetched.Load())
+	}
+}
+
+func TestEnsureImagePresigned(t *testing.T) {
+	store, fake := newTestS3Store(t)
+	url, _ := upstream(t)
+	p := NewPipeline(store, Options{Presign: 10 * time.Minute})
+	ctx := context.Background()
+	res := photo{url: url}
+	v := Variant{Format: FormatPNG}
+
+	if _, err := p.EnsureImage(ctx, res, "1001", v); err != nil {
+		t.Fatal(err)
+	}
+
+	// presigned variants are only looked up, not read
+	before := fake.count()
+	im, err := p.EnsureImage(ctx, res, "1001", v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake.requests[len(fake.requests)-1] != "HEAD /photos/img/1001.png" || fake.count()-before != 1 {
+		t.Errorf("requests = %v", fake.requests[before:])
+	}
+	if u, _, err := p.Presign(ctx, im); err != nil || u == nil || u.Path != "/photos/img/1001.png" {
+		t.Errorf("Presign = %v, %v", u, err)
+	}
+}
+
+func TestImageETagAndCopyright(t *testing.T) {
+	store, err := NewFileStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	url, _ := upstream(t)
+	p := NewPipeline(store, DefaultOptions)
+	ctx := context.Background()
+	v := Variant{Format: FormatPNG}
+
+	im, err := p.EnsureImage(ctx, photo{url: url, version: "1"}, "1001", v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Attribute(ctx, "1001", im.Original, "Büro Müller"); err != nil {
+		t.Fatal(err)
+	}
+
+	// both survive a restart
+	restarted := NewPipeline(store, DefaultOptions)
+	cached, err := restarted.EnsureImage(ctx, photo{url: url, version: "1"}, "1001", v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached.ETag != im.ETag || cached.Original.Copyright == nil || *cached.Original.Copyright != "Büro Müller" {
+		t.Errorf("cached image = %+v, want ETag %s and the copyright", cached, im.ETag)
+	}
+
+	// a new image upstream is a new variant, without copyright until looked up again
+	changed, err := restarted.EnsureImage(ctx, photo{url: url, version: "2"}, "1001", v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed.ETag == im.ETag || changed.Original.Copyright != nil {
+		t.Errorf("changed image = %+v", changed)
+	}
+	// attributing the old image doesn't overwrite the new one
+	if err := restarted.Attribute(ctx, "1001", cached.Original, "stale"); err != nil {
+		t.Fatal(err)
+	}
+	if orig, _ := restarted.cachedOriginal(ctx, "1001"); orig.Version != "2" || orig.Copyright != nil {
+		t.Errorf("description of the new image = %+v", orig)
+	}
+}
@@ -0,0 +1,127 @@
+package img
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	_ "golang.org/x/image/webp"
+)
+
+// Format is an output format of the pipeline. AVIF and progressive JPEG are
+// not supported, there is no pure-Go encoder for either.
+type Format string
+
+const (
+	FormatWebP Format = "webp"
+	FormatJPEG Format = "jpeg"
+	FormatPNG  Format = "png"
+)
+
+func (f Format) MediaType() string {
+	return "image/" + string(f)
+}
+
+// ParseFormat accepts the format names and their media types.
+func ParseFormat(s string) (Format, error) {
+	for _, f := range []Format{FormatWebP, FormatJPEG, FormatPNG} {
+		if s == string(f) || s == f.MediaType() {
+			return f, nil
+		}
+	}
+	if s == "jpg" {
+		return FormatJPEG, nil
+	}
+	return "", fmt.Errorf("unsupported image format %q", s)
+}
+
+var (
+	// ErrNoImage is returned for resources without a photo.
+	ErrNoImage = errors.New("no image")
+	// ErrUpstream is returned when the photo cannot be fetched.
+	ErrUpstream = errors.New("upstream unavailable")
+)
+
+// Options are the encoder settings, qualities range from 0 to 100. A WebP
+// quality of 100 is lossless.
+type Options struct {
+	WebPQuality int
+	JPEGQuality int
+	// Presign lets clients download images from stores that support it,
+	// with URLs valid this long. Images are served by the API if it's 0.
+	Presign time.Duration
+}
+
+var DefaultOptions = Options{WebPQuality: 75, JPEGQuality: 85}
+
+// Pipeline fetches, decodes and encodes images in process and caches them
+// in a store. It decodes JPEG, PNG and WebP.
+type Pipeline struct {
+	store  Store
+	opts   Options
+	client *http.Client
+
+	mu        sync.Mutex
+	originals map[string]Original
+}
+
+func NewPipeline(store Store, opts Options) *Pipeline {
+	return &Pipeline{
+		store:     store,
+		opts:      opts,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		originals: map[string]Original{},
+	}
+}
+
+// Fetch downloads the image at url.
+func (p *Pipeline) Fetch(url string) ([]byte, error) {
+	if url == "" {
+		return nil, ErrNoImage
+	}
+	resp, err := p.client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUpstream, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s returned %s", ErrUpstream, url, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUpstream, err)
+	}
+	return data, nil
+}
+
+func (p *Pipeline) Decode(r io.Reader) (image.Image, error) {
+	m, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return m, nil
+}
+
+func (p *Pipeline) Encode(w io.Writer, m image.Image, f Format) error {
+	var err error
+	switch f {
+	case FormatWebP:
+		err = encodeWebP(w, m, p.opts.WebPQuality)
+	case FormatJPEG:
+		err = jpeg.Encode(w, m, &jpeg.Options{Quality: p.opts.JPEGQuality})
+	case FormatPNG:
+		err = png.Encode(w, m)
+	default:
+		err = fmt.Errorf("unsupported image format %q", f)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", f, err)
+	}
+	return nil
+}
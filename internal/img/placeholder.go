@@ -0,0 +1,146 @@
+package img
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+// placeholders are computed from a thumbnail, the detail is lost anyway
+const thumbnailSize = 64
+
+// thumbnail scales m to fit into thumbnailSize.
+func thumbnail(m image.Image) *image.RGBA {
+	b := m.Bounds()
+	scale := min(1, float64(thumbnailSize)/float64(max(b.Dx(), b.Dy())))
+	w, h := max(int(float64(b.Dx())*scale+0.5), 1), max(int(float64(b.Dy())*scale+0.5), 1)
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.ApproxBiLinear.Scale(dst, dst.Bounds(), m, b, draw.Src, nil)
+	return dst
+}
+
+const base83 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// blurHash encodes m as described at https://github.com/woltapp/blurhash,
+// with 4x3 components for landscape and 3x4 for portrait images.
+func blurHash(m *image.RGBA) string {
+	w, h := m.Rect.Dx(), m.Rect.Dy()
+	cx, cy := 4, 3
+	if h > w {
+		cx, cy = 3, 4
+	}
+
+	// linear RGB of every pixel, so the basis functions don't convert again
+	lin := make([][3]float64, w*h)
+	for y := range h {
+		for x := range w {
+			i := m.PixOffset(x, y)
+			lin[y*w+x] = [3]float64{srgbToLinear(m.Pix[i]), srgbToLinear(m.Pix[i+1]), srgbToLinear(m.Pix[i+2])}
+		}
+	}
+
+	factors := make([][3]float64, 0, cx*cy)
+	for j := range cy {
+		for i := range cx {
+			var f [3]float64
+			for y := range h {
+				for x := range w {
+					basis := math.Cos(math.Pi*float64(i*x)/float64(w)) * math.Cos(math.Pi*float64(j*y)/float64(h))
+					for c := range 3 {
+						f[c] += basis * lin[y*w+x][c]
+					}
+				}
+			}
+			scale := 2 / float64(w*h)
+			if i == 0 && j == 0 {
+				scale = 1 / float64(w*h)
+			}
+			for c := range 3 {
+				f[c] *= scale
+			}
+			factors = append(factors, f)
+		}
+	}
+
+	var sb strings.Builder
+	encode83(&sb, (cx-1)+(cy-1)*9, 1)
+
+	dc, ac := factors[0], factors[1:]
+	maxAC := 1.0
+	if len(ac) > 0 {
+		actual := 0.0
+		for _, f := range ac {
+			actual = max(actual, math.Abs(f[0]), math.Abs(f[1]), math.Abs(f[2]))
+		}
+		quantised := max(0, min(82, int(math.Floor(actual*166-0.5))))
+		maxAC = float64(quantised+1) / 166
+		encode83(&sb, quantised, 1)
+	} else {
+		encode83(&sb, 0, 1)
+	}
+
+	encode83(&sb, linearToSRGB(dc[0])<<16|linearToSRGB(dc[1])<<8|linearToSRGB(dc[2]), 4)
+	for _, f := range ac {
+		q := func(v float64) int {
+			return max(0, min(18, int(math.Floor(signPow(v/maxAC, 0.5)*9+9.5))))
+		}
+		encode83(&sb, q(f[0])*19*19+q(f[1])*19+q(f[2]), 2)
+	}
+	return sb.String()
+}
+
+func encode83(sb *strings.Builder, n, length int) {
+	for i := 1; i <= length; i++ {
+		digit := n / int(math.Pow(83, float64(length-i))) % 83
+		sb.WriteByte(base83[digit])
+	}
+}
+
+func srgbToLinear(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) int {
+	v = max(0, min(1, v))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(v, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(v), exp), v)
+}
+
+// dominantColor is the average of the most common colors of m, counted in
+// buckets of 4 bits per channel, as hex triplet like "#1a2b3c".
+func dominantColor(m *image.RGBA) string {
+	type bucket struct {
+		n       int
+		r, g, b int
+	}
+	var buckets [4096]bucket
+	best := 0
+	for i := 0; i+3 < len(m.Pix); i += 4 {
+		r, g, b := int(m.Pix[i]), int(m.Pix[i+1]), int(m.Pix[i+2])
+		k := r>>4<<8 | g>>4<<4 | b>>4
+		bk := &buckets[k]
+		bk.n++
+		bk.r, bk.g, bk.b = bk.r+r, bk.g+g, bk.b+b
+		if bk.n > buckets[best].n {
+			best = k
+		}
+	}
+	bk := buckets[best]
+	if bk.n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("#%02x%02x%02x", bk.r/bk.n, bk.g/bk.n, bk.b/bk.n)
+}
@@ -0,0 +1,68 @@
+package img
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"testing"
+)
+
+func filled(w, h int, c color.RGBA) *image.RGBA {
+	m := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(m, m.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+	return m
+}
+
+func TestBlurHash(t *testing.T) {
+	black, white := color.RGBA{0, 0, 0, 255}, color.RGBA{255, 255, 255, 255}
+	// the left pixel black, the right one white
+	split := filled(2, 1, black)
+	split.SetRGBA(1, 0, white)
+
+	// the hashes follow from the reference encoder of woltapp/blurhash, which
+	// samples cos(πix/w) without the half pixel offset of a DCT: black has no
+	// AC components, which encode as fQ, but a uniform white image has some
+	tests := []struct {
+		name string
+		m    *image.RGBA
+		want string
+	}{
+		{"black", filled(8, 6, black), "L00000" + strings.Repeat("fQ", 11)},
+		{"black portrait", filled(6, 8, black), "T00000" + strings.Repeat("fQ", 11)},
+		{"white", filled(8, 6, white), "LsTSUA_3fQ_3~qt7fQt7fQfQfQfQ"},
+		{"split", split, "L~Lqe9fQ00fQ~qfQ00fQ~qfQ00fQ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blurHash(tt.m); got != tt.want {
+				t.Errorf("blurHash = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDominantColor(t *testing.T) {
+	red := filled(4, 4, color.RGBA{200, 0, 0, 255})
+	// in the same bucket as the other reds
+	red.SetRGBA(0, 0, color.RGBA{207, 0, 0, 255})
+	red.SetRGBA(1, 0, color.RGBA{207, 0, 0, 255})
+	red.SetRGBA(0, 1, color.RGBA{0, 0, 255, 255})
+
+	tests := []struct {
+		name string
+		m    *image.RGBA
+		want string
+	}{
+		{"uniform", filled(3, 3, color.RGBA{0x1a, 0x2b, 0x3c, 255}), "#1a2b3c"},
+		{"averaged within the most common bucket", red, "#c80000"},
+		{"empty", image.NewRGBA(image.Rect(0, 0, 0, 0)), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dominantColor(tt.m); got != tt.want {
+				t.Errorf("dominantColor = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
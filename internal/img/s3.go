@@ -0,0 +1,135 @@
+package img
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Options address a bucket of an S3-compatible service, e.g. MinIO for
+// local tests.
+type S3Options struct {
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+	// Prefix is prepended to the names of the images, e.g. "img/".
+	Prefix   string
+	Insecure bool
+}
+
+// S3Store keeps images in a bucket, so they survive restarts and are shared
+// by all instances. Eviction is left to the lifecycle rules of the bucket.
+type S3Store struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Store connects to the bucket, creating it if it doesn't exist yet.
+// Without keys the credentials are taken from the environment, e.g.
+// AWS_ACCESS_KEY_ID, or the instance.
+func NewS3Store(ctx context.Context, opts S3Options) (*S3Store, error) {
+	creds := credentials.NewStaticV4(opts.AccessKey, opts.SecretKey, "")
+	if opts.AccessKey == "" {
+		creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.EnvMinio{},
+			&credentials.IAM{},
+		})
+	}
+	client, err := minio.New(opts.Endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: !opts.Insecure,
+		Region: opts.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client: %w", err)
+	}
+
+	exists, err := client.BucketExists(ctx, opts.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bucket %s: %w", opts.Bucket, err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, opts.Bucket, minio.MakeBucketOptions{Region: opts.Region}); err != nil {
+			return nil, fmt.Errorf("failed to create bucket %s: %w", opts.Bucket, err)
+		}
+	}
+	return &S3Store{client: client, bucket: opts.Bucket, prefix: opts.Prefix}, nil
+}
+
+func (s *S3Store) Get(ctx context.Context, name string) ([]byte, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, s.prefix+name, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s.error(name, err)
+	}
+	defer obj.Close()
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		return nil, s.error(name, err)
+	}
+	return data, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, name string, data []byte) error {
+	_, err := s.client.PutObject(ctx, s.bucket, s.prefix+name, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: mime.TypeByExtension(path.Ext(name)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+	return nil
+}
+
+func (s *S3Store) Exists(ctx context.Context, name string) (bool, error) {
+	_, err := s.client.StatObject(ctx, s.bucket, s.prefix+name, minio.StatObjectOptions{})
+	if err == nil {
+		return true, nil
+	}
+	if err = s.error(name, err); errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (s *S3Store) Remove(ctx context.Context, prefix string) error {
+	objects := s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: s.prefix + prefix})
+	var err error
+	// drained to the end, RemoveObjects stops only then
+	for e := range s.client.RemoveObjects(ctx, s.bucket, objects, minio.RemoveObjectsOptions{}) {
+		if err == nil {
+			err = fmt.Errorf("failed to remove image %s: %w", e.ObjectName, e.Err)
+		}
+	}
+	return err
+}
+
+// Presign returns a URL to download name without credentials until expiry.
+func (s *S3Store) Presign(ctx context.Context, name string, expiry time.Duration) (*url.URL, error) {
+	u, err := s.client.PresignedGetObject(ctx, s.bucket, s.prefix+name, expiry, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign image: %w", err)
+	}
+	return u, nil
+}
+
+// error turns missing objects into fs.ErrNotExist, like the FileStore
+// reports them.
+func (s *S3Store) error(name string, err error) error {
+	if minio.ToErrorResponse(err).Code == minio.NoSuchKey {
+		return fmt.Errorf("image %s: %w", name, fs.ErrNotExist)
+	}
+	return fmt.Errorf("failed to read image %s: %w", name, err)
+}
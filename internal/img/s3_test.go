@@ -0,0 +1,218 @@
+package img
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 serves the part of the S3 API the store uses, path-style, without
+// checking signatures.
+type fakeS3 struct {
+	mu       sync.Mutex
+	buckets  map[string]bool
+	objects  map[string][]byte // by bucket/key
+	requests []string          // method and path of every request
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{buckets: map[string]bool{}, objects: map[string][]byte{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeS3) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.requests)
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	q := r.URL.Query()
+	switch {
+	case key == "" && r.Method == http.MethodHead:
+		if !f.buckets[bucket] {
+			s3Error(w, http.StatusNotFound, "NoSuchBucket")
+		}
+	case key == "" && r.Method == http.MethodPut:
+		f.buckets[bucket] = true
+	case key == "" && r.Method == http.MethodGet && q.Get("list-type") == "2":
+		f.list(w, bucket, q.Get("prefix"))
+	case key == "" && r.Method == http.MethodPost && q.Has("delete"):
+		f.delete(w, r, bucket)
+	case r.Method == http.MethodPut:
+		data, err := readPayload(r)
+		if err != nil {
+			s3Error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		f.objects[bucket+"/"+key] = data
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		data, ok := f.objects[bucket+"/"+key]
+		if !ok {
+			s3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	default:
+		s3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, bucket, prefix string) {
+	type object struct {
+		Key  string `xml:"Key"`
+		Size int    `xml:"Size"`
+	}
+	result := struct {
+		XMLName  xml.Name `xml:"ListBucketResult"`
+		Name     string   `xml:"Name"`
+		Prefix   string   `xml:"Prefix"`
+		KeyCount int      `xml:"KeyCount"`
+		Contents []object `xml:"Contents"`
+	}{Name: bucket, Prefix: prefix}
+	for k, data := range f.objects {
+		if key, ok := strings.CutPrefix(k, bucket+"/"); ok && strings.HasPrefix(key, prefix) {
+			result.Contents = append(result.Contents, object{key, len(data)})
+		}
+	}
+	slices.SortFunc(result.Contents, func(a, b object) int { return strings.Compare(a.Key, b.Key) })
+	result.KeyCount = len(result.Contents)
+	xml.NewEncoder(w).Encode(result)
+}
+
+func (f *fakeS3) delete(w http.ResponseWriter, r *http.Request, bucket string) {
+	var req struct {
+		Objects []struct {
+			Key string `xml:"Key"`
+		} `xml:"Object"`
+	}
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+		s3Error(w, http.StatusBadRequest, "MalformedXML")
+		return
+	}
+	fmt.Fprint(w, `<DeleteResult>`)
+	for _, o := range req.Objects {
+		delete(f.objects, bucket+"/"+o.Key)
+		fmt.Fprintf(w, `<Deleted><Key>%s</Key></Deleted>`, o.Key)
+	}
+	fmt.Fprint(w, `</DeleteResult>`)
+}
+
+// readPayload decodes the aws-chunked bodies the client sends over plain
+// HTTP.
+func readPayload(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+	var data []byte
+	br := bufio.NewReader(r.Body)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return data, nil
+		}
+		chunk := make([]byte, size+2) // with the CRLF
+		if _, err := io.ReadFull(br, chunk); err != nil {
+			return nil, err
+		}
+		data = append(data, chunk[:size]...)
+	}
+}
+
+func s3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+}
+
+func newTestS3Store(t *testing.T) (*S3Store, *fakeS3) {
+	t.Helper()
+	fake, srv := newFakeS3(t)
+	u, _ := url.Parse(srv.URL)
+	store, err := NewS3Store(context.Background(), S3Options{
+		Endpoint: u.Host, Bucket: "photos", Region: "us-east-1",
+		AccessKey: "key", SecretKey: "secret", Prefix: "img/", Insecure: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store, fake
+}
+
+func TestS3Store(t *testing.T) {
+	store, fake := newTestS3Store(t)
+	ctx := context.Background()
+
+	if !fake.buckets["photos"] {
+		t.Fatal("bucket wasn't created")
+	}
+
+	for name, data := range map[string]string{"1001.orig": "original", "1001-48x48-cover.webp": "variant", "1002.webp": "other"} {
+		if err := store.Put(ctx, name, []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := fake.objects["photos/img/1001.orig"]; !ok {
+		t.Error("prefix isn't applied")
+	}
+
+	data, err := store.Get(ctx, "1001-48x48-cover.webp")
+	if err != nil || string(data) != "variant" {
+		t.Errorf("Get = %q, %v", data, err)
+	}
+	if _, err := store.Get(ctx, "missing.webp"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Get of a missing image = %v, want fs.ErrNotExist", err)
+	}
+	if ok, err := store.Exists(ctx, "1002.webp"); !ok || err != nil {
+		t.Errorf("Exists = %v, %v", ok, err)
+	}
+	if ok, err := store.Exists(ctx, "missing.webp"); ok || err != nil {
+		t.Errorf("Exists of a missing image = %v, %v", ok, err)
+	}
+
+	if err := store.Remove(ctx, "1001"); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.objects) != 1 || fake.objects["photos/img/1002.webp"] == nil {
+		t.Errorf("objects after Remove = %v", fake.objects)
+	}
+
+	u, err := store.Presign(ctx, "1002.webp", time.Minute)
+	if err != nil || u.Path != "/photos/img/1002.webp" || u.Query().Get("X-Amz-Expires") != "60" {
+		t.Errorf("Presign = %v, %v", u, err)
+	}
+}
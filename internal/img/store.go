@@ -0,0 +1,187 @@
+package img
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Store keeps the cached images and their originals, by names like
+// "1001-48x48-cover.webp".
+type Store interface {
+	// Get returns an error matching fs.ErrNotExist if name isn't stored.
+	Get(ctx context.Context, name string) ([]byte, error)
+	Put(ctx context.Context, name string, data []byte) error
+	Exists(ctx context.Context, name string) (bool, error)
+	// Remove deletes every image whose name starts with prefix.
+	Remove(ctx context.Context, prefix string) error
+}
+
+// Presigner is implemented by stores clients can download from directly.
+type Presigner interface {
+	Presign(ctx context.Context, name string, expiry time.Duration) (*url.URL, error)
+}
+
+// FileStore keeps images in a local directory. Once they take more than the
+// size cap, the least recently used are removed.
+type FileStore struct {
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	size  int64
+	used  *list.List // of fileEntry, most recently used first
+	index map[string]*list.Element
+}
+
+type fileEntry struct {
+	name    string
+	size    int64
+	touched time.Time
+}
+
+// touchEvery is how often the modification time of a file is updated when
+// it's read, which keeps the order of use across restarts.
+const touchEvery = time.Hour
+
+// NewFileStore opens dir, creating it if necessary. Files already there count
+// towards maxBytes, the last modified as the most recently used. A maxBytes
+// of 0 doesn't limit the size.
+func NewFileStore(dir string, maxBytes int64) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create image dir: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image dir: %w", err)
+	}
+
+	var files []fs.FileInfo
+	for _, e := range entries {
+		if fi, err := e.Info(); err == nil && fi.Mode().IsRegular() && !strings.HasSuffix(e.Name(), ".tmp") {
+			files = append(files, fi)
+		}
+	}
+	slices.SortFunc(files, func(a, b fs.FileInfo) int { return a.ModTime().Compare(b.ModTime()) })
+
+	s := &FileStore{dir: dir, maxBytes: maxBytes, used: list.New(), index: map[string]*list.Element{}}
+	for _, fi := range files {
+		s.index[fi.Name()] = s.used.PushFront(fileEntry{fi.Name(), fi.Size(), fi.ModTime()})
+		s.size += fi.Size()
+	}
+	s.evict()
+	return s, nil
+}
+
+func (s *FileStore) Get(ctx context.Context, name string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(name))
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.index[name]; ok {
+		s.used.MoveToFront(e)
+		if entry := e.Value.(fileEntry); time.Since(entry.touched) > touchEvery {
+			entry.touched = time.Now()
+			_ = os.Chtimes(s.path(name), entry.touched, entry.touched)
+			e.Value = entry
+		}
+	}
+	return data, nil
+}
+
+// Put writes data next to its name first, so readers never see a partial
+// file.
+func (s *FileStore) Put(ctx context.Context, name string, data []byte) error {
+	tmpFile, err := os.CreateTemp(s.dir, name+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp image file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(data)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpFile.Name(), 0644)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+	if err := os.Rename(tmpFile.Name(), s.path(name)); err != nil {
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.forget(name)
+	s.index[name] = s.used.PushFront(fileEntry{name, int64(len(data)), time.Now()})
+	s.size += int64(len(data))
+	s.evict()
+	return nil
+}
+
+func (s *FileStore) Exists(ctx context.Context, name string) (bool, error) {
+	_, err := os.Stat(s.path(name))
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (s *FileStore) Remove(ctx context.Context, prefix string) error {
+	paths, err := filepath.Glob(s.path(prefix) + "*")
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, path := range paths {
+		if strings.HasSuffix(path, ".tmp") {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove image: %w", err)
+		}
+		s.forget(filepath.Base(path))
+	}
+	return nil
+}
+
+func (s *FileStore) path(name string) string {
+	return filepath.Join(s.dir, filepath.Base(name))
+}
+
+// forget drops name from the index, s.mu must be held.
+func (s *FileStore) forget(name string) {
+	if e, ok := s.index[name]; ok {
+		s.size -= s.used.Remove(e).(fileEntry).size
+		delete(s.index, name)
+	}
+}
+
+// evict removes the least recently used images until they fit into the cap,
+// but keeps the latest, which may be about to be served. s.mu must be held.
+func (s *FileStore) evict() {
+	for s.maxBytes > 0 && s.size > s.maxBytes && s.used.Len() > 1 {
+		name := s.used.Back().Value.(fileEntry).name
+		if err := os.Remove(s.path(name)); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("failed to evict image %s: %v\n", name, err)
+			return
+		}
+		s.forget(name)
+	}
+}
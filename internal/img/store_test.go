@@ -0,0 +1,91 @@
+package img
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	s, err := NewFileStore(dir, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a.webp", "b.webp", "c.webp"} {
+		if err := s.Put(ctx, name, []byte("1234")); err != nil {
+			t.Fatal(err)
+		}
+		// reads keep a from being evicted
+		if _, err := s.Get(ctx, "a.webp"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ok, _ := s.Exists(ctx, "b.webp"); ok {
+		t.Error("least recently used image wasn't evicted")
+	}
+	for _, name := range []string{"a.webp", "c.webp"} {
+		if ok, _ := s.Exists(ctx, name); !ok {
+			t.Errorf("%s was evicted", name)
+		}
+	}
+	if _, err := s.Get(ctx, "b.webp"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Get of an evicted image = %v, want fs.ErrNotExist", err)
+	}
+
+	if err := s.Remove(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.Exists(ctx, "a.webp"); ok || s.size != 4 {
+		t.Errorf("a.webp exists after Remove, size %d", s.size)
+	}
+
+	// the files count after a restart
+	s, err = NewFileStore(dir, 10)
+	if err != nil || s.size != 4 || s.used.Len() != 1 {
+		t.Errorf("reopened store = %d bytes in %d files, %v", s.size, s.used.Len(), err)
+	}
+}
+
+func TestFileStoreTouch(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	path := filepath.Join(dir, "a.webp")
+	if err := os.WriteFile(path, []byte("1234"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * touchEvery)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewFileStore(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(ctx, "a.webp"); err != nil {
+		t.Fatal(err)
+	}
+	fi, _ := os.Stat(path)
+	touched := fi.ModTime()
+	if time.Since(touched) > time.Minute {
+		t.Errorf("modification time %v wasn't updated", touched)
+	}
+
+	// not again within touchEvery
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(ctx, "a.webp"); err != nil {
+		t.Fatal(err)
+	}
+	if fi, _ := os.Stat(path); !fi.ModTime().Equal(old) {
+		t.Errorf("modification time updated on every read")
+	}
+}
@@ -0,0 +1,215 @@
+package img
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/muesli/smartcrop"
+	"golang.org/x/image/draw"
+)
+
+var analyzer = smartcrop.NewAnalyzer(resizer{})
+
+// resizer lets smartcrop scale with x/image/draw. Like the resizer it comes
+// with, it keeps the aspect ratio if width or height is 0.
+type resizer struct{}
+
+func (resizer) Resize(m image.Image, width, height uint) image.Image {
+	return Resize(m, Variant{Width: int(width), Height: int(height), Fit: FitContain})
+}
+
+// Fit is how a photo is fitted into a box of the requested width and height.
+type Fit string
+
+const (
+	// FitCover fills the box and crops what exceeds it, centered.
+	FitCover Fit = "cover"
+	// FitContain scales the photo to fit into the box, the result may be
+	// smaller than the box in one dimension.
+	FitContain Fit = "contain"
+	// FitSmart fills the box like cover, but crops around the content, e.g.
+	// the face in a portrait.
+	FitSmart Fit = "smart"
+)
+
+var ErrInvalidVariant = errors.New("invalid image variant")
+
+// Variant is a rendition of a photo. Without width and height it keeps the
+// size of the original, with only one of them the aspect ratio.
+type Variant struct {
+	Width  int
+	Height int
+	Fit    Fit
+	Format Format
+}
+
+func (v Variant) resized() bool {
+	return v.Width > 0 || v.Height > 0
+}
+
+// name is the file name of the variant of the photo of id in the cache.
+func (v Variant) name(id string) string {
+	switch {
+	case !v.resized():
+		return fmt.Sprintf("%s.%s", id, v.Format)
+	case v.Width == 0 || v.Height == 0:
+		// fit only matters for boxes
+		return fmt.Sprintf("%s-%dx%d.%s", id, v.Width, v.Height, v.Format)
+	}
+	return fmt.Sprintf("%s-%dx%d-%s.%s", id, v.Width, v.Height, v.Fit, v.Format)
+}
+
+// ParseVariant reads the variant from the parameters w, h, fit and format of
+// query. Widths and heights are limited to sizes, the fit defaults to cover.
+// The format is left empty if it isn't given.
+func ParseVariant(query url.Values, sizes []int) (Variant, error) {
+	v := Variant{Fit: FitCover}
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{{"w", &v.Width}, {"h", &v.Height}} {
+		s := query.Get(p.name)
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || !slices.Contains(sizes, n) {
+			return Variant{}, fmt.Errorf("%w: %s must be one of %v", ErrInvalidVariant, p.name, sizes)
+		}
+		*p.dst = n
+	}
+
+	switch fit := Fit(query.Get("fit")); fit {
+	case "":
+	case FitCover, FitContain, FitSmart:
+		v.Fit = fit
+	default:
+		return Variant{}, fmt.Errorf("%w: fit must be %s, %s or %s", ErrInvalidVariant, FitCover, FitContain, FitSmart)
+	}
+
+	if s := query.Get("format"); s != "" {
+		f, err := ParseFormat(s)
+		if err != nil {
+			return Variant{}, fmt.Errorf("%w: %w", ErrInvalidVariant, err)
+		}
+		v.Format = f
+	}
+	return v, nil
+}
+
+// skin tones are colors close to this direction in RGB, as smartcrop detects
+// them
+var (
+	skin          = [3]float64{0.78, 0.57, 0.44}
+	skinThreshold = 0.2
+)
+
+// smartCrop finds the part of m with the aspect ratio of width and height
+// that smartcrop rates best, and moves it to centre on the densest skin tones
+// in it, i.e. the face of a portrait.
+func smartCrop(m image.Image, width, height int) (image.Rectangle, bool) {
+	crop, err := analyzer.FindBestCrop(m, width, height)
+	if err != nil || crop.Empty() {
+		return image.Rectangle{}, false
+	}
+	b := m.Bounds()
+	crop = crop.Add(b.Min)
+
+	// summed skin weights of the thumbnail, sum[y][x] covers everything above
+	// and left of (x, y)
+	thumb := thumbnail(m)
+	tw, th := thumb.Rect.Dx(), thumb.Rect.Dy()
+	sum := make([][]float64, th+1)
+	sum[0] = make([]float64, tw+1)
+	for y := range th {
+		sum[y+1] = make([]float64, tw+1)
+		for x := range tw {
+			sum[y+1][x+1] = sum[y][x+1] + sum[y+1][x] - sum[y][x] + skinWeight(thumb.RGBAAt(x, y))
+		}
+	}
+
+	// a face takes about half of an avatar
+	scale := float64(b.Dx()) / float64(tw)
+	fw, fh := max(int(float64(crop.Dx())/scale/2), 1), max(int(float64(crop.Dy())/scale/2), 1)
+	best, bx, by := 0.0, 0, 0
+	for y := 0; y+fh <= th; y++ {
+		for x := 0; x+fw <= tw; x++ {
+			if s := sum[y+fh][x+fw] - sum[y][x+fw] - sum[y+fh][x] + sum[y][x]; s > best {
+				best, bx, by = s, x, y
+			}
+		}
+	}
+	// too little skin to tell a face
+	if best < 0.25*float64(fw*fh) {
+		return crop, true
+	}
+
+	// the centre of the skin in the window
+	var sx, sy, sw float64
+	for y := by; y < by+fh; y++ {
+		for x := bx; x < bx+fw; x++ {
+			w := skinWeight(thumb.RGBAAt(x, y))
+			sx, sy, sw = sx+w*(float64(x)+0.5), sy+w*(float64(y)+0.5), sw+w
+		}
+	}
+	cx, cy := b.Min.X+int(sx/sw*scale), b.Min.Y+int(sy/sw*scale)
+	crop = crop.Add(image.Pt(cx-(crop.Min.X+crop.Max.X)/2, cy-(crop.Min.Y+crop.Max.Y)/2))
+	// back into the image, the crop is never larger than it
+	crop = crop.Add(image.Pt(max(b.Min.X-crop.Min.X, 0), max(b.Min.Y-crop.Min.Y, 0)))
+	crop = crop.Sub(image.Pt(max(crop.Max.X-b.Max.X, 0), max(crop.Max.Y-b.Max.Y, 0)))
+	return crop, true
+}
+
+// skinWeight rates how close c is to skin tones, from 0 to 1.
+func skinWeight(c color.RGBA) float64 {
+	r, g, b := float64(c.R), float64(c.G), float64(c.B)
+	mag := math.Sqrt(r*r + g*g + b*b)
+	// too dark to tell
+	if mag < 40 {
+		return 0
+	}
+	d := math.Sqrt(math.Pow(r/mag-skin[0], 2) + math.Pow(g/mag-skin[1], 2) + math.Pow(b/mag-skin[2], 2))
+	return max(0, 1-d/skinThreshold)
+}
+
+// Resize scales m to the size of v.
+func Resize(m image.Image, v Variant) image.Image {
+	b := m.Bounds()
+	sw, sh := float64(b.Dx()), float64(b.Dy())
+	w, h := float64(v.Width), float64(v.Height)
+	src := b
+
+	switch {
+	case !v.resized():
+		return m
+	case w == 0:
+		w = sw * h / sh
+	case h == 0:
+		h = sh * w / sw
+	case v.Fit == FitContain:
+		scale := min(w/sw, h/sh)
+		w, h = sw*scale, sh*scale
+	case v.Fit == FitSmart:
+		if crop, ok := smartCrop(m, v.Width, v.Height); ok {
+			src = crop
+			break
+		}
+		fallthrough
+	default:
+		// crop the source to the aspect ratio of the box
+		scale := max(w/sw, h/sh)
+		cw, ch := int(w/scale+0.5), int(h/scale+0.5)
+		x, y := b.Min.X+(b.Dx()-cw)/2, b.Min.Y+(b.Dy()-ch)/2
+		src = image.Rect(x, y, x+cw, y+ch)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, max(int(w+0.5), 1), max(int(h+0.5), 1)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), m, src, draw.Src, nil)
+	return dst
+}
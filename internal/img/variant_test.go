@@ -0,0 +1,138 @@
+package img
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"net/url"
+	"testing"
+)
+
+func TestParseVariant(t *testing.T) {
+	sizes := []int{32, 64, 128}
+	tests := []struct {
+		query string
+		want  Variant
+		valid bool
+	}{
+		{"", Variant{Fit: FitCover}, true},
+		{"w=64", Variant{Width: 64, Fit: FitCover}, true},
+		{"w=64&h=32&fit=contain&format=png", Variant{Width: 64, Height: 32, Fit: FitContain, Format: FormatPNG}, true},
+		{"h=128&fit=smart&format=image/webp", Variant{Height: 128, Fit: FitSmart, Format: FormatWebP}, true},
+		{"w=jpg&format=jpg", Variant{}, false},
+		{"format=jpg", Variant{Fit: FitCover, Format: FormatJPEG}, true},
+		{"w=100", Variant{}, false},
+		{"h=0", Variant{}, false},
+		{"w=-32", Variant{}, false},
+		{"w=wide", Variant{}, false},
+		{"fit=fill", Variant{}, false},
+		{"fit=Cover", Variant{}, false},
+		{"format=gif", Variant{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			got, err := ParseVariant(query, sizes)
+			if tt.valid != (err == nil) || got != tt.want {
+				t.Errorf("ParseVariant = %+v, %v, want %+v", got, err, tt.want)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidVariant) {
+				t.Errorf("error %v isn't an ErrInvalidVariant", err)
+			}
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	landscape := filled(200, 100, color.RGBA{90, 120, 150, 255})
+	tests := []struct {
+		name string
+		v    Variant
+		w, h int
+	}{
+		{"original", Variant{}, 200, 100},
+		{"width", Variant{Width: 50}, 50, 25},
+		{"height", Variant{Height: 50}, 100, 50},
+		{"cover", Variant{Width: 64, Height: 64, Fit: FitCover}, 64, 64},
+		{"contain", Variant{Width: 64, Height: 64, Fit: FitContain}, 64, 32},
+		{"contain taller", Variant{Width: 32, Height: 128, Fit: FitContain}, 32, 16},
+		{"smart", Variant{Width: 64, Height: 32, Fit: FitSmart}, 64, 32},
+		{"upscaled", Variant{Width: 400, Height: 400, Fit: FitCover}, 400, 400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := Resize(landscape, tt.v).Bounds()
+			if b.Min != (image.Point{}) || b.Dx() != tt.w || b.Dy() != tt.h {
+				t.Errorf("bounds %v, want %dx%d", b, tt.w, tt.h)
+			}
+		})
+	}
+
+	// the source may not start at the origin
+	sub := filled(300, 300, color.RGBA{90, 120, 150, 255}).SubImage(image.Rect(100, 50, 300, 150))
+	if b := Resize(sub, Variant{Width: 64, Height: 64, Fit: FitCover}).Bounds(); b.Dx() != 64 || b.Dy() != 64 {
+		t.Errorf("bounds of a sub image %v, want 64x64", b)
+	}
+}
+
+func TestSmartCrop(t *testing.T) {
+	skinTone := color.RGBA{200, 146, 112, 255}
+	tests := []struct {
+		name string
+		face image.Rectangle
+	}{
+		{"centre", image.Rect(130, 70, 170, 130)},
+		// centring on these would move the crop out of the image
+		{"top left corner", image.Rect(0, 0, 40, 40)},
+		{"bottom right corner", image.Rect(260, 160, 300, 200)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := filled(300, 200, color.RGBA{20, 60, 140, 255})
+			draw.Draw(m, tt.face, image.NewUniform(skinTone), image.Point{}, draw.Src)
+
+			crop, ok := smartCrop(m, 100, 100)
+			if !ok {
+				t.Fatal("no crop")
+			}
+			if !crop.In(m.Bounds()) || crop.Dx() != crop.Dy() || crop.Empty() {
+				t.Fatalf("crop %v isn't a square within %v", crop, m.Bounds())
+			}
+			if !tt.face.Overlaps(crop) {
+				t.Errorf("crop %v misses the face at %v", crop, tt.face)
+			}
+		})
+	}
+
+	// the bounds of a sub image are kept
+	m := filled(400, 300, color.RGBA{20, 60, 140, 255})
+	draw.Draw(m, image.Rect(340, 240, 400, 300), image.NewUniform(skinTone), image.Point{}, draw.Src)
+	sub := m.SubImage(image.Rect(100, 100, 400, 300))
+	if crop, ok := smartCrop(sub, 100, 100); !ok || !crop.In(sub.Bounds()) {
+		t.Errorf("crop %v, %v, want one within %v", crop, ok, sub.Bounds())
+	}
+}
+
+func TestSkinWeight(t *testing.T) {
+	tests := []struct {
+		name string
+		c    color.RGBA
+		min  float64
+		max  float64
+	}{
+		// like smartcrop, skin isn't a unit vector, so no color reaches 1
+		{"skin", color.RGBA{199, 145, 112, 255}, 0.65, 0.7},
+		{"brighter skin", color.RGBA{240, 180, 140, 255}, 0.65, 0.7},
+		{"too dark", color.RGBA{20, 15, 10, 255}, 0, 0},
+		{"blue", color.RGBA{20, 60, 140, 255}, 0, 0},
+		{"grey", color.RGBA{128, 128, 128, 255}, 0, 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := skinWeight(tt.c); w < tt.min || w > tt.max {
+				t.Errorf("skinWeight = %v, want between %v and %v", w, tt.min, tt.max)
+			}
+		})
+	}
+}
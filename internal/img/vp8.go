@@ -0,0 +1,713 @@
+package img
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"math"
+)
+
+// A lossy WebP (VP8) encoder for key frames. Every macroblock is predicted as
+// a whole (16x16 luma, 8x8 chroma) with the best of the DC, TM, vertical and
+// horizontal modes; the 4x4 luma modes are not used. The token probabilities
+// are updated from the statistics of the frame.
+
+const (
+	predDC = iota
+	predTM
+	predVE
+	predHE
+	numPredModes
+)
+
+const (
+	planeYAfterY2 = iota
+	planeY2
+	planeUV
+)
+
+const maxLevel = 2047
+
+type quantizer struct {
+	dc, ac int32
+}
+
+// macroblock holds the modes and quantized levels in zigzag order of one
+// macroblock.
+type macroblock struct {
+	yMode, uvMode int
+	y2            [16]int32
+	y             [16][16]int32
+	uv            [8][16]int32 // U, then V
+}
+
+func (m *macroblock) skip() bool {
+	for _, b := range append([][16]int32{m.y2}, append(m.y[:], m.uv[:]...)...) {
+		if b != [16]int32{} {
+			return false
+		}
+	}
+	return true
+}
+
+type vp8Encoder struct {
+	mbw, mbh        int
+	y, u, v         []uint8 // padded source, replaced by its reconstruction
+	yStride         int
+	cStride         int
+	y1, y2, uvQuant quantizer
+	filterLevel     int
+	mbs             []macroblock
+}
+
+// encodeVP8 returns the VP8 frame of the opaque image m. quality from 0 to 99
+// picks the quantizer.
+func encodeVP8(m image.Image, quality int) ([]byte, error) {
+	b := m.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width == 0 || height == 0 || width > maxDimension || height > maxDimension {
+		return nil, errors.New("webp: image dimensions out of range")
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(rgba, rgba.Bounds(), m, b.Min, draw.Src)
+
+	qi := (100 - min(max(quality, 0), 100)) * 127 / 100
+	e := &vp8Encoder{
+		mbw:         (width + 15) / 16,
+		mbh:         (height + 15) / 16,
+		y1:          quantizer{int32(dcSteps[qi]), int32(acSteps[qi])},
+		y2:          quantizer{int32(dcSteps[qi]) * 2, max(int32(acSteps[qi])*155/100, 8)},
+		uvQuant:     quantizer{int32(dcSteps[min(qi, 117)]), int32(acSteps[qi])},
+		filterLevel: min(qi/2, 63),
+	}
+	e.toYUV(rgba)
+	e.mbs = make([]macroblock, e.mbw*e.mbh)
+	for mby := range e.mbh {
+		for mbx := range e.mbw {
+			e.encodeMacroblock(mbx, mby)
+		}
+	}
+	return e.frame(width, height, qi), nil
+}
+
+// toYUV converts to limited range BT.601 with 4:2:0 subsampling like libwebp
+// and repeats the last column and row to fill the macroblocks.
+func (e *vp8Encoder) toYUV(m *image.RGBA) {
+	w, h := m.Rect.Dx(), m.Rect.Dy()
+	e.yStride, e.cStride = e.mbw*16, e.mbw*8
+	e.y = make([]uint8, e.yStride*e.mbh*16)
+	e.u = make([]uint8, e.cStride*e.mbh*8)
+	e.v = make([]uint8, e.cStride*e.mbh*8)
+
+	rgb := func(x, y int) (int, int, int) {
+		i := m.PixOffset(min(x, w-1), min(y, h-1))
+		return int(m.Pix[i]), int(m.Pix[i+1]), int(m.Pix[i+2])
+	}
+	for y := range e.mbh * 16 {
+		for x := range e.yStride {
+			r, g, b := rgb(x, y)
+			e.y[y*e.yStride+x] = uint8((16839*r + 33059*g + 6420*b + 1<<15 + 16<<16) >> 16)
+		}
+	}
+	for y := range e.mbh * 8 {
+		for x := range e.cStride {
+			var r, g, b int
+			for _, d := range [][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+				r1, g1, b1 := rgb(2*x+d[0], 2*y+d[1])
+				r, g, b = r+r1, g+g1, b+b1
+			}
+			e.u[y*e.cStride+x] = clampUV(-9719*r - 19081*g + 28800*b)
+			e.v[y*e.cStride+x] = clampUV(28800*r - 24116*g - 4684*b)
+		}
+	}
+}
+
+func clampUV(v int) uint8 {
+	return uint8(clamp((v + 1<<17 + 128<<18) >> 18))
+}
+
+// edges returns the reconstructed row above and the column left of a block,
+// with the substitutes of section 12.2 outside of the frame.
+func edges(pix []uint8, stride, x, y, size int) (top, left []int32, topLeft int32) {
+	top, left = make([]int32, size), make([]int32, size)
+	for i := range size {
+		top[i], left[i] = 127, 129
+		if y > 0 {
+			top[i] = int32(pix[(y-1)*stride+x+i])
+		}
+		if x > 0 {
+			left[i] = int32(pix[(y+i)*stride+x-1])
+		}
+	}
+	switch {
+	case y == 0:
+		topLeft = 127
+	case x == 0:
+		topLeft = 129
+	default:
+		topLeft = int32(pix[(y-1)*stride+x-1])
+	}
+	return top, left, topLeft
+}
+
+// predictBlock fills a size x size block as the decoder does for mode.
+func predictBlock(pix []uint8, stride, x, y, size, mode int) []int32 {
+	top, left, topLeft := edges(pix, stride, x, y, size)
+	pred := make([]int32, size*size)
+	for j := range size {
+		for i := range size {
+			var p int32
+			switch mode {
+			case predDC:
+				p = dcPrediction(top, left, x > 0, y > 0)
+			case predTM:
+				p = int32(clamp(int(left[j] + top[i] - topLeft)))
+			case predVE:
+				p = top[i]
+			case predHE:
+				p = left[j]
+			}
+			pred[j*size+i] = p
+		}
+	}
+	return pred
+}
+
+func dcPrediction(top, left []int32, hasLeft, hasTop bool) int32 {
+	size := int32(len(top))
+	shift := int32(math.Log2(float64(size)))
+	var sum int32
+	switch {
+	case hasLeft && hasTop:
+		for i := range top {
+			sum += top[i] + left[i]
+		}
+		return (sum + size) >> (shift + 1)
+	case hasTop:
+		for _, v := range top {
+			sum += v
+		}
+	case hasLeft:
+		for _, v := range left {
+			sum += v
+		}
+	default:
+		return 128
+	}
+	return (sum + size/2) >> shift
+}
+
+// bestMode picks the mode with the smallest absolute difference to the source
+// over all planes of pix.
+func bestMode(planes [][]uint8, stride, x, y, size int) int {
+	best, bestCost := 0, int32(-1)
+	for mode := range numPredModes {
+		var cost int32
+		for _, pix := range planes {
+			pred := predictBlock(pix, stride, x, y, size, mode)
+			for j := range size {
+				for i := range size {
+					d := int32(pix[(y+j)*stride+x+i]) - pred[j*size+i]
+					cost += max(d, -d)
+				}
+			}
+		}
+		if bestCost < 0 || cost < bestCost {
+			best, bestCost = mode, cost
+		}
+	}
+	return best
+}
+
+func (e *vp8Encoder) encodeMacroblock(mbx, mby int) {
+	mb := &e.mbs[mby*e.mbw+mbx]
+
+	x, y := mbx*16, mby*16
+	mb.yMode = bestMode([][]uint8{e.y}, e.yStride, x, y, 16)
+	pred := predictBlock(e.y, e.yStride, x, y, 16, mb.yMode)
+	var coeffs [16][16]int32
+	var dcs [16]int32
+	for n := range 16 {
+		bx, by := x+n%4*4, y+n/4*4
+		coeffs[n] = fdct(residual(e.y, e.yStride, bx, by, pred[(n/4*4)*16+n%4*4:], 16))
+		dcs[n] = coeffs[n][0]
+	}
+	y2 := quantize(fwht(dcs), e.y2, 0, &mb.y2)
+	dc := iwht(y2)
+	for n := range 16 {
+		coeffs[n] = quantize(coeffs[n], e.y1, 1, &mb.y[n])
+		coeffs[n][0] = dc[n]
+		bx, by := x+n%4*4, y+n/4*4
+		reconstruct(e.y, e.yStride, bx, by, pred[(n/4*4)*16+n%4*4:], 16, coeffs[n])
+	}
+
+	x, y = mbx*8, mby*8
+	mb.uvMode = bestMode([][]uint8{e.u, e.v}, e.cStride, x, y, 8)
+	for p, pix := range [][]uint8{e.u, e.v} {
+		pred := predictBlock(pix, e.cStride, x, y, 8, mb.uvMode)
+		for n := range 4 {
+			bx, by := x+n%2*4, y+n/2*4
+			off := (n/2*4)*8 + n%2*4
+			c := quantize(fdct(residual(pix, e.cStride, bx, by, pred[off:], 8)), e.uvQuant, 0, &mb.uv[p*4+n])
+			reconstruct(pix, e.cStride, bx, by, pred[off:], 8, c)
+		}
+	}
+}
+
+// residual returns the 4x4 block at x, y minus its prediction, which starts
+// at pred with a stride of predStride.
+func residual(pix []uint8, stride, x, y int, pred []int32, predStride int) [16]int32 {
+	var r [16]int32
+	for j := range 4 {
+		for i := range 4 {
+			r[j*4+i] = int32(pix[(y+j)*stride+x+i]) - pred[j*predStride+i]
+		}
+	}
+	return r
+}
+
+// reconstruct replaces the source of a 4x4 block with what the decoder
+// restores from the prediction and the dequantized coefficients.
+func reconstruct(pix []uint8, stride, x, y int, pred []int32, predStride int, coeffs [16]int32) {
+	res := idct(coeffs)
+	for j := range 4 {
+		for i := range 4 {
+			pix[(y+j)*stride+x+i] = uint8(clamp(int(pred[j*predStride+i] + res[j*4+i])))
+		}
+	}
+}
+
+// quantize writes the levels of the coefficients from position first on in
+// zigzag order to levels and returns the dequantized coefficients. Small
+// coefficients are rounded towards zero, they are expensive to code.
+func quantize(coeffs [16]int32, q quantizer, first int, levels *[16]int32) [16]int32 {
+	var dq [16]int32
+	for n := first; n < 16; n++ {
+		pos := zigzag[n]
+		step := q.ac
+		if pos == 0 {
+			step = q.dc
+		}
+		c := coeffs[pos]
+		level := min((max(c, -c)+step/3)/step, maxLevel)
+		if c < 0 {
+			level = -level
+		}
+		levels[n] = level
+		dq[pos] = level * step
+	}
+	return dq
+}
+
+// fdct is the forward transform of libvpx, which idct inverts.
+func fdct(in [16]int32) [16]int32 {
+	var tmp, out [16]int32
+	for i := range 4 {
+		r := in[i*4 : i*4+4]
+		a, b := (r[0]+r[3])*8, (r[1]+r[2])*8
+		c, d := (r[1]-r[2])*8, (r[0]-r[3])*8
+		tmp[i*4+0] = a + b
+		tmp[i*4+2] = a - b
+		tmp[i*4+1] = (c*2217 + d*5352 + 14500) >> 12
+		tmp[i*4+3] = (d*2217 - c*5352 + 7500) >> 12
+	}
+	for i := range 4 {
+		a, b := tmp[i]+tmp[12+i], tmp[4+i]+tmp[8+i]
+		c, d := tmp[4+i]-tmp[8+i], tmp[i]-tmp[12+i]
+		out[i] = (a + b + 7) >> 4
+		out[8+i] = (a - b + 7) >> 4
+		out[4+i] = (c*2217+d*5352+12000)>>16 + btoi(d != 0)
+		out[12+i] = (d*2217 - c*5352 + 51000) >> 16
+	}
+	return out
+}
+
+// idct is the inverse transform of the decoder, section 14.3.
+func idct(in [16]int32) [16]int32 {
+	const c1, c2 = 85627, 35468
+	var m [4][4]int32
+	var out [16]int32
+	for i := range 4 {
+		a, b := in[i]+in[8+i], in[i]-in[8+i]
+		c := (in[4+i]*c2)>>16 - (in[12+i]*c1)>>16
+		d := (in[4+i]*c1)>>16 + (in[12+i]*c2)>>16
+		m[i] = [4]int32{a + d, b + c, b - c, a - d}
+	}
+	for j := range 4 {
+		dc := m[0][j] + 4
+		a, b := dc+m[2][j], dc-m[2][j]
+		c := (m[1][j]*c2)>>16 - (m[3][j]*c1)>>16
+		d := (m[1][j]*c1)>>16 + (m[3][j]*c2)>>16
+		out[j*4+0], out[j*4+1] = (a+d)>>3, (b+c)>>3
+		out[j*4+2], out[j*4+3] = (b-c)>>3, (a-d)>>3
+	}
+	return out
+}
+
+// fwht is the forward Walsh-Hadamard transform of libvpx, which iwht
+// inverts.
+func fwht(in [16]int32) [16]int32 {
+	var tmp, out [16]int32
+	for i := range 4 {
+		r := in[i*4 : i*4+4]
+		a, d := (r[0]+r[2])*4, (r[1]+r[3])*4
+		c, b := (r[1]-r[3])*4, (r[0]-r[2])*4
+		tmp[i*4+0] = a + d + btoi(a != 0)
+		tmp[i*4+1] = b + c
+		tmp[i*4+2] = b - c
+		tmp[i*4+3] = a - d
+	}
+	for i := range 4 {
+		a, d := tmp[i]+tmp[8+i], tmp[4+i]+tmp[12+i]
+		c, b := tmp[4+i]-tmp[12+i], tmp[i]-tmp[8+i]
+		for k, v := range [4]int32{a + d, b + c, b - c, a - d} {
+			v += btoi(v < 0)
+			out[k*4+i] = (v + 3) >> 3
+		}
+	}
+	return out
+}
+
+// iwht is the inverse transform of the decoder, section 14.3. It returns the
+// DC coefficients of the 16 luma blocks.
+func iwht(in [16]int32) [16]int32 {
+	var m, out [16]int32
+	for i := range 4 {
+		a0, a1 := in[i]+in[12+i], in[4+i]+in[8+i]
+		a2, a3 := in[4+i]-in[8+i], in[i]-in[12+i]
+		m[i], m[8+i] = a0+a1, a0-a1
+		m[4+i], m[12+i] = a3+a2, a3-a2
+	}
+	for i := range 4 {
+		dc := m[i*4] + 3
+		a0, a1 := dc+m[i*4+3], m[i*4+1]+m[i*4+2]
+		a2, a3 := m[i*4+1]-m[i*4+2], dc-m[i*4+3]
+		out[i*4+0], out[i*4+1] = (a0+a1)>>3, (a3+a2)>>3
+		out[i*4+2], out[i*4+3] = (a0-a1)>>3, (a3-a2)>>3
+	}
+	return out
+}
+
+func btoi(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// frame writes the frame header, the modes and the tokens.
+func (e *vp8Encoder) frame(width, height, qi int) []byte {
+	// count the token branches first to pick the probabilities
+	stats := &tokenStats{}
+	e.tokens(&tokenWriter{stats: stats})
+	probs := defaultTokenProbs
+	var updates [numPlanes][numBands][numContexts][numProbs]bool
+	for i := range probs {
+		for j := range probs[i] {
+			for k := range probs[i][j] {
+				for l, old := range probs[i][j][k] {
+					n := stats[i][j][k][l]
+					p := newProb(n)
+					upd := tokenProbUpdateProbs[i][j][k][l]
+					if bitCost(old, n)+flagCost(upd, false) > bitCost(p, n)+flagCost(upd, true)+8 {
+						probs[i][j][k][l], updates[i][j][k][l] = p, true
+					}
+				}
+			}
+		}
+	}
+
+	var skipped int
+	for i := range e.mbs {
+		skipped += int(btoi(e.mbs[i].skip()))
+	}
+	skipProb := uint8(min(max(255*(len(e.mbs)-skipped)/len(e.mbs), 1), 254))
+
+	hdr := &boolEncoder{}
+	hdr.init()
+	hdr.literal(0, 1) // color space
+	hdr.literal(0, 1) // clamping required
+	hdr.literal(0, 1) // no segments
+	hdr.literal(0, 1) // normal loop filter
+	hdr.literal(uint32(e.filterLevel), 6)
+	hdr.literal(0, 3) // sharpness
+	hdr.literal(0, 1) // no filter deltas
+	hdr.literal(0, 2) // one token partition
+	hdr.literal(uint32(qi), 7)
+	hdr.literal(0, 5) // no quantizer deltas
+	hdr.literal(0, 1) // refresh entropy probs
+	for i := range probs {
+		for j := range probs[i] {
+			for k := range probs[i][j] {
+				for l, p := range probs[i][j][k] {
+					hdr.bit(updates[i][j][k][l], tokenProbUpdateProbs[i][j][k][l])
+					if updates[i][j][k][l] {
+						hdr.literal(uint32(p), 8)
+					}
+				}
+			}
+		}
+	}
+	hdr.literal(1, 1) // skip flags
+	hdr.literal(uint32(skipProb), 8)
+	for i := range e.mbs {
+		mb := &e.mbs[i]
+		hdr.bit(mb.skip(), skipProb)
+		hdr.bit(true, 145) // 16x16 luma
+		switch mb.yMode {
+		case predDC:
+			hdr.bit(false, 156)
+			hdr.bit(false, 163)
+		case predVE:
+			hdr.bit(false, 156)
+			hdr.bit(true, 163)
+		case predHE:
+			hdr.bit(true, 156)
+			hdr.bit(false, 128)
+		case predTM:
+			hdr.bit(true, 156)
+			hdr.bit(true, 128)
+		}
+		hdr.bit(mb.uvMode != predDC, 142)
+		if mb.uvMode != predDC {
+			hdr.bit(mb.uvMode != predVE, 114)
+			if mb.uvMode != predVE {
+				hdr.bit(mb.uvMode != predHE, 183)
+			}
+		}
+	}
+	first := hdr.flush()
+
+	tokens := &boolEncoder{}
+	tokens.init()
+	e.tokens(&tokenWriter{enc: tokens, probs: &probs})
+	second := tokens.flush()
+
+	size := len(first)
+	out := []byte{
+		byte(size<<5 | 1<<4), byte(size >> 3), byte(size >> 11), // key frame, shown
+		0x9d, 0x01, 0x2a,
+		byte(width), byte(width >> 8), byte(height), byte(height >> 8),
+	}
+	out = append(out, first...)
+	return append(out, second...)
+}
+
+type tokenStats [numPlanes][numBands][numContexts][numProbs][2]uint32
+
+func newProb(n [2]uint32) uint8 {
+	if n[0]+n[1] == 0 {
+		return 128
+	}
+	return uint8(min(max((255*n[0]+(n[0]+n[1])/2)/(n[0]+n[1]), 1), 255))
+}
+
+// bitCost is the cost in bits of coding n zeros and ones with the
+// probability p of a zero.
+func bitCost(p uint8, n [2]uint32) float64 {
+	p0 := float64(p) / 256
+	return -float64(n[0])*math.Log2(p0) - float64(n[1])*math.Log2(1-p0)
+}
+
+func flagCost(p uint8, set bool) float64 {
+	if set {
+		return bitCost(p, [2]uint32{0, 1})
+	}
+	return bitCost(p, [2]uint32{1, 0})
+}
+
+// tokenWriter codes the tokens of blocks, or only counts their branches
+// when stats is set.
+type tokenWriter struct {
+	enc   *boolEncoder
+	probs *tokenProbs
+	stats *tokenStats
+}
+
+func (t *tokenWriter) branch(bit bool, plane, band, ctx, i int) {
+	if t.stats != nil {
+		t.stats[plane][band][ctx][i][btoi(bit)]++
+		return
+	}
+	t.enc.bit(bit, t.probs[plane][band][ctx][i])
+}
+
+func (t *tokenWriter) fixed(bit bool, prob uint8) {
+	if t.enc != nil {
+		t.enc.bit(bit, prob)
+	}
+}
+
+// tokens codes the levels of all macroblocks with the contexts of section
+// 13.3, i.e. whether the blocks left and above have levels.
+func (e *vp8Encoder) tokens(t *tokenWriter) {
+	up := make([]struct{ y2, y, uv uint8 }, e.mbw)
+	for mby := range e.mbh {
+		var left struct{ y2, y, uv uint8 }
+		for mbx := range e.mbw {
+			mb := &e.mbs[mby*e.mbw+mbx]
+			if mb.skip() {
+				left.y2, left.y, left.uv = 0, 0, 0
+				up[mbx].y2, up[mbx].y, up[mbx].uv = 0, 0, 0
+				continue
+			}
+			nz := t.block(&mb.y2, planeY2, 0, left.y2+up[mbx].y2)
+			left.y2, up[mbx].y2 = nz, nz
+			for n := range 16 {
+				bx, by := uint(n%4), uint(n/4)
+				nz := t.block(&mb.y[n], planeYAfterY2, 1, (left.y>>by)&1+(up[mbx].y>>bx)&1)
+				left.y = left.y&^(1<<by) | nz<<by
+				up[mbx].y = up[mbx].y&^(1<<bx) | nz<<bx
+			}
+			// two bits per chroma plane, U first
+			for n := range 8 {
+				bx, by := uint(n/4*2+n%2), uint(n/4*2+n%4/2)
+				nz := t.block(&mb.uv[n], planeUV, 0, (left.uv>>by)&1+(up[mbx].uv>>bx)&1)
+				left.uv = left.uv&^(1<<by) | nz<<by
+				up[mbx].uv = up[mbx].uv&^(1<<bx) | nz<<bx
+			}
+		}
+	}
+}
+
+// block codes the levels from position first on and returns 1 if any was
+// coded, as in section 13.2.
+func (t *tokenWriter) block(levels *[16]int32, plane, first int, ctx uint8) uint8 {
+	last := -1
+	for n := first; n < 16; n++ {
+		if levels[n] != 0 {
+			last = n
+		}
+	}
+	band, c := int(bands[first]), int(ctx)
+	if last < 0 {
+		t.branch(false, plane, band, c, 0)
+		return 0
+	}
+	t.branch(true, plane, band, c, 0)
+	for n := first; n <= last; n++ {
+		v := levels[n]
+		v = max(v, -v)
+		next := int(bands[n+1])
+		if v == 0 {
+			t.branch(false, plane, band, c, 1)
+			band, c = next, 0
+			continue
+		}
+		t.branch(true, plane, band, c, 1)
+		if v == 1 {
+			t.branch(false, plane, band, c, 2)
+		} else {
+			t.branch(true, plane, band, c, 2)
+			t.value(v, plane, band, c)
+		}
+		t.fixed(levels[n] < 0, 128)
+		band, c = next, min(int(v), 2)
+		if n < 15 {
+			t.branch(n != last, plane, band, c, 0)
+		}
+	}
+	return 1
+}
+
+// value codes a level of at least 2.
+func (t *tokenWriter) value(v int32, plane, band, c int) {
+	switch {
+	case v <= 4:
+		t.branch(false, plane, band, c, 3)
+		t.branch(v != 2, plane, band, c, 4)
+		if v != 2 {
+			t.branch(v == 4, plane, band, c, 5)
+		}
+	case v <= 10:
+		t.branch(true, plane, band, c, 3)
+		t.branch(false, plane, band, c, 6)
+		if v <= 6 {
+			t.branch(false, plane, band, c, 7)
+			t.fixed(v == 6, 159)
+		} else {
+			t.branch(true, plane, band, c, 7)
+			t.fixed((v-7)&2 != 0, 165)
+			t.fixed((v-7)&1 != 0, 145)
+		}
+	default:
+		t.branch(true, plane, band, c, 3)
+		t.branch(true, plane, band, c, 6)
+		cat := 0
+		for cat < 3 && v >= 3+8<<(cat+1) {
+			cat++
+		}
+		t.branch(cat >= 2, plane, band, c, 8)
+		t.branch(cat&1 != 0, plane, band, c, 9+cat>>1)
+		extra, probs := v-(3+8<<cat), categoryProbs[cat]
+		for i, p := range probs {
+			t.fixed(extra>>(len(probs)-1-i)&1 != 0, p)
+		}
+	}
+}
+
+// boolEncoder is the arithmetic coder of section 7.3.
+type boolEncoder struct {
+	out      []byte
+	rng      uint32
+	bottom   uint32
+	bitCount int
+}
+
+func (e *boolEncoder) init() {
+	e.rng, e.bitCount = 255, 24
+}
+
+func (e *boolEncoder) bit(bit bool, prob uint8) {
+	split := 1 + (e.rng-1)*uint32(prob)>>8
+	if bit {
+		e.bottom += split
+		e.rng -= split
+	} else {
+		e.rng = split
+	}
+	for e.rng < 128 {
+		e.rng <<= 1
+		if e.bottom&(1<<31) != 0 {
+			e.carry()
+		}
+		e.bottom <<= 1
+		e.bitCount--
+		if e.bitCount == 0 {
+			e.out = append(e.out, byte(e.bottom>>24))
+			e.bottom &= 1<<24 - 1
+			e.bitCount = 8
+		}
+	}
+}
+
+func (e *boolEncoder) carry() {
+	i := len(e.out) - 1
+	for ; e.out[i] == 0xff; i-- {
+		e.out[i] = 0
+	}
+	e.out[i]++
+}
+
+func (e *boolEncoder) literal(v uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		e.bit(v>>i&1 != 0, 128)
+	}
+}
+
+func (e *boolEncoder) flush() []byte {
+	c, v := e.bitCount, e.bottom
+	if v&(1<<(32-c)) != 0 {
+		e.carry()
+	}
+	v <<= c & 7
+	for c >>= 3; c > 0; c-- {
+		v <<= 8
+	}
+	for range 4 {
+		e.out = append(e.out, byte(v>>24))
+		v <<= 8
+	}
+	return e.out
+}
@@ -0,0 +1,473 @@
+package img
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"slices"
+)
+
+// A lossless WebP (VP8L) encoder. It applies the subtract-green and predictor
+// transforms and entropy codes the residuals with one set of prefix codes,
+// without backward references or a color cache. That is far simpler than
+// libwebp, photos are usually encoded lossy by vp8.go anyway.
+
+const (
+	maxDimension  = 1 << 14
+	predictorBits = 4 // 16x16 blocks per prediction mode
+	numModes      = 14
+
+	numLiteral     = 256
+	numLengthCodes = 24
+	numDistance    = 40
+	maxCodeLength  = 15
+	maxCLLength    = 7 // lengths of the code length code
+)
+
+var codeLengthOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// encodeVP8L returns the lossless bitstream of m.
+func encodeVP8L(m image.Image) ([]byte, error) {
+	b := m.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width == 0 || height == 0 || width > maxDimension || height > maxDimension {
+		return nil, errors.New("webp: image dimensions out of range")
+	}
+
+	nrgba := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(nrgba, nrgba.Bounds(), m, b.Min, draw.Src)
+
+	pix := make([]uint32, width*height)
+	opaque := true
+	for i := range pix {
+		p := nrgba.Pix[i*4 : i*4+4]
+		pix[i] = uint32(p[3])<<24 | uint32(p[0])<<16 | uint32(p[1])<<8 | uint32(p[2])
+		opaque = opaque && p[3] == 0xff
+	}
+
+	bw := &bitWriter{}
+	bw.write(0x2f, 8)
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	if opaque {
+		bw.write(0, 1)
+	} else {
+		bw.write(1, 1)
+	}
+	bw.write(0, 3)
+
+	subtractGreen(pix)
+	bw.write(1, 1)
+	bw.write(2, 2)
+
+	modes := predictionModes(pix, width, height)
+	bw.write(1, 1)
+	bw.write(0, 2)
+	bw.write(predictorBits-2, 3)
+	writeEntropyImage(bw, modes, false)
+	residuals := predict(pix, width, height, modes)
+
+	bw.write(0, 1)
+	writeEntropyImage(bw, residuals, true)
+
+	return bw.bytes(), nil
+}
+
+func subtractGreen(pix []uint32) {
+	for i, p := range pix {
+		g := (p >> 8) & 0xff
+		r := ((p >> 16) - g) & 0xff
+		b := (p - g) & 0xff
+		pix[i] = p&0xff00ff00 | r<<16 | b
+	}
+}
+
+// predictionModes picks the mode with the smallest residuals for every block,
+// as sub-image with the mode in the green channel.
+func predictionModes(pix []uint32, width, height int) []uint32 {
+	size := 1 << predictorBits
+	tilesX, tilesY := (width+size-1)/size, (height+size-1)/size
+	modes := make([]uint32, tilesX*tilesY)
+
+	for ty := range tilesY {
+		for tx := range tilesX {
+			best, bestCost := 0, -1
+			for mode := range numModes {
+				cost := 0
+				for y := ty * size; y < min((ty+1)*size, height); y++ {
+					for x := tx * size; x < min((tx+1)*size, width); x++ {
+						cost += residualCost(sub(pix[y*width+x], predictPixel(pix, width, x, y, mode)))
+					}
+				}
+				if bestCost < 0 || cost < bestCost {
+					best, bestCost = mode, cost
+				}
+			}
+			modes[ty*tilesX+tx] = 0xff000000 | uint32(best)<<8
+		}
+	}
+	return modes
+}
+
+// predict returns the residuals of pix.
+func predict(pix []uint32, width, height int, modes []uint32) []uint32 {
+	tilesX := (width + 1<<predictorBits - 1) >> predictorBits
+	residuals := make([]uint32, len(pix))
+	for y := range height {
+		for x := range width {
+			i := y*width + x
+			mode := int(channel(modes[(y>>predictorBits)*tilesX+x>>predictorBits], 8))
+			residuals[i] = sub(pix[i], predictPixel(pix, width, x, y, mode))
+		}
+	}
+	return residuals
+}
+
+func predictPixel(pix []uint32, width, x, y, mode int) uint32 {
+	i := y*width + x
+	switch {
+	case x == 0 && y == 0:
+		return 0xff000000
+	case y == 0:
+		return pix[i-1]
+	case x == 0:
+		return pix[i-width]
+	}
+
+	// the top right of the last column is the first pixel of the row
+	l, t, tl, tr := pix[i-1], pix[i-width], pix[i-width-1], pix[i-width+1]
+	switch mode {
+	case 0:
+		return 0xff000000
+	case 1:
+		return l
+	case 2:
+		return t
+	case 3:
+		return tr
+	case 4:
+		return tl
+	case 5:
+		return average(average(l, tr), t)
+	case 6:
+		return average(l, tl)
+	case 7:
+		return average(l, t)
+	case 8:
+		return average(tl, t)
+	case 9:
+		return average(t, tr)
+	case 10:
+		return average(average(l, tl), average(t, tr))
+	case 11:
+		return sel(l, t, tl)
+	case 12:
+		return perChannel(func(s uint) uint32 {
+			return clamp(int(channel(l, s)) + int(channel(t, s)) - int(channel(tl, s)))
+		})
+	default:
+		a := average(l, t)
+		return perChannel(func(s uint) uint32 {
+			return clamp(int(channel(a, s)) + (int(channel(a, s))-int(channel(tl, s)))/2)
+		})
+	}
+}
+
+func channel(p uint32, shift uint) uint32 {
+	return (p >> shift) & 0xff
+}
+
+func perChannel(f func(shift uint) uint32) uint32 {
+	return f(24)<<24 | f(16)<<16 | f(8)<<8 | f(0)
+}
+
+func average(a, b uint32) uint32 {
+	return perChannel(func(s uint) uint32 { return (channel(a, s) + channel(b, s)) / 2 })
+}
+
+func clamp(v int) uint32 {
+	return uint32(min(max(v, 0), 0xff))
+}
+
+func sel(l, t, tl uint32) uint32 {
+	pl, pt := 0, 0
+	for _, s := range []uint{24, 16, 8, 0} {
+		p := int(channel(l, s)) + int(channel(t, s)) - int(channel(tl, s))
+		pl += abs(p - int(channel(l, s)))
+		pt += abs(p - int(channel(t, s)))
+	}
+	if pl < pt {
+		return l
+	}
+	return t
+}
+
+func sub(a, b uint32) uint32 {
+	return perChannel(func(s uint) uint32 { return (channel(a, s) - channel(b, s)) & 0xff })
+}
+
+func residualCost(r uint32) int {
+	cost := 0
+	for _, s := range []uint{24, 16, 8, 0} {
+		v := int(channel(r, s))
+		cost += min(v, 256-v)
+	}
+	return cost
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// writeEntropyImage codes pix with one set of prefix codes. Only the main
+// image has the flag for meta prefix codes.
+func writeEntropyImage(bw *bitWriter, pix []uint32, main bool) {
+	bw.write(0, 1) // no color cache
+	if main {
+		bw.write(0, 1) // no meta prefix codes
+	}
+
+	green := make([]uint32, numLiteral+numLengthCodes)
+	red, blue, alpha := make([]uint32, numLiteral), make([]uint32, numLiteral), make([]uint32, numLiteral)
+	for _, p := range pix {
+		green[channel(p, 8)]++
+		red[channel(p, 16)]++
+		blue[channel(p, 0)]++
+		alpha[channel(p, 24)]++
+	}
+
+	codes := []*prefixCode{newPrefixCode(green), newPrefixCode(red), newPrefixCode(blue), newPrefixCode(alpha),
+		newPrefixCode(make([]uint32, numDistance))}
+	for _, c := range codes {
+		c.writeTo(bw)
+	}
+
+	for _, p := range pix {
+		codes[0].writeSymbol(bw, int(channel(p, 8)))
+		codes[1].writeSymbol(bw, int(channel(p, 16)))
+		codes[2].writeSymbol(bw, int(channel(p, 0)))
+		codes[3].writeSymbol(bw, int(channel(p, 24)))
+	}
+}
+
+// prefixCode is a canonical Huffman code. Codes with less than two used
+// symbols are written as simple codes and take no bits per symbol.
+type prefixCode struct {
+	used    []int
+	lengths []uint8
+	codes   []uint16
+}
+
+func newPrefixCode(counts []uint32) *prefixCode {
+	c := &prefixCode{}
+	for sym, n := range counts {
+		if n > 0 {
+			c.used = append(c.used, sym)
+		}
+	}
+	if len(c.used) < 2 {
+		return c
+	}
+	c.lengths = codeLengths(counts, maxCodeLength)
+	c.codes = canonicalCodes(c.lengths)
+	return c
+}
+
+func (c *prefixCode) writeSymbol(bw *bitWriter, sym int) {
+	if c.lengths != nil {
+		bw.write(uint32(c.codes[sym]), uint(c.lengths[sym]))
+	}
+}
+
+func (c *prefixCode) writeTo(bw *bitWriter) {
+	if c.lengths == nil {
+		sym := 0
+		if len(c.used) == 1 {
+			sym = c.used[0]
+		}
+		bw.write(1, 1) // simple code
+		bw.write(0, 1) // of one symbol
+		if sym < 2 {
+			bw.write(0, 1)
+			bw.write(uint32(sym), 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(uint32(sym), 8)
+		}
+		return
+	}
+
+	// the lengths, with runs of zeros shortened to repeat codes
+	type token struct{ sym, extra, extraBits int }
+	var tokens []token
+	clCounts := make([]uint32, len(codeLengthOrder))
+	for i := 0; i < len(c.lengths); {
+		run := 1
+		for i+run < len(c.lengths) && c.lengths[i+run] == c.lengths[i] {
+			run++
+		}
+		if c.lengths[i] != 0 || run < 3 {
+			tokens = append(tokens, token{sym: int(c.lengths[i])})
+			i++
+			continue
+		}
+		switch {
+		case run >= 11:
+			run = min(run, 138)
+			tokens = append(tokens, token{sym: 18, extra: run - 11, extraBits: 7})
+		default:
+			tokens = append(tokens, token{sym: 17, extra: run - 3, extraBits: 3})
+		}
+		i += run
+	}
+	for _, t := range tokens {
+		clCounts[t.sym]++
+	}
+
+	clLengths := codeLengths(clCounts, maxCLLength)
+	if used := slices.IndexFunc(clLengths, func(l uint8) bool { return l > 0 }); used >= 0 &&
+		slices.IndexFunc(clLengths[used+1:], func(l uint8) bool { return l > 0 }) < 0 {
+		// a single length in use still needs a complete code
+		clLengths[used] = 1
+		clLengths[(used+1)%len(clLengths)] = 1
+	}
+	clCodes := canonicalCodes(clLengths)
+
+	n := len(codeLengthOrder)
+	for n > 4 && clLengths[codeLengthOrder[n-1]] == 0 {
+		n--
+	}
+	bw.write(0, 1) // normal code
+	bw.write(uint32(n-4), 4)
+	for _, sym := range codeLengthOrder[:n] {
+		bw.write(uint32(clLengths[sym]), 3)
+	}
+	bw.write(0, 1) // lengths for the whole alphabet
+	for _, t := range tokens {
+		bw.write(uint32(clCodes[t.sym]), uint(clLengths[t.sym]))
+		if t.extraBits > 0 {
+			bw.write(uint32(t.extra), uint(t.extraBits))
+		}
+	}
+}
+
+// codeLengths builds a Huffman code limited to limit bits. Too deep trees are
+// flattened by raising the counts of rare symbols until they fit.
+func codeLengths(counts []uint32, limit int) []uint8 {
+	type node struct {
+		count       uint32
+		left, right int // children, -1 for leaves
+		sym         int
+	}
+
+	lengths := make([]uint8, len(counts))
+	for floor := uint32(1); ; floor *= 2 {
+		var nodes []node
+		for sym, n := range counts {
+			if n > 0 {
+				nodes = append(nodes, node{count: max(n, floor), left: -1, right: -1, sym: sym})
+			}
+		}
+		if len(nodes) < 2 {
+			for _, n := range nodes {
+				lengths[n.sym] = 1
+			}
+			return lengths
+		}
+		slices.SortStableFunc(nodes, func(a, b node) int { return int(a.count) - int(b.count) })
+
+		// two queues: the sorted leaves and the merged nodes in order of creation
+		leaves := len(nodes)
+		li, mi := 0, leaves
+		pop := func() int {
+			if li < leaves && (mi >= len(nodes) || nodes[li].count <= nodes[mi].count) {
+				li++
+				return li - 1
+			}
+			mi++
+			return mi - 1
+		}
+		for len(nodes) < 2*leaves-1 {
+			a, b := pop(), pop()
+			nodes = append(nodes, node{count: nodes[a].count + nodes[b].count, left: a, right: b, sym: -1})
+		}
+
+		depth := make([]int, len(nodes))
+		deepest := 0
+		for i := len(nodes) - 1; i >= 0; i-- {
+			if nodes[i].left >= 0 {
+				depth[nodes[i].left] = depth[i] + 1
+				depth[nodes[i].right] = depth[i] + 1
+				continue
+			}
+			deepest = max(deepest, depth[i])
+		}
+		if deepest > limit {
+			continue
+		}
+		for i := range leaves {
+			lengths[nodes[i].sym] = uint8(depth[i])
+		}
+		return lengths
+	}
+}
+
+// canonicalCodes assigns the codes of the lengths, bit-reversed since the
+// bit stream is read from the least significant bit.
+func canonicalCodes(lengths []uint8) []uint16 {
+	var count [maxCodeLength + 1]int
+	for _, l := range lengths {
+		if l > 0 {
+			count[l]++
+		}
+	}
+	var next [maxCodeLength + 1]int
+	code := 0
+	for bits := 1; bits <= maxCodeLength; bits++ {
+		code = (code + count[bits-1]) << 1
+		next[bits] = code
+	}
+
+	codes := make([]uint16, len(lengths))
+	for sym, l := range lengths {
+		if l == 0 {
+			continue
+		}
+		c := next[l]
+		next[l]++
+		var rev uint16
+		for range l {
+			rev = rev<<1 | uint16(c&1)
+			c >>= 1
+		}
+		codes[sym] = rev
+	}
+	return codes
+}
+
+type bitWriter struct {
+	buf  []byte
+	acc  uint64
+	bits uint
+}
+
+func (w *bitWriter) write(v uint32, n uint) {
+	w.acc |= uint64(v) << w.bits
+	w.bits += n
+	for w.bits >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.bits -= 8
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	if w.bits > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc, w.bits = 0, 0
+	}
+	return w.buf
+}
@@ -0,0 +1,51 @@
+package img
+
+import (
+	"encoding/binary"
+	"image"
+	"io"
+)
+
+// encodeWebP writes m as WebP: lossy (VP8) below quality 100, lossless
+// (VP8L) at 100 and for images with transparency, which VP8 cannot hold.
+func encodeWebP(w io.Writer, m image.Image, quality int) error {
+	fourCC, encode := "VP8L", encodeVP8L
+	if quality < 100 && opaque(m) {
+		fourCC = "VP8 "
+		encode = func(m image.Image) ([]byte, error) { return encodeVP8(m, quality) }
+	}
+	data, err := encode(m)
+	if err != nil {
+		return err
+	}
+
+	chunk := len(data) + len(data)%2
+	header := make([]byte, 20)
+	copy(header, "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(12+chunk))
+	copy(header[8:], "WEBP"+fourCC)
+	binary.LittleEndian.PutUint32(header[16:], uint32(len(data)))
+	if len(data)%2 == 1 {
+		data = append(data, 0)
+	}
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func opaque(m image.Image) bool {
+	if o, ok := m.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	b := m.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, _, _, a := m.At(x, y).RGBA(); a != 0xffff {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package img
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+// testImage is photo-like: smooth gradients, a hard edge and some noise.
+func testImage(width, height int, alpha bool) *image.NRGBA {
+	rng := rand.New(rand.NewPCG(uint64(width), uint64(height)))
+	m := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			r := 40 + 170*x/max(width, 1)
+			g := 60 + 150*y/max(height, 1)
+			b := 128
+			if x > width/2 && y > height/3 {
+				b = 220 // an edge
+			}
+			noise := rng.IntN(9) - 4
+			a := 255
+			if alpha {
+				a = 255 * (x + y) / max(width+height-2, 1)
+			}
+			m.SetNRGBA(x, y, color.NRGBA{uint8(clampInt(r + noise)), uint8(clampInt(g + noise)), uint8(clampInt(b + noise)), uint8(a)})
+		}
+	}
+	return m
+}
+
+func clampInt(v int) int {
+	return min(max(v, 0), 255)
+}
+
+// psnr compares the RGB channels of two opaque images, in dB.
+func psnr(a, b image.Image) float64 {
+	var sum float64
+	bounds := a.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r1, g1, b1, _ := a.At(x, y).RGBA()
+			r2, g2, b2, _ := b.At(x, y).RGBA()
+			for _, d := range []float64{float64(r1>>8) - float64(r2>>8), float64(g1>>8) - float64(g2>>8), float64(b1>>8) - float64(b2>>8)} {
+				sum += d * d
+			}
+		}
+	}
+	mse := sum / float64(3*bounds.Dx()*bounds.Dy())
+	if mse == 0 {
+		return math.Inf(1)
+	}
+	return 10 * math.Log10(255*255/mse)
+}
+
+func encode(t *testing.T, m image.Image, quality int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := encodeWebP(&buf, m, quality); err != nil {
+		t.Fatalf("encodeWebP(%v, %d) = %v", m.Bounds().Size(), quality, err)
+	}
+	data := buf.Bytes()
+	if len(data) < 20 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		t.Fatalf("encodeWebP(%v, %d) wrote no RIFF WebP header", m.Bounds().Size(), quality)
+	}
+	if size := binary.LittleEndian.Uint32(data[4:]); int(size) != len(data)-8 || size%2 != 0 {
+		t.Errorf("RIFF size %d, file has %d bytes", size, len(data))
+	}
+	return data
+}
+
+func TestEncodeVP8(t *testing.T) {
+	sizes := [][2]int{{1, 1}, {15, 17}, {16, 16}, {33, 7}, {100, 75}, {257, 129}}
+	for _, size := range sizes {
+		m := testImage(size[0], size[1], false)
+		last := 0.0
+		for _, q := range []int{0, 25, 50, 75, 99} {
+			data := encode(t, m, q)
+			if fourCC := string(data[12:16]); fourCC != "VP8 " {
+				t.Errorf("%v q%d: chunk %q, want lossy", size, q, fourCC)
+			}
+			d, err := webp.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("%v q%d: %v", size, q, err)
+			}
+			if d.Bounds() != m.Bounds() {
+				t.Fatalf("%v q%d: decoded %v", size, q, d.Bounds())
+			}
+			ycbcr, ok := d.(*image.YCbCr)
+			if !ok {
+				t.Fatalf("%v q%d: decoded a %T", size, q, d)
+			}
+			floor := 20.0
+			if size[0]*size[1] >= 100*75 {
+				floor = 27
+				if q == 99 {
+					floor = 38
+				}
+			}
+			p := psnr(m, limitedRange(ycbcr))
+			if p < floor {
+				t.Errorf("%v q%d: PSNR %.1f dB, want at least %.0f", size, q, p, floor)
+			}
+			if size[0]*size[1] >= 100*75 && p < last {
+				t.Errorf("%v q%d: PSNR %.1f dB, below %.1f of a lower quality", size, q, p, last)
+			}
+			last = p
+		}
+	}
+}
+
+func TestEncodeVP8L(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		m       *image.NRGBA
+		quality int
+	}{
+		{"opaque q100", testImage(33, 7, false), 100},
+		{"opaque 1x1", testImage(1, 1, false), 100},
+		{"alpha q0", testImage(15, 17, true), 0},
+		{"alpha q75", testImage(257, 129, true), 75},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data := encode(t, tc.m, tc.quality)
+			if fourCC := string(data[12:16]); fourCC != "VP8L" {
+				t.Errorf("chunk %q, want lossless", fourCC)
+			}
+			d, err := webp.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.Bounds() != tc.m.Bounds() {
+				t.Fatalf("decoded %v", d.Bounds())
+			}
+			for y := range tc.m.Rect.Dy() {
+				for x := range tc.m.Rect.Dx() {
+					want := tc.m.NRGBAAt(x, y)
+					got := color.NRGBAModel.Convert(d.At(x, y)).(color.NRGBA)
+					if want.A == 0 {
+						want, got = color.NRGBA{}, color.NRGBA{A: got.A}
+					}
+					if got != want {
+						t.Fatalf("pixel (%d,%d) = %v, want %v", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+// limitedRange converts to RGB like libwebp: VP8 uses the limited range of
+// BT.601, image.YCbCr the full range of JPEG.
+func limitedRange(m *image.YCbCr) image.Image {
+	out := image.NewRGBA(m.Bounds())
+	for y := m.Rect.Min.Y; y < m.Rect.Max.Y; y++ {
+		for x := m.Rect.Min.X; x < m.Rect.Max.X; x++ {
+			yy := float64(m.Y[m.YOffset(x, y)]) - 16
+			cb := float64(m.Cb[m.COffset(x, y)]) - 128
+			cr := float64(m.Cr[m.COffset(x, y)]) - 128
+			r := 1.164*yy + 1.596*cr
+			g := 1.164*yy - 0.392*cb - 0.813*cr
+			b := 1.164*yy + 2.017*cb
+			out.Set(x, y, color.RGBA{uint8(clampInt(int(math.Round(r)))), uint8(clampInt(int(math.Round(g)))), uint8(clampInt(int(math.Round(b)))), 255})
+		}
+	}
+	return out
+}
@@ -0,0 +1,203 @@
+package linkeddata
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"strings"
+	"time"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+)
+
+const mediaType = "application/ld+json"
+
+var (
+	genders = map[string]string{v1.GenderMale: "https://schema.org/Male", v1.GenderFemale: "https://schema.org/Female"}
+
+	bundestag = Organization{Type: "GovernmentOrganization", ID: "https://www.bundestag.de/", Name: "Deutscher Bundestag"}
+)
+
+// Mapper maps v1 resources to schema.org nodes. Their @id is the URL of the
+// resource below the public base URL of the API.
+type Mapper struct {
+	base rest.PublicBase
+}
+
+func NewMapper(base rest.PublicBase) *Mapper {
+	return &Mapper{base: base}
+}
+
+func (m *Mapper) id(req *http.Request, segments ...string) string {
+	return m.base.Of(req).JoinPath(segments...).String()
+}
+
+func (m *Mapper) Politician(req *http.Request, p *v1.Politician) Person {
+	bio := p.Bio
+
+	person := Person{
+		Type:            "Person",
+		ID:              m.id(req, "politicians", bio.Id.Value),
+		Name:            strings.Join(strings.Fields(strings.Join([]string{bio.AcademicTitle, bio.FirstName, bio.NobilityTitle, bio.LastName}, " ")), " "),
+		GivenName:       bio.FirstName,
+		FamilyName:      strings.TrimSpace(bio.NobilityTitle + " " + bio.LastName),
+		HonorificPrefix: bio.AcademicTitle,
+		Gender:          genders[strings.ToLower(bio.Gender)],
+		JobTitle:        "Mitglied des Deutschen Bundestages",
+		Telephone:       bio.Phone,
+		URL:             bio.BioURL,
+		Address: &PostalAddress{
+			Type:          "PostalAddress",
+			StreetAddress: v1.Office.Street,
+			PostalCode:    v1.Office.PostalCode,
+			Locality:      v1.Office.Locality,
+			Country:       v1.Office.CountryCode,
+		},
+		MemberOf: []Organization{bundestag},
+	}
+	if birth, ok := bio.BirthDate(); ok {
+		person.BirthDate = birth.Format(time.DateOnly)
+	}
+	if bio.Homepage != "" {
+		person.SameAs = append(person.SameAs, bio.Homepage)
+	}
+	for _, site := range bio.OtherWebsite.Website {
+		person.SameAs = append(person.SameAs, site.URL)
+	}
+	if photo := p.Media.Foto; photo.URL != "" {
+		person.Image = &ImageObject{Type: "ImageObject", ContentURL: photo.URL, Caption: photo.AltText, CopyrightNotice: photo.Copyright}
+	}
+	if bio.Party != "" {
+		person.Affiliation = &Organization{Type: "PoliticalParty", Name: bio.Party}
+	}
+	if bio.Faction != "" {
+		person.MemberOf = append(person.MemberOf, faction(bio.Faction))
+	}
+
+	memberships := bio.Memberships
+	for _, committees := range [][]v1.Committee{memberships.LeadCommittees, memberships.RegularMemberCommittees,
+		memberships.SubstituteMemberCommittees, memberships.ViceChairOtherCommittees} {
+		for _, c := range committees {
+			committee := Organization{Type: "Organization", Name: c.Name, URL: c.Url}
+			if c.Id != "" {
+				committee.ID = m.id(req, "committees", c.Id)
+			}
+			// an MdB can hold more than one role in the same committee
+			if !containsOrganization(person.MemberOf, committee) {
+				person.MemberOf = append(person.MemberOf, committee)
+			}
+		}
+	}
+
+	return person
+}
+
+func (m *Mapper) PersonListEntry(req *http.Request, p *v1.PersonListEntry) Person {
+	person := Person{
+		Type:     "Person",
+		ID:       m.id(req, "politicians", p.Id.Value),
+		Name:     p.Name.Value,
+		JobTitle: "Mitglied des Deutschen Bundestages",
+		URL:      p.BioURL,
+		MemberOf: []Organization{bundestag},
+	}
+	// the catalog lists names as "Last, First"
+	if last, first, ok := strings.Cut(p.Name.Value, ", "); ok {
+		person.Name, person.GivenName, person.FamilyName = first+" "+last, first, last
+	}
+	if p.PhotoURL != "" {
+		person.Image = &ImageObject{Type: "ImageObject", ContentURL: p.PhotoURL, Caption: p.ImageAltText}
+	}
+	if p.Faction != "" {
+		person.MemberOf = append(person.MemberOf, faction(p.Faction))
+	}
+	return person
+}
+
+func (m *Mapper) Committee(req *http.Request, c *v1.CommitteeListEntry) Organization {
+	org := Organization{
+		Type:               "Organization",
+		ID:                 m.id(req, "committees", c.Id),
+		Name:               c.Name,
+		AlternateName:      c.ShortName,
+		Description:        c.Teaser,
+		ParentOrganization: &bundestag,
+	}
+	if c.ImageURL != "" {
+		org.Image = &ImageObject{Type: "ImageObject", ContentURL: c.ImageURL, Caption: c.ImageAltText, CopyrightNotice: c.ImageCopyright}
+	}
+	return org
+}
+
+func (m *Mapper) CommitteeDetails(req *http.Request, c *v1.CommitteeDetails) Organization {
+	org := Organization{
+		Type:               "Organization",
+		ID:                 m.id(req, "committees", c.Id),
+		Name:               c.CommitteeName,
+		URL:                c.SourceURL,
+		ParentOrganization: &bundestag,
+	}
+	if c.ImageURL != "" {
+		org.Image = &ImageObject{Type: "ImageObject", ContentURL: c.ImageURL, Caption: c.ImageAltText, CopyrightNotice: c.ImageCopyright}
+	}
+	for i := range c.Members {
+		member := m.PersonListEntry(req, &c.Members[i])
+		// members are referenced, their details live at their @id
+		org.Member = append(org.Member, Person{Type: member.Type, ID: member.ID, Name: member.Name})
+	}
+	return org
+}
+
+// factions have no resource of their own, they are blank nodes
+func faction(name string) Organization {
+	return Organization{Type: "Organization", Name: "Fraktion " + name, ParentOrganization: &bundestag}
+}
+
+func containsOrganization(orgs []Organization, org Organization) bool {
+	for _, o := range orgs {
+		if o.Name == org.Name && o.ID == org.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// Representation offers the nodes produced by node as application/ld+json,
+// lists as @graph.
+func Representation[T any, N any](node func(req *http.Request, res *T) N) rest.Representation[T] {
+	return rest.Representation[T]{
+		MediaType: mediaType,
+		Format:    "jsonld",
+		One: func(w http.ResponseWriter, req *http.Request, res *T) {
+			data, err := json.Marshal(node(req, res))
+			if err != nil {
+				http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+				return
+			}
+			// nodes always start with their @type, the context goes in front
+			write(w, append([]byte(`{"@context":"`+schemaContext+`",`), data[1:]...))
+		},
+		Many: func(w http.ResponseWriter, req *http.Request, res iter.Seq[T]) {
+			graph := Graph[N]{Context: schemaContext, Graph: []N{}}
+			for r := range res {
+				graph.Graph = append(graph.Graph, node(req, &r))
+			}
+			data, err := json.Marshal(graph)
+			if err != nil {
+				http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+				return
+			}
+			write(w, data)
+		},
+	}
+}
+
+func write(w http.ResponseWriter, data []byte) {
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		fmt.Printf("failed to write json-ld: %v\n", err)
+	}
+}
@@ -0,0 +1,93 @@
+package linkeddata
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+)
+
+func TestPoliticianJSONLD(t *testing.T) {
+	p := &v1.Politician{}
+	p.Bio = v1.PoliticianBio{
+		Id:            v1.ID{Value: "1001"},
+		FirstName:     "Erika",
+		LastName:      "Musterfrau",
+		NobilityTitle: "von",
+		AcademicTitle: "Dr.",
+		DateOfBirth:   "07.03.1970",
+		Gender:        "Weiblich",
+		Faction:       "SPD",
+		Party:         "SPD",
+		Phone:         "030 227-12345",
+		BioURL:        "https://www.bundestag.de/abgeordnete/biografien/M/musterfrau-1001",
+		Homepage:      "https://musterfrau.de",
+		OtherWebsite:  v1.OtherWebsites{Website: []v1.Website{{Title: "Mastodon", URL: "https://social.example/@erika"}}},
+		Memberships: v1.Memberships{
+			RegularMemberCommittees:    []v1.Committee{{Id: "a08", Name: "Haushaltsausschuss", Url: "https://www.bundestag.de/haushalt"}},
+			SubstituteMemberCommittees: []v1.Committee{{Id: "a08", Name: "Haushaltsausschuss", Url: "https://www.bundestag.de/haushalt"}},
+		},
+	}
+	p.Media.Foto.URL = "https://www.bundestag.de/photo.jpg"
+	p.Media.Foto.AltText = "Erika Musterfrau"
+	p.Media.Foto.Copyright = "Deutscher Bundestag"
+
+	base, _ := url.Parse("https://api.example/")
+	rep := Representation(NewMapper(rest.PublicBase{URL: base}).Politician)
+	rec := httptest.NewRecorder()
+	rep.One(rec, httptest.NewRequest(http.MethodGet, "/politicians/1001/bio?format=jsonld", nil), p)
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/ld+json" {
+		t.Errorf("Content-Type %q", ct)
+	}
+	want := `{
+		"@context": "https://schema.org",
+		"@type": "Person",
+		"@id": "https://api.example/politicians/1001",
+		"name": "Dr. Erika von Musterfrau",
+		"givenName": "Erika",
+		"familyName": "von Musterfrau",
+		"honorificPrefix": "Dr.",
+		"birthDate": "1970-03-07",
+		"gender": "https://schema.org/Female",
+		"jobTitle": "Mitglied des Deutschen Bundestages",
+		"telephone": "030 227-12345",
+		"url": "https://www.bundestag.de/abgeordnete/biografien/M/musterfrau-1001",
+		"sameAs": ["https://musterfrau.de", "https://social.example/@erika"],
+		"image": {"@type": "ImageObject", "contentUrl": "https://www.bundestag.de/photo.jpg", "caption": "Erika Musterfrau", "copyrightNotice": "Deutscher Bundestag"},
+		"address": {"@type": "PostalAddress", "streetAddress": "Platz der Republik 1", "postalCode": "11011", "addressLocality": "Berlin", "addressCountry": "DE"},
+		"affiliation": {"@type": "PoliticalParty", "name": "SPD"},
+		"memberOf": [
+			{"@type": "GovernmentOrganization", "@id": "https://www.bundestag.de/", "name": "Deutscher Bundestag"},
+			{"@type": "Organization", "name": "Fraktion SPD", "parentOrganization": {"@type": "GovernmentOrganization", "@id": "https://www.bundestag.de/", "name": "Deutscher Bundestag"}},
+			{"@type": "Organization", "@id": "https://api.example/committees/a08", "name": "Haushaltsausschuss", "url": "https://www.bundestag.de/haushalt"}
+		]
+	}`
+
+	var got, expected any
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("%v: %s", err, rec.Body)
+	}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("JSON-LD =\n%s\nwant\n%s", rec.Body, want)
+	}
+}
+
+func TestPoliticianBirthDate(t *testing.T) {
+	m := NewMapper(rest.PublicBase{})
+	req := httptest.NewRequest(http.MethodGet, "/politicians/1", nil)
+	for dob, want := range map[string]string{"07.03.1970": "1970-03-07", "1970": "", "31.02.1970": "", "": ""} {
+		p := &v1.Politician{Bio: v1.PoliticianBio{DateOfBirth: dob}}
+		if got := m.Politician(req, p).BirthDate; got != want {
+			t.Errorf("birthDate of %q = %q, want %q", dob, got, want)
+		}
+	}
+}
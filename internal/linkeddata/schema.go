@@ -0,0 +1,57 @@
+package linkeddata
+
+// subset of the schema.org vocabulary used to describe MdBs and committees
+
+const schemaContext = "https://schema.org"
+
+type Person struct {
+	Type            string         `json:"@type"`
+	ID              string         `json:"@id"`
+	Name            string         `json:"name"`
+	GivenName       string         `json:"givenName,omitempty"`
+	FamilyName      string         `json:"familyName,omitempty"`
+	HonorificPrefix string         `json:"honorificPrefix,omitempty"`
+	BirthDate       string         `json:"birthDate,omitempty"`
+	Gender          string         `json:"gender,omitempty"`
+	JobTitle        string         `json:"jobTitle,omitempty"`
+	Telephone       string         `json:"telephone,omitempty"`
+	URL             string         `json:"url,omitempty"`
+	SameAs          []string       `json:"sameAs,omitempty"`
+	Image           *ImageObject   `json:"image,omitempty"`
+	Address         *PostalAddress `json:"address,omitempty"`
+	Affiliation     *Organization  `json:"affiliation,omitempty"`
+	MemberOf        []Organization `json:"memberOf,omitempty"`
+}
+
+type Organization struct {
+	Type               string        `json:"@type"`
+	ID                 string        `json:"@id,omitempty"`
+	Name               string        `json:"name"`
+	AlternateName      string        `json:"alternateName,omitempty"`
+	Description        string        `json:"description,omitempty"`
+	URL                string        `json:"url,omitempty"`
+	Image              *ImageObject  `json:"image,omitempty"`
+	ParentOrganization *Organization `json:"parentOrganization,omitempty"`
+	Member             []Person      `json:"member,omitempty"`
+}
+
+type ImageObject struct {
+	Type            string `json:"@type"`
+	ContentURL      string `json:"contentUrl"`
+	Caption         string `json:"caption,omitempty"`
+	CopyrightNotice string `json:"copyrightNotice,omitempty"`
+}
+
+type PostalAddress struct {
+	Type          string `json:"@type"`
+	StreetAddress string `json:"streetAddress"`
+	PostalCode    string `json:"postalCode"`
+	Locality      string `json:"addressLocality"`
+	Country       string `json:"addressCountry"`
+}
+
+// Graph holds the nodes of a list.
+type Graph[T any] struct {
+	Context string `json:"@context"`
+	Graph   []T    `json:"@graph"`
+}
@@ -0,0 +1,107 @@
+package links
+
+import (
+	"net/http"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+)
+
+// names of the routes links point to, registered in cmd/server.go
+const (
+	RoutePolitician           = "politician"
+	RoutePoliticianBio        = "politician.bio"
+	RoutePoliticianCommittees = "politician.committees"
+	RoutePoliticianPhoto      = "politician.photo"
+	RouteCommittee            = "committee"
+	RouteCommitteeDetail      = "committee.detail"
+	RouteCommitteeMembers     = "committee.members"
+)
+
+// link relations, see the Readme
+const (
+	RelSelf         = "self"
+	RelPolitician   = "politician"
+	RelBio          = "bio"
+	RelPhoto        = "photo"
+	RelCommittees   = "committees"
+	RelConstituency = "constituency"
+	RelCommittee    = "committee"
+	RelDetail       = "detail"
+	RelMembers      = "members"
+	RelUpstream     = "upstream"
+)
+
+const xmlType = "application/xml"
+
+// Builder lists the links of the v1 resources.
+type Builder struct {
+	routes *rest.Routes
+}
+
+func NewBuilder(routes *rest.Routes) *Builder {
+	return &Builder{routes: routes}
+}
+
+func (b *Builder) PersonListEntry(req *http.Request, p *v1.PersonListEntry) []rest.Link {
+	id := p.Id.Value
+	return rest.Links(
+		b.routes.Link(req, RelSelf, RoutePolitician, id),
+		b.routes.Link(req, RelBio, RoutePoliticianBio, id),
+		b.photo(req, id, p.PhotoURL),
+		b.routes.Link(req, RelCommittees, RoutePoliticianCommittees, id),
+		rest.Link{Link: p.Constituency.Url, Rel: RelConstituency},
+		rest.Link{Link: p.InfoXMLURL, Rel: RelUpstream, Type: xmlType},
+	)
+}
+
+func (b *Builder) Politician(req *http.Request, p *v1.Politician) []rest.Link {
+	id := p.Bio.Id.Value
+	return rest.Links(
+		b.routes.Link(req, RelSelf, RoutePoliticianBio, id),
+		b.routes.Link(req, RelPolitician, RoutePolitician, id),
+		b.photo(req, id, p.Media.Foto.URL),
+		b.routes.Link(req, RelCommittees, RoutePoliticianCommittees, id),
+		rest.Link{Link: p.Bio.Constituency.Url, Rel: RelConstituency},
+		rest.Link{Link: p.DocumentInfo.DocumentURL, Rel: RelUpstream, Type: xmlType},
+	)
+}
+
+// Committee links a committee an MdB is a member of. Other bodies, e.g.
+// parliamentary groups, have no id and only link to bundestag.de.
+func (b *Builder) Committee(req *http.Request, c *v1.Committee) []rest.Link {
+	if c.Id == "" {
+		return rest.Links(rest.Link{Link: c.Url, Rel: RelUpstream, Type: "text/html"})
+	}
+	return rest.Links(
+		b.routes.Link(req, RelSelf, RouteCommittee, c.Id),
+		b.routes.Link(req, RelDetail, RouteCommitteeDetail, c.Id),
+		b.routes.Link(req, RelMembers, RouteCommitteeMembers, c.Id),
+	)
+}
+
+func (b *Builder) CommitteeListEntry(req *http.Request, c *v1.CommitteeListEntry) []rest.Link {
+	return rest.Links(
+		b.routes.Link(req, RelSelf, RouteCommittee, c.Id),
+		b.routes.Link(req, RelDetail, RouteCommitteeDetail, c.Id),
+		b.routes.Link(req, RelMembers, RouteCommitteeMembers, c.Id),
+		rest.Link{Link: c.DetailXML, Rel: RelUpstream, Type: xmlType},
+	)
+}
+
+func (b *Builder) CommitteeDetails(req *http.Request, c *v1.CommitteeDetails) []rest.Link {
+	return rest.Links(
+		b.routes.Link(req, RelSelf, RouteCommitteeDetail, c.Id),
+		b.routes.Link(req, RelCommittee, RouteCommittee, c.Id),
+		b.routes.Link(req, RelMembers, RouteCommitteeMembers, c.Id),
+		rest.Link{Link: c.DocumentInfo.DocumentURL, Rel: RelUpstream, Type: xmlType},
+	)
+}
+
+// photo links the photo endpoint, if the MdB has a photo at all.
+func (b *Builder) photo(req *http.Request, id, photoURL string) rest.Link {
+	if photoURL == "" {
+		return rest.Link{Rel: RelPhoto}
+	}
+	return b.routes.Link(req, RelPhoto, RoutePoliticianPhoto, id)
+}
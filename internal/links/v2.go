@@ -0,0 +1,85 @@
+package links
+
+import (
+	"net/http"
+
+	v2 "github.com/kyzrfranz/bundestag-api/api/v2"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+)
+
+// BuilderV2 lists the links of the v2 resources. Its routes are registered
+// under the same names as the v1 routes.
+type BuilderV2 struct {
+	routes *rest.Routes
+	v1     *Builder
+}
+
+// NewBuilderV2 links photos via v1, which serves them.
+func NewBuilderV2(routes *rest.Routes, v1 *Builder) *BuilderV2 {
+	return &BuilderV2{routes: routes, v1: v1}
+}
+
+func (b *BuilderV2) Politician(req *http.Request, p *v2.Politician) []rest.Link {
+	return rest.Links(
+		b.routes.Link(req, RelSelf, RoutePolitician, p.ID),
+		b.routes.Link(req, RelBio, RoutePoliticianBio, p.ID),
+		b.v1.photo(req, p.ID, photoURL(p.Photo)),
+		b.routes.Link(req, RelCommittees, RoutePoliticianCommittees, p.ID),
+		constituency(p.Constituency),
+		rest.Link{Link: p.SourceURL, Rel: RelUpstream, Type: xmlType},
+	)
+}
+
+func (b *BuilderV2) Bio(req *http.Request, p *v2.Bio) []rest.Link {
+	return rest.Links(
+		b.routes.Link(req, RelSelf, RoutePoliticianBio, p.ID),
+		b.routes.Link(req, RelPolitician, RoutePolitician, p.ID),
+		b.v1.photo(req, p.ID, photoURL(p.Photo)),
+		b.routes.Link(req, RelCommittees, RoutePoliticianCommittees, p.ID),
+		constituency(p.Constituency),
+		rest.Link{Link: p.SourceURL, Rel: RelUpstream, Type: xmlType},
+	)
+}
+
+func (b *BuilderV2) Membership(req *http.Request, m *v2.Membership) []rest.Link {
+	if m.CommitteeID == "" {
+		return rest.Links(rest.Link{Link: m.URL, Rel: RelUpstream, Type: "text/html"})
+	}
+	return rest.Links(
+		b.routes.Link(req, RelCommittee, RouteCommittee, m.CommitteeID),
+		b.routes.Link(req, RelDetail, RouteCommitteeDetail, m.CommitteeID),
+		b.routes.Link(req, RelMembers, RouteCommitteeMembers, m.CommitteeID),
+	)
+}
+
+func (b *BuilderV2) Committee(req *http.Request, c *v2.Committee) []rest.Link {
+	return rest.Links(
+		b.routes.Link(req, RelSelf, RouteCommittee, c.ID),
+		b.routes.Link(req, RelDetail, RouteCommitteeDetail, c.ID),
+		b.routes.Link(req, RelMembers, RouteCommitteeMembers, c.ID),
+		rest.Link{Link: c.SourceURL, Rel: RelUpstream, Type: xmlType},
+	)
+}
+
+func (b *BuilderV2) CommitteeDetails(req *http.Request, c *v2.CommitteeDetails) []rest.Link {
+	return rest.Links(
+		b.routes.Link(req, RelSelf, RouteCommitteeDetail, c.ID),
+		b.routes.Link(req, RelCommittee, RouteCommittee, c.ID),
+		b.routes.Link(req, RelMembers, RouteCommitteeMembers, c.ID),
+		rest.Link{Link: c.SourceURL, Rel: RelUpstream, Type: xmlType},
+	)
+}
+
+func photoURL(photo *v2.Image) string {
+	if photo == nil {
+		return ""
+	}
+	return photo.URL
+}
+
+func constituency(c *v2.Constituency) rest.Link {
+	if c == nil {
+		return rest.Link{Rel: RelConstituency}
+	}
+	return rest.Link{Link: c.URL, Rel: RelConstituency}
+}
@@ -1,6 +1,7 @@
 package proxy
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,6 +9,8 @@ import (
 	"strings"
 
 	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	v2 "github.com/kyzrfranz/bundestag-api/api/v2"
+	"github.com/kyzrfranz/bundestag-api/internal/export"
 	myHttp "github.com/kyzrfranz/bundestag-api/internal/http"
 	"github.com/kyzrfranz/bundestag-api/internal/rest"
 	"github.com/kyzrfranz/bundestag-api/pkg/resources"
@@ -24,10 +27,17 @@ type SearchResult struct {
 type ConstProxy struct {
 	proxyUrl string
 	repo     resources.Repository[v1.PersonListEntry]
+	reps     []rest.Representation[v1.PersonListEntry]
 }
 
-func NewConstituencyProxy(proxyUrl string, politiciansRepo resources.Repository[v1.PersonListEntry]) *ConstProxy {
-	return &ConstProxy{proxyUrl: proxyUrl, repo: politiciansRepo}
+// NewConstituencyProxy offers the politicians of a constituency in reps in
+// addition to JSON, XML, CSV and XLSX.
+func NewConstituencyProxy(proxyUrl string, politiciansRepo resources.Repository[v1.PersonListEntry], reps ...rest.Representation[v1.PersonListEntry]) *ConstProxy {
+	return &ConstProxy{
+		proxyUrl: proxyUrl,
+		repo:     politiciansRepo,
+		reps:     append(export.Representations(export.PersonListEntries), reps...),
+	}
 }
 
 func (proxy *ConstProxy) ConstituencySearch(w http.ResponseWriter, req *http.Request) {
@@ -38,11 +48,7 @@ func (proxy *ConstProxy) ConstituencySearch(w http.ResponseWriter, req *http.Req
 		return
 	}
 
-	if err := rest.MarshalResponse(w, constituencies); err != nil {
-		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
-		return
-	}
-
+	rest.ServeList(w, req, constituencies, export.Representations(export.Constituencies)...)
 }
 
 func (proxy *ConstProxy) ConstituencyPoliticianSearch(w http.ResponseWriter, req *http.Request) {
@@ -53,18 +59,54 @@ func (proxy *ConstProxy) ConstituencyPoliticianSearch(w http.ResponseWriter, req
 		return
 	}
 
-	politicians := proxy.repo.List(req.Context())
-	result := lo.Filter(politicians, func(p v1.PersonListEntry, _ int) bool {
+	rest.ServeList(w, req, proxy.politiciansIn(req.Context(), constituencies), proxy.reps...)
+}
+
+// ConstituencySearchV2 serves the constituencies of a zipcode in the v2 model.
+func (proxy *ConstProxy) ConstituencySearchV2(w http.ResponseWriter, req *http.Request) {
+	constituencies, status := proxy.readConsituencies(req.PathValue("zipcode"))
+	if status != http.StatusOK {
+		http.Error(w, "Failed to read constituencies", status)
+		return
+	}
+
+	rest.ServeList(w, req, lo.Map(constituencies, func(c v1.Constituency, _ int) v2.Constituency {
+		return v2.Constituency{Number: c.Number, Name: c.Name, URL: c.Url}
+	}))
+}
+
+// ConstituencyPoliticianSearchV2 serves the politicians of the constituencies
+// of a zipcode in the v2 model, offered in reps in addition to JSON and XML.
+func (proxy *ConstProxy) ConstituencyPoliticianSearchV2(reps ...rest.Representation[v2.Politician]) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		constituencies, status := proxy.readConsituencies(req.PathValue("zipcode"))
+		if status != http.StatusOK {
+			http.Error(w, "Failed to read constituencies", status)
+			return
+		}
+
+		rest.ServeList(w, req, lo.Map(proxy.politiciansIn(req.Context(), constituencies), func(p v1.PersonListEntry, _ int) v2.Politician {
+			return *v2.FromPersonListEntry(&p)
+		}), reps...)
+	}
+}
+
+func (proxy *ConstProxy) politiciansIn(ctx context.Context, constituencies []v1.Constituency) []v1.PersonListEntry {
+	return lo.Filter(proxy.repo.List(ctx), func(p v1.PersonListEntry, _ int) bool {
 		//filter all p.Constituency.Number iin constituencies
 		return lo.SomeBy(constituencies, func(c v1.Constituency) bool {
 			return c.Number == p.Constituency.Number
 		})
 	})
+}
 
-	if err := rest.MarshalResponse(w, result); err != nil {
-		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
-		return
+// Constituencies finds the constituencies of a zipcode.
+func (proxy *ConstProxy) Constituencies(ctx context.Context, zipcode string) ([]v1.Constituency, error) {
+	constituencies, status := proxy.readConsituencies(zipcode)
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to read constituencies: %s", http.StatusText(status))
 	}
+	return constituencies, nil
 }
 
 func (proxy *ConstProxy) readConsituencies(zipcode string) ([]v1.Constituency, int) {
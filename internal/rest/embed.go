@@ -0,0 +1,170 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// Embed prepares to expand res, e.g. by reading the bios of all catalog
+// entries at once, and returns the function fetching the resource related to
+// one of them.
+type Embed[T any] func(req *http.Request, res []T) func(res *T) (any, error)
+
+// WithEmbed lets ?embed=name expand every resource with the result of embed.
+// Expanded resources are offered as JSON and NDJSON, and in the
+// representations added with WithEmbeddedRepresentation.
+func WithEmbed[T any](name string, embed Embed[T]) HandlerOption[T] {
+	return func(h *genericHandler[T]) {
+		h.embeds[name] = embed
+	}
+}
+
+// WithEmbeddedRepresentation offers reps for expanded resources in addition
+// to JSON and NDJSON, e.g. exports with columns of the embeds.
+func WithEmbeddedRepresentation[T any](reps ...Representation[Embedded[T]]) HandlerOption[T] {
+	return func(h *genericHandler[T]) {
+		for _, r := range reps {
+			h.embeddedRepresentations = withRepresentation(h.embeddedRepresentations, r)
+		}
+	}
+}
+
+// Embedded is a resource with related resources added as fields of its JSON
+// object.
+type Embedded[T any] struct {
+	Resource T
+	Embeds   map[string]any
+}
+
+func (e Embedded[T]) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(e.Resource)
+	if err != nil || len(e.Embeds) == 0 || len(data) < 2 || data[0] != '{' {
+		return data, err
+	}
+
+	names := lo.Keys(e.Embeds)
+	slices.Sort(names)
+
+	out := data[:len(data)-1]
+	for i, name := range names {
+		value, err := json.Marshal(e.Embeds[name])
+		if err != nil {
+			return nil, err
+		}
+		key, _ := json.Marshal(name)
+		if i > 0 || len(out) > 1 {
+			out = append(out, ',')
+		}
+		out = append(append(append(out, key...), ':'), value...)
+	}
+	return append(out, '}'), nil
+}
+
+func (r genericHandler[T]) requestedEmbeds(req *http.Request) ([]string, error) {
+	var names []string
+	for _, param := range req.URL.Query()["embed"] {
+		for _, name := range strings.Split(param, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" || slices.Contains(names, name) {
+				continue
+			}
+			if _, ok := r.embeds[name]; !ok {
+				return nil, fmt.Errorf("unknown embed: %s", name)
+			}
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// embedder prepares the embeds names for res and returns the function
+// expanding one of them. A failing embed is left empty rather than failing
+// the whole list.
+func (r genericHandler[T]) embedder(req *http.Request, res []T, names []string) func(res *T) Embedded[T] {
+	embeds := make(map[string]func(res *T) (any, error), len(names))
+	for _, name := range names {
+		embeds[name] = r.embeds[name](req, res)
+	}
+
+	return func(res *T) Embedded[T] {
+		e := Embedded[T]{Resource: *res, Embeds: make(map[string]any, len(names))}
+		for _, name := range names {
+			v, err := embeds[name](res)
+			if err != nil {
+				fmt.Printf("failed to embed %s: %v\n", name, err)
+				v = nil
+			}
+			e.Embeds[name] = v
+		}
+		if r.linker != nil {
+			e.Embeds["_links"] = r.linker(req, res)
+		}
+		return e
+	}
+}
+
+// selectEmbeddedRepresentation negotiates among the representations of
+// expanded resources. Other formats of the handler, e.g. the image, are
+// refused instead of silently falling back to JSON.
+func (r genericHandler[T]) selectEmbeddedRepresentation(w http.ResponseWriter, req *http.Request, many bool) (Representation[Embedded[T]], bool) {
+	reps := lo.Filter(r.embeddedRepresentations, func(rep Representation[Embedded[T]], _ int) bool {
+		return (many && rep.Many != nil) || (!many && rep.One != nil)
+	})
+
+	format := req.URL.Query().Get("format")
+	offered := slices.ContainsFunc(r.representations, func(rep Representation[T]) bool {
+		return rep.Format != "" && strings.EqualFold(rep.Format, format)
+	})
+	embeddable := slices.ContainsFunc(reps, func(rep Representation[Embedded[T]]) bool {
+		return strings.EqualFold(rep.Format, format)
+	})
+	if offered && !embeddable {
+		http.Error(w, "Format "+format+" is not available with embed", http.StatusBadRequest)
+		return Representation[Embedded[T]]{}, false
+	}
+
+	return selectRepresentation(w, req, reps)
+}
+
+// listEmbedded expands the resources one by one while they are written, the
+// embeds are prepared once for the whole list.
+func (r genericHandler[T]) listEmbedded(w http.ResponseWriter, req *http.Request, names []string) {
+	rep, ok := r.selectEmbeddedRepresentation(w, req, true)
+	if !ok {
+		return
+	}
+
+	res, ok := r.list(w, req)
+	if !ok {
+		return
+	}
+
+	embed := r.embedder(req, res, names)
+	rep.Many(w, req, func(yield func(Embedded[T]) bool) {
+		for i := range res {
+			if !yield(embed(&res[i])) {
+				return
+			}
+		}
+	})
+}
+
+func (r genericHandler[T]) getEmbedded(w http.ResponseWriter, req *http.Request, names []string) {
+	rep, ok := r.selectEmbeddedRepresentation(w, req, false)
+	if !ok {
+		return
+	}
+
+	res, ok := r.get(w, req)
+	if !ok {
+		return
+	}
+
+	e := r.embedder(req, []T{*res}, names)(res)
+	rep.One(w, req, &e)
+}
@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type item struct {
+	ID   string `json:"id" xml:"id"`
+	Name string `json:"name" xml:"name"`
+}
+
+// sliceRepo serves items, Get finds them by ID.
+type sliceRepo []item
+
+func (s sliceRepo) List(ctx context.Context) []item { return s }
+
+func (s sliceRepo) Get(ctx context.Context, id string) (*item, error) {
+	for i := range s {
+		if s[i].ID == id {
+			return &s[i], nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (s sliceRepo) Delete(ctx context.Context, id string) error                 { return nil }
+func (s sliceRepo) Create(ctx context.Context, i *item) (*item, error)          { return i, nil }
+func (s sliceRepo) Update(ctx context.Context, _ *item, i *item) (*item, error) { return i, nil }
+func (s sliceRepo) Name() string                                                { return "items" }
+
+func TestEmbeddedMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		e    Embedded[item]
+		want string
+	}{
+		{"none", Embedded[item]{Resource: item{ID: "1"}}, `{"id":"1","name":""}`},
+		{"sorted", Embedded[item]{Resource: item{ID: "1"}, Embeds: map[string]any{"z": 1, "bio": nil}},
+			`{"id":"1","name":"","bio":null,"z":1}`},
+		{"not an object", Embedded[item]{}, `{"id":"","name":""}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.e)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("Marshal = %s, %v, want %s", got, err, tt.want)
+			}
+		})
+	}
+
+	got, err := json.Marshal(Embedded[string]{Resource: "x", Embeds: map[string]any{"a": 1}})
+	if err != nil || string(got) != `"x"` {
+		t.Errorf("Marshal of a string = %s, %v, want \"x\"", got, err)
+	}
+}
+
+func TestListEmbedded(t *testing.T) {
+	repo := sliceRepo{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}, {ID: "3", Name: "c"}}
+	prepared, size := 0, 0
+	h := NewHandler[item](repo, WithEmbed("upper", func(req *http.Request, res []item) func(*item) (any, error) {
+		prepared, size = prepared+1, len(res)
+		return func(i *item) (any, error) {
+			if i.ID == "2" {
+				return nil, errors.New("failed")
+			}
+			return i.Name + "!", nil
+		}
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/items?embed=upper", nil)
+	w := httptest.NewRecorder()
+	h.List(w, req)
+
+	if prepared != 1 || size != len(repo) {
+		t.Errorf("embed prepared %d times for %d items, want once for %d", prepared, size, len(repo))
+	}
+	want := `[{"id":"1","name":"a","upper":"a!"},{"id":"2","name":"b","upper":null},{"id":"3","name":"c","upper":"c!"}]`
+	if got := w.Body.String(); got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/items/3?embed=upper", nil)
+	req.SetPathValue("id", "3")
+	w = httptest.NewRecorder()
+	h.Get(w, req)
+	if got := w.Body.String(); got != `{"id":"3","name":"c","upper":"c!"}` {
+		t.Errorf("body = %s", got)
+	}
+}
+
+func TestListEmbeddedErrors(t *testing.T) {
+	h := NewHandler[item](sliceRepo{}, WithEmbed("upper", func(*http.Request, []item) func(*item) (any, error) {
+		return func(*item) (any, error) { return nil, nil }
+	}), WithRepresentation(Representation[item]{MediaType: "text/csv", Format: "csv"}))
+
+	for target, want := range map[string]int{
+		"/items?embed=unknown":            http.StatusBadRequest,
+		"/items?embed=upper&format=csv":   http.StatusBadRequest,
+		"/items?embed=upper&format=latex": http.StatusNotAcceptable,
+		"/items?embed=upper":              http.StatusOK,
+	} {
+		w := httptest.NewRecorder()
+		h.List(w, httptest.NewRequest(http.MethodGet, target, nil))
+		if w.Code != want {
+			t.Errorf("%s = %d, want %d", target, w.Code, want)
+		}
+	}
+}
+
+func TestEmbeddedRepresentation(t *testing.T) {
+	names := Representation[Embedded[item]]{
+		MediaType: "text/plain",
+		Format:    "txt",
+		Many: func(w http.ResponseWriter, req *http.Request, res iter.Seq[Embedded[item]]) {
+			for e := range res {
+				fmt.Fprintf(w, "%s %v\n", e.Resource.Name, e.Embeds["upper"])
+			}
+		},
+	}
+	h := NewHandler[item](sliceRepo{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}},
+		WithEmbed("upper", func(*http.Request, []item) func(*item) (any, error) {
+			return func(i *item) (any, error) { return strings.ToUpper(i.Name), nil }
+		}),
+		WithEmbeddedRepresentation(names))
+
+	w := httptest.NewRecorder()
+	h.List(w, httptest.NewRequest(http.MethodGet, "/items?embed=upper&format=txt", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "a A\nb B\n" {
+		t.Errorf("list = %d %q", w.Code, w.Body)
+	}
+
+	// only offered for lists
+	req := httptest.NewRequest(http.MethodGet, "/items/1?embed=upper&format=txt", nil)
+	req.SetPathValue("id", "1")
+	w = httptest.NewRecorder()
+	h.Get(w, req)
+	if w.Code != http.StatusNotAcceptable {
+		t.Errorf("get = %d, want %d", w.Code, http.StatusNotAcceptable)
+	}
+}
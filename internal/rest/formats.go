@@ -0,0 +1,183 @@
+package rest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"slices"
+
+	"github.com/kyzrfranz/bundestag-api/internal/img"
+)
+
+// lines between flushes of streamed lists
+const flushEvery = 64
+
+// JSON writes lists element by element, so only one element is held as
+// encoded JSON at a time.
+func JSON[T any]() Representation[T] {
+	return Representation[T]{
+		MediaType: "application/json",
+		Format:    "json",
+		One: func(w http.ResponseWriter, req *http.Request, res *T) {
+			if err := MarshalResponse(w, http.StatusOK, res); err != nil {
+				http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+			}
+		},
+		Many: func(w http.ResponseWriter, req *http.Request, res iter.Seq[T]) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			if err := streamJSON(w, res, true); err != nil {
+				fmt.Printf("failed to stream json: %v\n", err)
+			}
+		},
+	}
+}
+
+// NDJSON writes one JSON object per line and flushes regularly, so clients
+// can process lists while they are still being read.
+func NDJSON[T any]() Representation[T] {
+	return Representation[T]{
+		MediaType: "application/x-ndjson",
+		Format:    "ndjson",
+		One: func(w http.ResponseWriter, req *http.Request, res *T) {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+			if err := streamJSON(w, slices.Values([]T{*res}), false); err != nil {
+				fmt.Printf("failed to stream ndjson: %v\n", err)
+			}
+		},
+		Many: func(w http.ResponseWriter, req *http.Request, res iter.Seq[T]) {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.Header().Set("X-Accel-Buffering", "no")
+			w.WriteHeader(http.StatusOK)
+			if err := streamJSON(w, res, false); err != nil {
+				fmt.Printf("failed to stream ndjson: %v\n", err)
+			}
+		},
+	}
+}
+
+// streamJSON writes the elements of res as JSON array or, if not array, as
+// one line each. The status is sent already, an error can only cut the
+// response short.
+func streamJSON[T any](w http.ResponseWriter, res iter.Seq[T], array bool) error {
+	rc := http.NewResponseController(w)
+
+	if array {
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+	}
+	n := 0
+	for r := range res {
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		if array && n > 0 {
+			data = append([]byte(","), data...)
+		} else if !array {
+			data = append(data, '\n')
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		if n++; n%flushEvery == 0 {
+			if err := rc.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if array {
+		_, err := io.WriteString(w, "]")
+		return err
+	}
+	return nil
+}
+
+// XML uses the upstream element names of the v1 types.
+func XML[T any]() Representation[T] {
+	return Representation[T]{
+		MediaType: "application/xml",
+		Format:    "xml",
+		One: func(w http.ResponseWriter, req *http.Request, res *T) {
+			if err := marshalXML(w, res); err != nil {
+				http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+			}
+		},
+		Many: func(w http.ResponseWriter, req *http.Request, res iter.Seq[T]) {
+			w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			if err := streamXML(w, res); err != nil {
+				fmt.Printf("failed to stream xml: %v\n", err)
+			}
+		},
+	}
+}
+
+// streamXML writes the elements of res as <item>s of a <list>, like JSON one
+// at a time.
+func streamXML[T any](w http.ResponseWriter, res iter.Seq[T]) error {
+	rc := http.NewResponseController(w)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	list := xml.StartElement{Name: xml.Name{Local: "list"}}
+	if err := enc.EncodeToken(list); err != nil {
+		return err
+	}
+	n := 0
+	for r := range res {
+		if err := enc.EncodeElement(r, xml.StartElement{Name: xml.Name{Local: "item"}}); err != nil {
+			return err
+		}
+		if n++; n%flushEvery == 0 {
+			if err := enc.Flush(); err != nil {
+				return err
+			}
+			if err := rc.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := enc.EncodeToken(list.End()); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+func marshalXML(w http.ResponseWriter, res any) error {
+	data, err := xml.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// WebP serves the image of a single resource as WebP, converted by pipeline.
+func WebP[T img.Source](pipeline *img.Pipeline) Representation[T] {
+	return Representation[T]{
+		MediaType: img.FormatWebP.MediaType(),
+		Format:    string(img.FormatWebP),
+		One: func(w http.ResponseWriter, req *http.Request, res *T) {
+			im, err := pipeline.EnsureImage(req.Context(), *res, req.PathValue("id"), img.Variant{Format: img.FormatWebP})
+			if err != nil {
+				imageError(w, err)
+				return
+			}
+			ServeImage(w, req, pipeline, im)
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func serveMany(rep Representation[item], items []item) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	rep.Many(w, httptest.NewRequest(http.MethodGet, "/items", nil), slices.Values(items))
+	return w
+}
+
+func manyItems(n int) []item {
+	items := make([]item, n)
+	for i := range items {
+		items[i] = item{ID: string(rune('a' + i%26)), Name: "<&>"}
+	}
+	return items
+}
+
+func TestJSONMany(t *testing.T) {
+	if got := serveMany(JSON[item](), nil).Body.String(); got != "[]" {
+		t.Errorf("empty list = %s, want []", got)
+	}
+
+	items := manyItems(flushEvery*2 + 1)
+	var got []item
+	if err := json.Unmarshal(serveMany(JSON[item](), items).Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, items) {
+		t.Error("streamed list differs")
+	}
+}
+
+func TestNDJSONMany(t *testing.T) {
+	items := manyItems(3)
+	w := serveMany(NDJSON[item](), items)
+	if got := w.Header().Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("Content-Type = %s", got)
+	}
+
+	var got []item
+	sc := bufio.NewScanner(w.Body)
+	for sc.Scan() {
+		var i item
+		if err := json.Unmarshal(sc.Bytes(), &i); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, i)
+	}
+	if !slices.Equal(got, items) {
+		t.Errorf("lines = %v, want %v", got, items)
+	}
+}
+
+func TestXMLMany(t *testing.T) {
+	type list struct {
+		XMLName xml.Name `xml:"list"`
+		Items   []item   `xml:"item"`
+	}
+
+	for _, items := range [][]item{nil, manyItems(1), manyItems(flushEvery*2 + 1)} {
+		w := serveMany(XML[item](), items)
+		if got := w.Header().Get("Content-Type"); got != "application/xml; charset=utf-8" {
+			t.Errorf("Content-Type = %s", got)
+		}
+
+		// the same as the whole list marshalled at once
+		want, err := xml.Marshal(list{Items: items})
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, ok := strings.CutPrefix(w.Body.String(), xml.Header)
+		if !ok || body != string(want) {
+			t.Errorf("body of %d items = %s, want %s", len(items), w.Body.String(), want)
+		}
+	}
+}
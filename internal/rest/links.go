@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Routes names the patterns handlers are registered with, so links can be
+// built from them instead of spelling out paths a second time.
+type Routes struct {
+	base     PublicBase
+	patterns map[string]string
+}
+
+// NewRoutes builds links below base.
+func NewRoutes(base PublicBase) *Routes {
+	return &Routes{base: base, patterns: map[string]string{}}
+}
+
+// Route names pattern and returns it unchanged, to be passed on to the mux.
+func (r *Routes) Route(name, pattern string) string {
+	r.patterns[name] = pattern
+	return pattern
+}
+
+// URL expands the named route with values for its wildcards, in the order
+// they appear in the pattern.
+func (r *Routes) URL(req *http.Request, name string, values ...string) (*url.URL, error) {
+	pattern, ok := r.patterns[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown route %s", name)
+	}
+	// drop the method of patterns like "GET /webhooks/{id}"
+	if _, path, ok := strings.Cut(pattern, " "); ok {
+		pattern = path
+	}
+
+	segments := strings.Split(strings.Trim(pattern, "/"), "/")
+	for i, s := range segments {
+		if !strings.HasPrefix(s, "{") {
+			continue
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("missing value for %s in route %s", s, name)
+		}
+		segments[i], values = values[0], values[1:]
+	}
+	return r.base.Of(req).JoinPath(segments...), nil
+}
+
+// Link points to the named route. Links to routes that aren't registered
+// are left out, see Links.
+func (r *Routes) Link(req *http.Request, rel, name string, values ...string) Link {
+	u, err := r.URL(req, name, values...)
+	if err != nil {
+		fmt.Printf("failed to build %s link: %v\n", rel, err)
+		return Link{Rel: rel}
+	}
+	return Link{Link: u.String(), Rel: rel}
+}
+
+// Links drops links without a target, e.g. upstream URLs missing from the
+// data.
+func Links(links ...Link) []Link {
+	result := make([]Link, 0, len(links))
+	for _, l := range links {
+		if l.Link != "" {
+			result = append(result, l)
+		}
+	}
+	return result
+}
+
+// Linker lists the links of a resource.
+type Linker[T any] func(req *http.Request, res *T) []Link
+
+// WithLinks adds the links of every resource as _links to its JSON and
+// NDJSON representations, including expanded resources.
+func WithLinks[T any](linker Linker[T]) HandlerOption[T] {
+	return func(h *genericHandler[T]) {
+		h.linker = linker
+		for _, rep := range Linked(linker) {
+			h.representations = withRepresentation(h.representations, rep)
+		}
+	}
+}
+
+// Linked offers JSON and NDJSON with the links of every resource as _links,
+// for handlers not built with NewHandler.
+func Linked[T any](linker Linker[T]) []Representation[T] {
+	link := func(req *http.Request, res *T) Embedded[T] {
+		return Embedded[T]{Resource: *res, Embeds: map[string]any{"_links": linker(req, res)}}
+	}
+	return []Representation[T]{adapt(JSON[Embedded[T]](), link), adapt(NDJSON[Embedded[T]](), link)}
+}
+
+// adapt offers rep for resources of another type by converting them while
+// they are written.
+func adapt[T, U any](rep Representation[U], convert func(req *http.Request, res *T) U) Representation[T] {
+	return Representation[T]{
+		MediaType: rep.MediaType,
+		Format:    rep.Format,
+		One: func(w http.ResponseWriter, req *http.Request, res *T) {
+			u := convert(req, res)
+			rep.One(w, req, &u)
+		},
+		Many: func(w http.ResponseWriter, req *http.Request, res iter.Seq[T]) {
+			rep.Many(w, req, func(yield func(U) bool) {
+				for r := range res {
+					if !yield(convert(req, &r)) {
+						return
+					}
+				}
+			})
+		},
+	}
+}
@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestPublicBase(t *testing.T) {
+	configured, _ := url.Parse("https://api.example.org/bundestag")
+
+	tests := []struct {
+		name      string
+		base      PublicBase
+		tls       bool
+		forwarded map[string]string
+		want      string
+	}{
+		{"request", PublicBase{}, false, nil, "http://api.local"},
+		{"tls", PublicBase{}, true, nil, "https://api.local"},
+		{"forwarded untrusted", PublicBase{}, false, map[string]string{"X-Forwarded-Host": "evil.example", "X-Forwarded-Proto": "https"}, "http://api.local"},
+		{"forwarded", PublicBase{Forwarded: true}, false, map[string]string{"X-Forwarded-Host": "api.example.org, internal", "X-Forwarded-Proto": "https"}, "https://api.example.org"},
+		{"forwarded invalid proto", PublicBase{Forwarded: true}, true, map[string]string{"X-Forwarded-Proto": "gopher"}, "https://api.local"},
+		{"configured", PublicBase{URL: configured, Forwarded: true}, false, map[string]string{"X-Forwarded-Host": "evil.example"}, "https://api.example.org/bundestag"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://api.local/politicians", nil)
+			if tt.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			for k, v := range tt.forwarded {
+				req.Header.Set(k, v)
+			}
+			if got := tt.base.Of(req).String(); got != tt.want {
+				t.Errorf("Of = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicBaseVary(t *testing.T) {
+	configured, _ := url.Parse("https://api.example.org")
+	for base, want := range map[PublicBase]string{
+		{}:                                 "",
+		{Forwarded: true}:                  "X-Forwarded-Host, X-Forwarded-Proto",
+		{URL: configured, Forwarded: true}: "",
+	} {
+		w := httptest.NewRecorder()
+		base.Vary(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		if got := w.Header().Get("Vary"); got != want {
+			t.Errorf("Vary of %+v = %q, want %q", base, got, want)
+		}
+	}
+}
+
+func TestRoutesURL(t *testing.T) {
+	configured, _ := url.Parse("https://api.example.org/v1")
+	routes := NewRoutes(PublicBase{URL: configured})
+	routes.Route("politician", "/politicians/{id}")
+	routes.Route("webhook", "GET /webhooks/{id}")
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if u, err := routes.URL(req, "politician", "1001"); err != nil || u.String() != "https://api.example.org/v1/politicians/1001" {
+		t.Errorf("URL = %v, %v", u, err)
+	}
+	if u, err := routes.URL(req, "webhook", "7"); err != nil || u.String() != "https://api.example.org/v1/webhooks/7" {
+		t.Errorf("URL = %v, %v", u, err)
+	}
+	if _, err := routes.URL(req, "politician"); err == nil {
+		t.Error("URL without a value succeeded")
+	}
+	if _, err := routes.URL(req, "unknown"); err == nil {
+		t.Error("URL of an unknown route succeeded")
+	}
+}
@@ -0,0 +1,151 @@
+package rest
+
+import (
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MediaRange is a single entry of an Accept header.
+type MediaRange struct {
+	Type    string
+	Subtype string
+	Params  map[string]string
+	Q       float64
+}
+
+// ParseAccept parses an Accept header into its media ranges, ordered by
+// preference. Malformed entries are skipped.
+func ParseAccept(header string) []MediaRange {
+	var ranges []MediaRange
+	for _, entry := range strings.Split(header, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		mediaType, params, err := mime.ParseMediaType(entry)
+		if err != nil {
+			continue
+		}
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok || (typ == "*" && subtype != "*") {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil || q < 0 || q > 1 {
+				continue
+			}
+			delete(params, "q")
+		}
+
+		ranges = append(ranges, MediaRange{Type: typ, Subtype: subtype, Params: params, Q: q})
+	}
+
+	slices.SortStableFunc(ranges, func(a, b MediaRange) int {
+		if a.Q != b.Q {
+			if a.Q > b.Q {
+				return -1
+			}
+			return 1
+		}
+		return b.specificity() - a.specificity()
+	})
+	return ranges
+}
+
+// Matches reports whether mediaType falls into the range.
+func (m MediaRange) Matches(mediaType string) bool {
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	return (m.Type == "*" || m.Type == typ) && (m.Subtype == "*" || m.Subtype == subtype)
+}
+
+func (m MediaRange) specificity() int {
+	switch {
+	case m.Type == "*":
+		return 0
+	case m.Subtype == "*":
+		return 1
+	case len(m.Params) == 0:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// quality returns the q-value the most specific matching range assigns to
+// mediaType, or -1 if no range matches. With explicit, only a range naming
+// mediaType counts.
+func quality(ranges []MediaRange, mediaType string, explicit bool) float64 {
+	q, specificity := -1.0, -1
+	for _, r := range ranges {
+		if r.Matches(mediaType) && r.specificity() > specificity {
+			q, specificity = r.Q, r.specificity()
+		}
+	}
+	if explicit && specificity < 2 {
+		return -1
+	}
+	return q
+}
+
+// Negotiate picks the offered media type that best matches the Accept header
+// of req, see Select. If nothing is acceptable, Negotiate answers with 406 Not
+// Acceptable and returns false.
+func Negotiate(w http.ResponseWriter, req *http.Request, offers ...string) (string, bool) {
+	w.Header().Add("Vary", "Accept")
+
+	best, ok := Select(req.Header.Get("Accept"), offers...)
+	if !ok {
+		http.Error(w, "Not acceptable, available: "+strings.Join(offers, ", "), http.StatusNotAcceptable)
+		return "", false
+	}
+	return best, true
+}
+
+// Select picks the offered media type that best matches header. The first
+// offer is the default: it is selected without an Accept header, wins ties
+// and is the only one selected by a wildcard, unless it isn't acceptable at
+// all. The others need to be named with a higher q-value, so browsers, which
+// accept */* and name XML or images for embedded content, get the default.
+// For the same reason a header preferring text/html, which no offer matches,
+// selects the default.
+func Select(header string, offers ...string) (string, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+	if strings.TrimSpace(header) == "" {
+		return offers[0], true
+	}
+
+	ranges := ParseAccept(header)
+	if len(ranges) > 0 && ranges[0].Type == "text" && ranges[0].Subtype == "html" && ranges[0].Q > 0 &&
+		!slices.Contains(offers, "text/html") {
+		return offers[0], true
+	}
+
+	best, bestQ := "", max(quality(ranges, offers[0], false), 0)
+	if bestQ > 0 {
+		best = offers[0]
+	}
+	for _, offer := range offers[1:] {
+		if q := quality(ranges, offer, true); q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	if best != "" {
+		return best, true
+	}
+
+	// only wildcards match, e.g. image/* for images
+	for _, offer := range offers[1:] {
+		if q := quality(ranges, offer, false); q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best, best != ""
+}
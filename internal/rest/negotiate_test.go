@@ -0,0 +1,115 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	firefox       = "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+	firefoxRecent = "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8"
+	chrome        = "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.7"
+	safari        = "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+	chromeImage   = "image/avif,image/webp,image/apng,image/svg+xml,image/*,*/*;q=0.8"
+	firefoxImage  = "image/avif,image/webp,image/png,image/svg+xml,image/*;q=0.8,*/*;q=0.5"
+	safariImage   = "image/webp,image/avif,image/jxl,image/heic,image/heic-sequence,video/*;q=0.8,image/png,image/svg+xml,image/*;q=0.8,*/*;q=0.5"
+	axios         = "application/json, text/plain, */*"
+)
+
+// offers of a catalog entry, in the order of the handler
+var entryOffers = []string{"application/json", "application/xml", "application/x-ndjson", "image/webp", "text/csv",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/ld+json"}
+
+func TestSelect(t *testing.T) {
+	photoOffers := []string{"image/webp", "image/jpeg", "image/png"}
+
+	tests := []struct {
+		name   string
+		header string
+		offers []string
+		want   string
+	}{
+		{"no header", "", entryOffers, "application/json"},
+		{"firefox page", firefox, entryOffers, "application/json"},
+		{"recent firefox page", firefoxRecent, entryOffers, "application/json"},
+		{"chrome page", chrome, entryOffers, "application/json"},
+		{"safari page", safari, entryOffers, "application/json"},
+		{"chrome image", chromeImage, entryOffers, "image/webp"},
+		{"firefox image", firefoxImage, entryOffers, "image/webp"},
+		{"safari image", safariImage, entryOffers, "image/webp"},
+		{"fetch", "*/*", entryOffers, "application/json"},
+		{"axios", axios, entryOffers, "application/json"},
+		{"application wildcard", "application/*", entryOffers, "application/json"},
+		{"xml", "application/xml", entryOffers, "application/xml"},
+		{"xml preferred", "application/xml;q=0.9, application/json;q=0.5", entryOffers, "application/xml"},
+		{"tie", "application/xml;q=0.5, application/json;q=0.5", entryOffers, "application/json"},
+		{"xml over wildcard", "application/xml, */*;q=0.1", entryOffers, "application/xml"},
+		{"wildcard q ignored for others", "application/json;q=0.5, */*", entryOffers, "application/json"},
+		{"json refused", "application/json;q=0, */*", entryOffers, "application/xml"},
+		{"csv", "text/csv", entryOffers, "text/csv"},
+		{"webp", "image/webp", entryOffers, "image/webp"},
+		{"json-ld", "application/ld+json", entryOffers, "application/ld+json"},
+		{"richtext parameter", "application/json; markup=markdown", entryOffers, "application/json"},
+		{"photo page", chrome, photoOffers, "image/webp"},
+		{"photo image", firefoxImage, photoOffers, "image/webp"},
+		{"photo png", "image/png", photoOffers, "image/png"},
+		{"photo jpeg preferred", "image/jpeg, image/webp;q=0.5", photoOffers, "image/jpeg"},
+		{"photo image wildcard", "image/*", photoOffers, "image/webp"},
+		{"only a wildcard of another", "image/*", []string{"application/json", "image/png"}, "image/png"},
+		{"event source", "text/event-stream", []string{"text/event-stream", "application/x-ndjson"}, "text/event-stream"},
+		{"ndjson stream", "application/x-ndjson", []string{"text/event-stream", "application/x-ndjson"}, "application/x-ndjson"},
+		{"malformed entries", "foo, */bar, application/xml;q=2, application/json", entryOffers, "application/json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Select(tt.header, tt.offers...)
+			if !ok || got != tt.want {
+				t.Errorf("Select(%q) = %q, %v, want %q", tt.header, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectNotAcceptable(t *testing.T) {
+	for _, header := range []string{"text/plain", "application/json;q=0", "image/*;q=0, application/pdf"} {
+		if got, ok := Select(header, entryOffers...); ok {
+			t.Errorf("Select(%q) = %q, want none", header, got)
+		}
+	}
+	if got, ok := Select("*/*"); ok {
+		t.Errorf("Select without offers = %q, want none", got)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/politicians/1001", nil)
+	req.Header.Set("Accept", "text/plain")
+	w := httptest.NewRecorder()
+
+	if _, ok := Negotiate(w, req, entryOffers...); ok {
+		t.Fatal("Negotiate accepted text/plain")
+	}
+	if w.Code != http.StatusNotAcceptable {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotAcceptable)
+	}
+	if got := w.Header().Get("Vary"); got != "Accept" {
+		t.Errorf("Vary = %q, want Accept", got)
+	}
+}
+
+func TestParseAccept(t *testing.T) {
+	ranges := ParseAccept("text/*;q=0.5, application/json; markup=markdown, */*;q=0.1, application/xml")
+	want := []string{"application/json", "application/xml", "text/*", "*/*"}
+	if len(ranges) != len(want) {
+		t.Fatalf("got %d ranges, want %d", len(ranges), len(want))
+	}
+	for i, r := range ranges {
+		if got := r.Type + "/" + r.Subtype; got != want[i] {
+			t.Errorf("range %d = %s, want %s", i, got, want[i])
+		}
+	}
+	if ranges[0].Params["markup"] != "markdown" {
+		t.Errorf("params = %v, want markup=markdown", ranges[0].Params)
+	}
+}
@@ -0,0 +1,181 @@
+package rest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"time"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/img"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+// photos don't change often, but they do change
+const photoCacheControl = "public, max-age=86400"
+
+// Copyright returns the copyright of the image of res, if the resource
+// itself doesn't list it. It's kept with the image until that changes.
+type Copyright[T any] func(req *http.Request, res *T) (string, error)
+
+// PhotoHandler serves the image of the resources of a repository and its
+// metadata.
+type PhotoHandler[T img.Source] struct {
+	repo      resources.Repository[T]
+	pipeline  *img.Pipeline
+	sizes     []int
+	copyright Copyright[T]
+}
+
+type PhotoOption[T img.Source] func(h *PhotoHandler[T])
+
+// WithCopyright looks up the copyright elsewhere, e.g. in the details of a
+// catalog entry.
+func WithCopyright[T img.Source](c Copyright[T]) PhotoOption[T] {
+	return func(h *PhotoHandler[T]) {
+		h.copyright = c
+	}
+}
+
+// NewPhotoHandler serves images in the sizes given, the widths and heights
+// clients may ask for.
+func NewPhotoHandler[T img.Source](repo resources.Repository[T], pipeline *img.Pipeline, sizes []int, opts ...PhotoOption[T]) *PhotoHandler[T] {
+	h := &PhotoHandler[T]{repo: repo, pipeline: pipeline, sizes: sizes}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Get serves the image of the resource {id} in the variant requested by
+// ?w=&h=&fit=&format=, see img.ParseVariant. Without ?format= the format is
+// negotiated. The copyright and the source are sent along as headers, the
+// image is served without copyright if it can't be looked up.
+func (h *PhotoHandler[T]) Get(w http.ResponseWriter, req *http.Request) {
+	offers := []string{img.FormatWebP.MediaType(), img.FormatJPEG.MediaType(), img.FormatPNG.MediaType()}
+
+	v, err := img.ParseVariant(req.URL.Query(), h.sizes)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if v.Format == "" {
+		mediaType, ok := Negotiate(w, req, offers...)
+		if !ok {
+			return
+		}
+		v.Format, _ = img.ParseFormat(mediaType)
+	}
+
+	res, err := h.repo.Get(req.Context(), req.PathValue("id"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	im, err := h.pipeline.EnsureImage(req.Context(), *res, req.PathValue("id"), v)
+	if err != nil {
+		imageError(w, err)
+		return
+	}
+	if copyright := h.copyrightOf(req, res, im.Original); copyright != "" {
+		w.Header().Set("X-Image-Copyright", mime.QEncoding.Encode("utf-8", copyright))
+	}
+	w.Header().Set("X-Image-Source", im.Original.SourceURL)
+	w.Header().Add("Link", fmt.Sprintf(`<%s/meta>; rel="describedby"`, req.URL.Path))
+	ServeImage(w, req, h.pipeline, im)
+}
+
+// Meta serves the attribution, the source and the size of the image of the
+// resource {id}.
+func (h *PhotoHandler[T]) Meta(w http.ResponseWriter, req *http.Request) {
+	res, err := h.repo.Get(req.Context(), req.PathValue("id"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	orig, err := h.pipeline.Original(req.Context(), *res, req.PathValue("id"))
+	if err != nil {
+		imageError(w, err)
+		return
+	}
+	meta := v1.PhotoMeta{
+		Copyright:     h.copyrightOf(req, res, orig),
+		AltText:       (*res).ImageInfo().AltText,
+		SourceURL:     orig.SourceURL,
+		Width:         orig.Width,
+		Height:        orig.Height,
+		LastChanged:   orig.Changed.Format(time.RFC3339),
+		BlurHash:      orig.BlurHash,
+		DominantColor: orig.DominantColor,
+	}
+	w.Header().Set("Cache-Control", photoCacheControl)
+	if err := MarshalResponse(w, http.StatusOK, meta); err != nil {
+		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+	}
+}
+
+// copyrightOf returns the copyright of orig, the image of res. A failed
+// lookup is only logged, it's tried again with the next request.
+func (h *PhotoHandler[T]) copyrightOf(req *http.Request, res *T, orig img.Original) string {
+	if c := (*res).ImageInfo().Copyright; c != "" || h.copyright == nil {
+		return c
+	}
+	if orig.Copyright != nil {
+		return *orig.Copyright
+	}
+	c, err := h.copyright(req, res)
+	if err != nil {
+		fmt.Printf("failed to get copyright: %v\n", err)
+		return ""
+	}
+	if err := h.pipeline.Attribute(req.Context(), req.PathValue("id"), orig, c); err != nil {
+		fmt.Printf("failed to keep copyright: %v\n", err)
+	}
+	return c
+}
+
+// ServeImage writes the cached image im with its ETag, answering conditional
+// requests with 304 Not Modified. Last-Modified is the last change of the
+// image upstream. If the pipeline presigns, clients are redirected to the
+// store instead, for as long as the URL is valid.
+func ServeImage(w http.ResponseWriter, req *http.Request, pipeline *img.Pipeline, im img.Image) {
+	u, expiry, err := pipeline.Presign(req.Context(), im)
+	if err != nil {
+		fmt.Printf("failed to presign image: %v\n", err)
+	} else if u != nil {
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(expiry.Seconds())/2))
+		http.Redirect(w, req, u.String(), http.StatusFound)
+		return
+	}
+
+	data, err := pipeline.Read(req.Context(), im)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.Error(w, "Image not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		fmt.Printf("failed to read image: %v\n", err)
+		http.Error(w, "Failed to read image", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", im.Format.MediaType())
+	w.Header().Set("ETag", im.ETag)
+	w.Header().Set("Cache-Control", photoCacheControl)
+	http.ServeContent(w, req, "", im.Original.Changed, bytes.NewReader(data))
+}
+
+func imageError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, img.ErrNoImage):
+		http.Error(w, "Image not found", http.StatusNotFound)
+	case errors.Is(err, img.ErrUpstream):
+		fmt.Printf("failed to fetch image: %v\n", err)
+		http.Error(w, "Failed to fetch image", http.StatusBadGateway)
+	default:
+		fmt.Printf("failed to convert image: %v\n", err)
+		http.Error(w, "Failed to convert image", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/img"
+)
+
+type portrait struct {
+	url string
+}
+
+func (p portrait) Images() []v1.Image      { return []v1.Image{{Size: 1, URL: p.url}} }
+func (p portrait) ImageInfo() v1.ImageInfo { return v1.ImageInfo{LastChanged: "01.02.2025"} }
+
+type portraitRepo struct {
+	portrait
+}
+
+func (r portraitRepo) List(ctx context.Context) []portrait { return []portrait{r.portrait} }
+
+func (r portraitRepo) Get(ctx context.Context, id string) (*portrait, error) {
+	if id != "1001" {
+		return nil, errors.New("not found")
+	}
+	return &r.portrait, nil
+}
+
+func (r portraitRepo) Delete(ctx context.Context, id string) error { return nil }
+func (r portraitRepo) Create(ctx context.Context, p *portrait) (*portrait, error) {
+	return p, nil
+}
+func (r portraitRepo) Update(ctx context.Context, _ *portrait, p *portrait) (*portrait, error) {
+	return p, nil
+}
+func (r portraitRepo) Name() string { return "portraits" }
+
+func newPhotoHandler(t *testing.T, copyright Copyright[portrait]) *PhotoHandler[portrait] {
+	m := image.NewGray(image.Rect(0, 0, 20, 20))
+	for i := range m.Pix {
+		m.Pix[i] = uint8(i)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(buf.Bytes())
+	}))
+	t.Cleanup(upstream.Close)
+
+	store, err := img.NewFileStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := portraitRepo{portrait{url: upstream.URL + "/1001.png"}}
+	return NewPhotoHandler[portrait](repo, img.NewPipeline(store, img.DefaultOptions), []int{16}, WithCopyright(copyright))
+}
+
+func servePhoto(h *PhotoHandler[portrait], path string, header http.Header) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/politicians/{id}/photo", h.Get)
+	mux.HandleFunc("/politicians/{id}/photo/meta", h.Meta)
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPhotoHandlerCopyright(t *testing.T) {
+	var lookups atomic.Int32
+	failing := true
+	h := newPhotoHandler(t, func(req *http.Request, p *portrait) (string, error) {
+		lookups.Add(1)
+		if failing {
+			return "", errors.New("bio unavailable")
+		}
+		return "Büro Müller", nil
+	})
+
+	// a failed lookup doesn't keep the photo from being served
+	rec := servePhoto(h, "/politicians/1001/photo?format=png", nil)
+	if rec.Code != http.StatusOK || rec.Header().Get("X-Image-Copyright") != "" {
+		t.Fatalf("status %d, copyright %q", rec.Code, rec.Header().Get("X-Image-Copyright"))
+	}
+
+	failing = false
+	rec = servePhoto(h, "/politicians/1001/photo?format=png", nil)
+	if got := rec.Header().Get("X-Image-Copyright"); rec.Code != http.StatusOK || got != "=?utf-8?q?B=C3=BCro_M=C3=BCller?=" {
+		t.Fatalf("status %d, copyright %q", rec.Code, got)
+	}
+
+	// the copyright is kept with the image
+	rec = servePhoto(h, "/politicians/1001/photo/meta", nil)
+	var meta v1.PhotoMeta
+	if err := json.Unmarshal(rec.Body.Bytes(), &meta); err != nil || meta.Copyright != "Büro Müller" {
+		t.Errorf("meta = %+v, %v", meta, err)
+	}
+	servePhoto(h, "/politicians/1001/photo?format=webp&w=16&h=16", nil)
+	if lookups.Load() != 2 {
+		t.Errorf("copyright looked up %d times, want 2", lookups.Load())
+	}
+}
+
+func TestPhotoHandlerETag(t *testing.T) {
+	h := newPhotoHandler(t, func(req *http.Request, p *portrait) (string, error) { return "", nil })
+
+	first := servePhoto(h, "/politicians/1001/photo?format=png", nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("status %d, ETag %q", first.Code, etag)
+	}
+
+	cached := servePhoto(h, "/politicians/1001/photo?format=png", nil)
+	if cached.Header().Get("ETag") != etag || !bytes.Equal(cached.Body.Bytes(), first.Body.Bytes()) {
+		t.Errorf("cached ETag %q, want %q", cached.Header().Get("ETag"), etag)
+	}
+	if rec := servePhoto(h, "/politicians/1001/photo?format=png", http.Header{"If-None-Match": {etag}}); rec.Code != http.StatusNotModified {
+		t.Errorf("conditional request got %d", rec.Code)
+	}
+	if other := servePhoto(h, "/politicians/1001/photo?format=png&w=16&h=16", nil); other.Header().Get("ETag") == etag {
+		t.Errorf("another variant has the same ETag %q", etag)
+	}
+}
@@ -0,0 +1,181 @@
+package rest
+
+import (
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+	"github.com/samber/lo"
+)
+
+// Representation writes resources in one media type. One or Many may be nil
+// if the representation is only offered for single resources or for lists.
+// Both write the complete response, including errors. Format is the short
+// name that selects the representation via ?format=, regardless of Accept.
+type Representation[T any] struct {
+	MediaType string
+	Format    string
+	One       func(w http.ResponseWriter, req *http.Request, res *T)
+	Many      func(w http.ResponseWriter, req *http.Request, res iter.Seq[T])
+}
+
+func defaultRepresentations[T any]() []Representation[T] {
+	return []Representation[T]{JSON[T](), XML[T](), NDJSON[T]()}
+}
+
+// WithRepresentation offers reps in addition to the default JSON, XML and
+// NDJSON representations. An existing representation of the same media type is
+// replaced.
+func WithRepresentation[T any](reps ...Representation[T]) HandlerOption[T] {
+	return func(h *genericHandler[T]) {
+		for _, r := range reps {
+			h.representations = withRepresentation(h.representations, r)
+		}
+	}
+}
+
+func withRepresentation[T any](reps []Representation[T], r Representation[T]) []Representation[T] {
+	for i := range reps {
+		if reps[i].MediaType == r.MediaType {
+			reps[i] = r
+			return reps
+		}
+	}
+	return append(reps, r)
+}
+
+// selectRepresentation picks the representation for req among reps. A
+// ?format= takes precedence over the Accept header. If it names none of reps
+// or none is acceptable, it answers with 406 Not Acceptable.
+func selectRepresentation[T any](w http.ResponseWriter, req *http.Request, reps []Representation[T]) (Representation[T], bool) {
+	w.Header().Add("Vary", "Accept")
+
+	rep, ok := pickRepresentation(req, reps)
+	if !ok {
+		offers := make([]string, len(reps))
+		for i, rep := range reps {
+			offers[i] = rep.MediaType
+		}
+		http.Error(w, "Not acceptable, available: "+strings.Join(offers, ", "), http.StatusNotAcceptable)
+		return Representation[T]{}, false
+	}
+	return rep, true
+}
+
+// pickRepresentation is selectRepresentation without a response.
+func pickRepresentation[T any](req *http.Request, reps []Representation[T]) (Representation[T], bool) {
+	if format := req.URL.Query().Get("format"); format != "" {
+		for _, rep := range reps {
+			if rep.Format != "" && strings.EqualFold(rep.Format, format) {
+				return rep, true
+			}
+		}
+		return Representation[T]{}, false
+	}
+
+	offers := make([]string, len(reps))
+	for i, rep := range reps {
+		offers[i] = rep.MediaType
+	}
+	mediaType, ok := Select(req.Header.Get("Accept"), offers...)
+	if !ok {
+		return Representation[T]{}, false
+	}
+	for _, rep := range reps {
+		if rep.MediaType == mediaType {
+			return rep, true
+		}
+	}
+	return Representation[T]{}, false
+}
+
+// ServeList writes res in the representation negotiated among JSON, XML,
+// NDJSON and reps. It is meant for handlers that aren't backed by a single repository.
+func ServeList[T any](w http.ResponseWriter, req *http.Request, res []T, reps ...Representation[T]) {
+	offered := defaultRepresentations[T]()
+	for _, r := range reps {
+		offered = withRepresentation(offered, r)
+	}
+	offered = lo.Filter(offered, func(r Representation[T], _ int) bool { return r.Many != nil })
+
+	rep, ok := selectRepresentation(w, req, offered)
+	if !ok {
+		return
+	}
+	rep.Many(w, req, slices.Values(res))
+}
+
+// NewNestedHandler serves the list nested in the resource {id} of repo, e.g.
+// the members of a committee.
+func NewNestedHandler[P any, T any](repo resources.Repository[P], nested func(*P) []T, reps ...Representation[T]) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		parent, err := repo.Get(req.Context(), req.PathValue("id"))
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		ServeList(w, req, nested(parent), reps...)
+	}
+}
+
+var unsafeName = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// Attachment marks the response as download. The file name is derived from
+// the request path, e.g. "committees-a11-members.csv" for /committees/a11/members.
+func Attachment(w http.ResponseWriter, req *http.Request, ext string) {
+	name := strings.Trim(unsafeName.ReplaceAllString(strings.ReplaceAll(strings.Trim(req.URL.Path, "/"), "/", "-"), "_"), "-_")
+	if name == "" {
+		name = "export"
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, ext))
+}
+
+// PublicBase is the public root of the API, which absolute links start
+// with.
+type PublicBase struct {
+	// URL is the configured root, it wins over the request.
+	URL *url.URL
+	// Forwarded trusts X-Forwarded-Proto and X-Forwarded-Host, which only
+	// proxies in front of the API may set.
+	Forwarded bool
+}
+
+// Of returns the configured root, otherwise the one the client used for req.
+func (b PublicBase) Of(req *http.Request) *url.URL {
+	if b.URL != nil {
+		return b.URL
+	}
+
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	host := req.Host
+	if b.Forwarded {
+		if proto := req.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+			scheme = proto
+		}
+		if fwd := req.Header.Get("X-Forwarded-Host"); fwd != "" {
+			host, _, _ = strings.Cut(fwd, ",")
+		}
+	}
+	return &url.URL{Scheme: scheme, Host: strings.TrimSpace(host)}
+}
+
+// Vary marks responses as depending on the forwarded headers, if links are
+// derived from them.
+func (b PublicBase) Vary(next http.Handler) http.Handler {
+	if b.URL != nil || !b.Forwarded {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Vary", "X-Forwarded-Host, X-Forwarded-Proto")
+		next.ServeHTTP(w, req)
+	})
+}
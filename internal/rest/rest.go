@@ -4,16 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/kyzrfranz/bundestag-api/internal/img"
 	"github.com/kyzrfranz/bundestag-api/pkg/resources"
-	"io"
+	"github.com/samber/lo"
 	"net/http"
-	"os"
+	"slices"
+	"strings"
 )
 
+// Link points from a resource to a related one. Type hints at the media type
+// of the target where it isn't JSON.
 type Link struct {
 	Link string `json:"link"`
 	Rel  string `json:"rel"`
+	Type string `json:"type,omitempty"`
 }
 
 type Handler[T any] interface {
@@ -23,64 +26,145 @@ type Handler[T any] interface {
 	Update(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
 	Path() string
+	// Selects reports whether Get would answer r in the representation
+	// named format, e.g. to tell requests for images.
+	Selects(r *http.Request, format string) bool
+	// Serve writes res like Get writes the resource from the repository,
+	// e.g. an older version of it. Nothing is embedded.
+	Serve(w http.ResponseWriter, r *http.Request, res *T)
 }
 
 type genericHandler[T any] struct {
-	repo    resources.Repository[T]
-	context context.Context
+	repo            resources.Repository[T]
+	context         context.Context
+	transforms      []Transform[T]
+	representations []Representation[T]
+	embeds          map[string]Embed[T]
+	linker          Linker[T]
+	// embeddedRepresentations are offered with ?embed=.
+	embeddedRepresentations []Representation[Embedded[T]]
 }
 
-func NewHandler[T any](resourceRepo resources.Repository[T]) Handler[T] {
-	return genericHandler[T]{
-		repo: resourceRepo,
+// Transform adapts a resource to the request before it is written. An error
+// rejects the request as bad request.
+type Transform[T any] func(req *http.Request, res *T) error
+
+type HandlerOption[T any] func(h *genericHandler[T])
+
+// WithTransform applies t to every resource returned by the handler.
+func WithTransform[T any](t Transform[T]) HandlerOption[T] {
+	return func(h *genericHandler[T]) {
+		h.transforms = append(h.transforms, t)
 	}
 }
 
+func NewHandler[T any](resourceRepo resources.Repository[T], opts ...HandlerOption[T]) Handler[T] {
+	h := genericHandler[T]{
+		repo:            resourceRepo,
+		representations: defaultRepresentations[T](),
+		embeds:          map[string]Embed[T]{},
+		embeddedRepresentations: []Representation[Embedded[T]]{
+			JSON[Embedded[T]](), NDJSON[Embedded[T]](),
+		},
+	}
+	for _, opt := range opts {
+		opt(&h)
+	}
+	return h
+}
+
 func (r genericHandler[T]) List(w http.ResponseWriter, req *http.Request) {
-	res := r.repo.List(r.context)
+	embeds, err := r.requestedEmbeds(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(embeds) > 0 {
+		r.listEmbedded(w, req, embeds)
+		return
+	}
 
-	if err := MarshalResponse(w, res); err != nil {
-		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+	rep, ok := selectRepresentation(w, req, lo.Filter(r.representations, func(rep Representation[T], _ int) bool { return rep.Many != nil }))
+	if !ok {
 		return
 	}
+
+	res, ok := r.list(w, req)
+	if !ok {
+		return
+	}
+
+	rep.Many(w, req, slices.Values(res))
 }
 
 func (r genericHandler[T]) Get(w http.ResponseWriter, req *http.Request) {
-	id := req.PathValue("id")
-	res, err := r.repo.Get(r.context, id)
-
+	embeds, err := r.requestedEmbeds(req)
 	if err != nil {
-		w.WriteHeader(http.StatusNotFound)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(embeds) > 0 {
+		r.getEmbedded(w, req, embeds)
 		return
 	}
 
-	if req.Header.Get("Accept") == "image/webp" {
-		err := img.EnsureImage(res, id)
-		if err != nil {
-			http.Error(w, "Image not found", http.StatusNotFound)
-			return
-		}
-		f, err := os.Open(fmt.Sprintf(".img/%s.webp", id))
-		if err != nil {
-			http.Error(w, "Image not found", http.StatusNotFound)
-			return
-		}
-		defer f.Close()
+	rep, ok := selectRepresentation(w, req, lo.Filter(r.representations, func(rep Representation[T], _ int) bool { return rep.One != nil }))
+	if !ok {
+		return
+	}
 
-		w.Header().Set("Content-Type", "image/webp")
-		w.WriteHeader(http.StatusOK)
+	res, ok := r.get(w, req)
+	if !ok {
+		return
+	}
 
-		if _, err := io.Copy(w, f); err != nil {
-			http.Error(w, "Failed to stream image", http.StatusInternalServerError)
-			return
-		}
-	} else {
-		if err = MarshalResponse(w, res); err != nil {
-			http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
-			return
+	rep.One(w, req, res)
+}
+
+func (r genericHandler[T]) Serve(w http.ResponseWriter, req *http.Request, res *T) {
+	rep, ok := selectRepresentation(w, req, lo.Filter(r.representations, func(rep Representation[T], _ int) bool { return rep.One != nil }))
+	if !ok {
+		return
+	}
+	if err := r.transform(req, res); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rep.One(w, req, res)
+}
+
+func (r genericHandler[T]) list(w http.ResponseWriter, req *http.Request) ([]T, bool) {
+	res := r.repo.List(r.context)
+	for i := range res {
+		if err := r.transform(req, &res[i]); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return nil, false
 		}
 	}
+	return res, true
+}
+
+func (r genericHandler[T]) get(w http.ResponseWriter, req *http.Request) (*T, bool) {
+	res, err := r.repo.Get(r.context, req.PathValue("id"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+
+	if err := r.transform(req, res); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return res, true
+}
 
+func (r genericHandler[T]) transform(req *http.Request, res *T) error {
+	for _, t := range r.transforms {
+		if err := t(req, res); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r genericHandler[T]) Create(w http.ResponseWriter, req *http.Request) {
@@ -95,18 +179,25 @@ func (r genericHandler[T]) Delete(w http.ResponseWriter, req *http.Request) {
 
 }
 
+func (r genericHandler[T]) Selects(req *http.Request, format string) bool {
+	rep, ok := pickRepresentation(req, lo.Filter(r.representations, func(rep Representation[T], _ int) bool { return rep.One != nil }))
+	return ok && strings.EqualFold(rep.Format, format)
+}
+
 func (r genericHandler[T]) Path() string {
 	return fmt.Sprintf("/%s", r.repo.Name())
 }
 
-func MarshalResponse(w http.ResponseWriter, res interface{}) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
+// MarshalResponse writes res as JSON with status. Nothing is written if res
+// can't be marshalled, so the caller can still answer with an error.
+func MarshalResponse(w http.ResponseWriter, status int, res interface{}) error {
 	jsonData, err := json.Marshal(res)
 	if err != nil {
 		return err
 	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
 	_, writeErr := w.Write(jsonData) // Write the JSON data
 	if writeErr != nil {
 		return writeErr
@@ -0,0 +1,105 @@
+package richtext
+
+import (
+	"net/http"
+	"net/url"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	v2 "github.com/kyzrfranz/bundestag-api/api/v2"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+)
+
+var defaultBase, _ = url.Parse("https://www.bundestag.de/")
+
+// RequestFormat reads the requested rendition from ?markup= or from the
+// markup parameter of a JSON Accept entry, e.g. "application/json;
+// markup=markdown". ?format= is left to the representations. Without either,
+// sanitized HTML is returned.
+func RequestFormat(req *http.Request) (Format, error) {
+	if f := req.URL.Query().Get("markup"); f != "" {
+		return ParseFormat(f)
+	}
+
+	for _, r := range rest.ParseAccept(req.Header.Get("Accept")) {
+		if r.Type != "application" || r.Subtype != "json" {
+			continue
+		}
+		if f, ok := r.Params["markup"]; ok {
+			return ParseFormat(f)
+		}
+	}
+
+	return FormatHTML, nil
+}
+
+// Politician renders the HTML fields of a bio in the requested format.
+func Politician(req *http.Request, p *v1.Politician) error {
+	format, err := RequestFormat(req)
+	if err != nil {
+		return err
+	}
+
+	RenderPolitician(p, format)
+	return nil
+}
+
+// RenderPolitician renders the HTML fields of a bio in format.
+func RenderPolitician(p *v1.Politician, format Format) {
+	base := baseURL(p.Bio.SourceURL)
+	p.Bio.BiographicInfo = Render(p.Bio.BiographicInfo, format, base)
+	p.Bio.Trivia = Render(p.Bio.Trivia, format, base)
+}
+
+// Committee renders the HTML fields of committee details in the requested
+// format.
+func Committee(req *http.Request, c *v1.CommitteeDetails) error {
+	format, err := RequestFormat(req)
+	if err != nil {
+		return err
+	}
+
+	RenderCommittee(c, format)
+	return nil
+}
+
+// RenderCommittee renders the HTML fields of committee details in format.
+func RenderCommittee(c *v1.CommitteeDetails, format Format) {
+	base := baseURL(c.SourceURL)
+	c.Tasks = Render(c.Tasks, format, base)
+	c.Contact = Render(c.Contact, format, base)
+}
+
+// Bio renders the HTML fields of a v2 bio in the requested format.
+func Bio(req *http.Request, b *v2.Bio) error {
+	format, err := RequestFormat(req)
+	if err != nil {
+		return err
+	}
+
+	base := baseURL(b.URL)
+	b.Biography = Render(b.Biography, format, base)
+	b.Trivia = Render(b.Trivia, format, base)
+	return nil
+}
+
+// CommitteeDetails renders the HTML fields of v2 committee details in the
+// requested format.
+func CommitteeDetails(req *http.Request, c *v2.CommitteeDetails) error {
+	format, err := RequestFormat(req)
+	if err != nil {
+		return err
+	}
+
+	base := baseURL(c.URL)
+	c.Tasks = Render(c.Tasks, format, base)
+	c.Contact = Render(c.Contact, format, base)
+	return nil
+}
+
+func baseURL(source string) *url.URL {
+	u, err := url.Parse(source)
+	if err != nil || !u.IsAbs() {
+		return defaultBase
+	}
+	return u
+}
@@ -0,0 +1,33 @@
+package richtext
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestFormat(t *testing.T) {
+	tests := []struct {
+		name, target, accept string
+		want                 Format
+		wantErr              bool
+	}{
+		{"default", "/politicians/1", "", FormatHTML, false},
+		{"query", "/politicians/1?markup=md", "", FormatMarkdown, false},
+		{"accept", "/politicians/1", "application/xml, application/json; markup=plain", FormatText, false},
+		{"query wins", "/politicians/1?markup=html", "application/json; markup=text", FormatHTML, false},
+		{"format selects the representation", "/politicians/1?format=csv", "", FormatHTML, false},
+		{"unknown", "/politicians/1?markup=latex", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.target, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			got, err := RequestFormat(req)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("RequestFormat = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package richtext
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+type Format string
+
+const (
+	FormatHTML     Format = "html"
+	FormatText     Format = "text"
+	FormatMarkdown Format = "markdown"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatHTML, FormatText, FormatMarkdown:
+		return f, nil
+	case "md":
+		return FormatMarkdown, nil
+	case "plain", "txt":
+		return FormatText, nil
+	}
+	return "", fmt.Errorf("unknown markup: %s", s)
+}
+
+// allowed elements of sanitized HTML and the attributes they keep
+var allowed = map[atom.Atom][]string{
+	atom.P: nil, atom.Br: nil, atom.Strong: nil, atom.B: nil, atom.Em: nil, atom.I: nil, atom.U: nil,
+	atom.Ul: nil, atom.Ol: nil, atom.Li: nil, atom.Blockquote: nil,
+	atom.H2: nil, atom.H3: nil, atom.H4: nil, atom.H5: nil, atom.H6: nil,
+	atom.A: {"href"},
+}
+
+// elements that are dropped together with their content
+var dropped = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true, atom.Embed: true,
+	atom.Noscript: true, atom.Template: true, atom.Head: true, atom.Title: true, atom.Form: true,
+}
+
+var blocks = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Ul: true, atom.Ol: true, atom.Li: true, atom.Blockquote: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Table: true, atom.Tr: true, atom.Section: true, atom.Article: true,
+}
+
+var whitespace = regexp.MustCompile(`\s+`)
+
+// Render converts the upstream HTML fragment raw into format. Relative links
+// are resolved against base.
+func Render(raw string, format Format, base *url.URL) string {
+	if strings.TrimSpace(raw) == "" {
+		return raw
+	}
+
+	nodes, err := html.ParseFragment(strings.NewReader(raw), &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div})
+	if err != nil {
+		return html.EscapeString(raw)
+	}
+
+	switch format {
+	case FormatText, FormatMarkdown:
+		w := &textWriter{markdown: format == FormatMarkdown, base: base}
+		for _, n := range nodes {
+			w.node(n)
+		}
+		return strings.TrimSpace(w.sb.String())
+	default:
+		var sb strings.Builder
+		for _, n := range nodes {
+			sanitize(&sb, n, base)
+		}
+		return strings.TrimSpace(sb.String())
+	}
+}
+
+func sanitize(sb *strings.Builder, n *html.Node, base *url.URL) {
+	switch n.Type {
+	case html.TextNode:
+		text := whitespace.ReplaceAllString(n.Data, " ")
+		if strings.HasSuffix(sb.String(), " ") {
+			text = strings.TrimLeft(text, " ")
+		}
+		sb.WriteString(html.EscapeString(text))
+		return
+	case html.ElementNode:
+	default:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			sanitize(sb, c, base)
+		}
+		return
+	}
+
+	if dropped[n.DataAtom] {
+		return
+	}
+
+	attrs, ok := allowed[n.DataAtom]
+	if n.DataAtom == atom.A && resolve(attr(n, "href"), base) == "" {
+		ok = false
+	}
+	if !ok {
+		// the content of blocks that are unwrapped stays apart from its
+		// surroundings
+		if blocks[n.DataAtom] {
+			space(sb)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			sanitize(sb, c, base)
+		}
+		if blocks[n.DataAtom] {
+			space(sb)
+		}
+		return
+	}
+
+	sb.WriteString("<" + n.Data)
+	for _, a := range attrs {
+		value := attr(n, a)
+		if a == "href" {
+			value = resolve(value, base)
+		}
+		if value != "" {
+			sb.WriteString(fmt.Sprintf(` %s="%s"`, a, html.EscapeString(value)))
+		}
+	}
+	if n.DataAtom == atom.A {
+		sb.WriteString(` rel="noopener nofollow"`)
+	}
+	sb.WriteString(">")
+	if n.DataAtom == atom.Br {
+		return
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sanitize(sb, c, base)
+	}
+	sb.WriteString("</" + n.Data + ">")
+}
+
+// space separates what is written next from the text before it, if any.
+func space(sb *strings.Builder) {
+	if s := sb.String(); s != "" && !strings.HasSuffix(s, " ") {
+		sb.WriteString(" ")
+	}
+}
+
+// textWriter renders plain text or Markdown, collapsing whitespace and
+// separating blocks by blank lines.
+type textWriter struct {
+	sb       strings.Builder
+	markdown bool
+	base     *url.URL
+	breaks   int
+	prefix   string
+	lists    []int
+}
+
+func (w *textWriter) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		text := whitespace.ReplaceAllString(n.Data, " ")
+		if w.markdown {
+			text = escapeMarkdown(text)
+		}
+		w.write(text)
+		return
+	case html.ElementNode:
+	default:
+		w.children(n)
+		return
+	}
+
+	if dropped[n.DataAtom] {
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		w.lineBreak(1)
+	case atom.Ul, atom.Ol:
+		start := -1
+		if n.DataAtom == atom.Ol {
+			start = 1
+		}
+		w.lists = append(w.lists, start)
+		w.lineBreak(2)
+		w.children(n)
+		w.lists = w.lists[:len(w.lists)-1]
+		w.lineBreak(2)
+	case atom.Li:
+		w.lineBreak(1)
+		indent := strings.Repeat("  ", max(len(w.lists)-1, 0))
+		if len(w.lists) > 0 && w.lists[len(w.lists)-1] > 0 {
+			w.prefix = fmt.Sprintf("%s%d. ", indent, w.lists[len(w.lists)-1])
+			w.lists[len(w.lists)-1]++
+		} else {
+			w.prefix = indent + "- "
+		}
+		w.children(n)
+		w.lineBreak(1)
+	case atom.Strong, atom.B:
+		w.wrap(n, "**")
+	case atom.Em, atom.I:
+		w.wrap(n, "*")
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		w.lineBreak(2)
+		if w.markdown {
+			w.prefix = strings.Repeat("#", int(n.Data[1]-'0')) + " "
+		}
+		w.children(n)
+		w.lineBreak(2)
+	case atom.A:
+		href := resolve(attr(n, "href"), w.base)
+		text := strings.TrimSpace(whitespace.ReplaceAllString(textContent(n), " "))
+		switch {
+		case href == "":
+			w.children(n)
+		case w.markdown:
+			w.write("[" + escapeMarkdown(text) + "](" + href + ")")
+		case text == "" || text == href:
+			w.write(href)
+		default:
+			w.write(text + " (" + href + ")")
+		}
+	default:
+		if blocks[n.DataAtom] {
+			w.lineBreak(2)
+			w.children(n)
+			w.lineBreak(2)
+			return
+		}
+		w.children(n)
+	}
+}
+
+func (w *textWriter) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.node(c)
+	}
+}
+
+func (w *textWriter) wrap(n *html.Node, marker string) {
+	if !w.markdown {
+		w.children(n)
+		return
+	}
+	text := strings.TrimSpace(whitespace.ReplaceAllString(textContent(n), " "))
+	if text == "" {
+		return
+	}
+	w.write(marker + escapeMarkdown(text) + marker)
+}
+
+func (w *textWriter) lineBreak(n int) {
+	w.breaks = max(w.breaks, n)
+}
+
+func (w *textWriter) write(text string) {
+	atLineStart := w.sb.Len() == 0 || w.breaks > 0 || strings.HasSuffix(w.sb.String(), "\n")
+	if atLineStart {
+		text = strings.TrimLeft(text, " ")
+	}
+	if text == "" {
+		return
+	}
+
+	if w.sb.Len() > 0 && w.breaks > 0 {
+		if w.breaks == 1 && w.markdown && w.prefix == "" {
+			// hard line break within a paragraph
+			w.sb.WriteString("  ")
+		}
+		w.sb.WriteString(strings.Repeat("\n", w.breaks))
+	}
+	w.breaks = 0
+	w.sb.WriteString(w.prefix)
+	w.prefix = ""
+	w.sb.WriteString(text)
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	if n.Type == html.ElementNode && dropped[n.DataAtom] {
+		return ""
+	}
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(textContent(c))
+	}
+	return sb.String()
+}
+
+var markdownSpecial = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "`", "\\`")
+
+func escapeMarkdown(s string) string {
+	return markdownSpecial.Replace(s)
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
+
+// resolve makes href absolute and returns "" for anything that isn't a web,
+// mail or phone link.
+func resolve(href string, base *url.URL) string {
+	if href == "" {
+		return ""
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	switch u.Scheme {
+	case "http", "https", "mailto", "tel":
+		return u.String()
+	}
+	return ""
+}
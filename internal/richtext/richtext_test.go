@@ -0,0 +1,95 @@
+package richtext
+
+import "testing"
+
+func TestRenderHTML(t *testing.T) {
+	tests := []struct {
+		name, raw, want string
+	}{
+		{"allowed tags", `<p>Ein <strong>starker</strong> und <em>kursiver</em> <b>b</b> <i>i</i> <u>u</u> Text<br>neue Zeile</p>`,
+			`<p>Ein <strong>starker</strong> und <em>kursiver</em> <b>b</b> <i>i</i> <u>u</u> Text<br>neue Zeile</p>`},
+		{"lists and headings", `<h2>Titel</h2><ul><li>eins</li></ul><ol><li>zwei</li></ol><blockquote>Zitat</blockquote>`,
+			`<h2>Titel</h2><ul><li>eins</li></ul><ol><li>zwei</li></ol><blockquote>Zitat</blockquote>`},
+		{"other tags keep their content", `<div class="x"><span style="color:red">Text</span> <h1>Titel</h1><table><tr><td>Zelle</td></tr></table></div>`,
+			`Text Titel Zelle`},
+		{"unwrapped blocks stay apart", "<div>eins</div> <div>zwei</div>", `eins zwei`},
+		{"attributes", `<p class="intro" onclick="alert(1)" id="p">Text</p><a href="https://example.org/" target="_blank" onclick="x()" title="t">Link</a>`,
+			`<p>Text</p><a href="https://example.org/" rel="noopener nofollow">Link</a>`},
+		{"script and style", `<p>vor<script>alert(1)</script><style>p{display:none}</style>nach</p><iframe src="https://example.org/">frame</iframe>`,
+			`<p>vornach</p>`},
+		{"javascript href", `<a href="javascript:alert(1)">Klick</a>`, `Klick`},
+		{"javascript href with whitespace", `<a href=" JavaScript:alert(1)">Klick</a>`, `Klick`},
+		{"data href", `<a href="data:text/html;base64,PHNjcmlwdD4=">Klick</a>`, `Klick`},
+		{"relative href", `<a href="/abgeordnete/biografien">Biografien</a>`,
+			`<a href="https://www.bundestag.de/abgeordnete/biografien" rel="noopener nofollow">Biografien</a>`},
+		{"mail and phone", `<a href="mailto:a@bundestag.de">Mail</a> <a href="tel:+49302270">Tel</a>`,
+			`<a href="mailto:a@bundestag.de" rel="noopener nofollow">Mail</a> <a href="tel:+49302270" rel="noopener nofollow">Tel</a>`},
+		{"escaped text", `<p>a &lt;b&gt; &amp; "c"</p>`, `<p>a &lt;b&gt; &amp; &#34;c&#34;</p>`},
+		{"whitespace", "<p>viel\n\n   Platz</p>", `<p>viel Platz</p>`},
+		{"blank", "  ", "  "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.raw, FormatHTML, defaultBase); got != tt.want {
+				t.Errorf("Render =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	raw := `<h2>Lebenslauf</h2><p>Geboren in <strong>Berlin</strong>;<br>verheiratet.</p>` +
+		`<ul><li>Abitur</li><li>Studium</li></ul><ol><li>eins</li><li>zwei</li></ol>` +
+		`<p>Mehr auf <a href="/abgeordnete">der Website</a>, <a href="javascript:alert(1)">nicht hier</a>.<script>alert(1)</script></p>` +
+		`<p>*Sterne* und [Klammern]</p>`
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{FormatText, "Lebenslauf\n\n" +
+			"Geboren in Berlin;\nverheiratet.\n\n" +
+			"- Abitur\n- Studium\n\n" +
+			"1. eins\n2. zwei\n\n" +
+			"Mehr auf der Website (https://www.bundestag.de/abgeordnete), nicht hier.\n\n" +
+			"*Sterne* und [Klammern]"},
+		{FormatMarkdown, "## Lebenslauf\n\n" +
+			"Geboren in **Berlin**;  \nverheiratet.\n\n" +
+			"- Abitur\n- Studium\n\n" +
+			"1. eins\n2. zwei\n\n" +
+			"Mehr auf [der Website](https://www.bundestag.de/abgeordnete), nicht hier.\n\n" +
+			`\*Sterne\* und \[Klammern\]`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			if got := Render(raw, tt.format, defaultBase); got != tt.want {
+				t.Errorf("Render =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Format
+		wantErr bool
+	}{
+		{"html", FormatHTML, false},
+		{"HTML", FormatHTML, false},
+		{"markdown", FormatMarkdown, false},
+		{"md", FormatMarkdown, false},
+		{"text", FormatText, false},
+		{"plain", FormatText, false},
+		{"txt", FormatText, false},
+		{"rtf", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseFormat(tt.s)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseFormat = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
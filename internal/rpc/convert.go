@@ -0,0 +1,195 @@
+package rpc
+
+import (
+	"fmt"
+
+	pb "github.com/kyzrfranz/bundestag-api/api/gen/bundestag/v1"
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/events"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// conversions of the v1 types into the messages mirroring them
+
+func documentInfo(d v1.DocumentInfo) *pb.DocumentInfo {
+	return &pb.DocumentInfo{DocumentUrl: d.DocumentURL, DocumentStand: d.DocumentStand}
+}
+
+func constituency(c v1.Constituency) *pb.Constituency {
+	return &pb.Constituency{Number: c.Number, Name: c.Name, Url: c.Url}
+}
+
+func personListEntry(p v1.PersonListEntry) *pb.PersonListEntry {
+	return &pb.PersonListEntry{
+		Faction:              p.Faction,
+		Id:                   &pb.MdbId{Value: p.Id.Value, Status: p.Id.Status},
+		Name:                 &pb.MdbName{Value: p.Name.Value, Status: p.Name.Status},
+		BioUrl:               p.BioURL,
+		InfoXmlUrl:           p.InfoXMLURL,
+		InfoXmlUrlMitmischen: p.InfoXMLURLMitmischen,
+		State:                p.State,
+		Constituency:         constituency(p.Constituency),
+		Elected:              p.Elected,
+		PhotoUrl:             p.PhotoURL,
+		PhotoLargeUrl:        p.PhotoLargeURL,
+		PhotoLastChanged:     p.PhotoLastChanged,
+		PhotoChangedDateTime: p.PhotoChangedDateTime,
+		ImageAltText:         p.ImageAltText,
+		LastChanged:          p.LastChanged,
+		ChangedDateTime:      p.ChangedDateTime,
+	}
+}
+
+func politician(p *v1.Politician) *pb.Politician {
+	bio := p.Bio
+	websites := make([]*pb.Website, len(bio.OtherWebsite.Website))
+	for i, w := range bio.OtherWebsite.Website {
+		websites[i] = &pb.Website{Title: w.Title, Url: w.URL}
+	}
+
+	return &pb.Politician{
+		DocumentInfo: documentInfo(p.DocumentInfo),
+		Bio: &pb.PoliticianBio{
+			Id:                                   &pb.MdbId{Value: bio.Id.Value, Status: bio.Id.Status},
+			ArticleId:                            bio.ArticleID,
+			SourceUrl:                            bio.SourceURL,
+			ExitDate:                             bio.ExitDate,
+			LastName:                             bio.LastName,
+			FirstName:                            bio.FirstName,
+			NobilityTitle:                        bio.NobilityTitle,
+			AcademicTitle:                        bio.AcademicTitle,
+			LocationSuffix:                       bio.LocationSuffix,
+			DateOfBirth:                          bio.DateOfBirth,
+			ReligionOrDenomination:               bio.ReligionOrDenomination,
+			EducationOrProfessionalQualification: bio.EducationOrProfessionalQualification,
+			HigherEducation:                      bio.HigherEducation,
+			Profession:                           &pb.Profession{Field: bio.Profession.Field, Value: bio.Profession.Value},
+			Gender:                               bio.Gender,
+			MaritalStatus:                        bio.MaritalStatus,
+			NumberOfKids:                         bio.NumberKids,
+			Faction:                              bio.Faction,
+			Party:                                bio.Party,
+			State:                                bio.State,
+			Constituency:                         constituency(bio.Constituency),
+			Elected:                              bio.Elected,
+			BioUrl:                               bio.BioURL,
+			BiographicInfo:                       bio.BiographicInfo,
+			Trivia:                               bio.Trivia,
+			Homepage:                             bio.Homepage,
+			OtherWebsites:                        &pb.OtherWebsites{Websites: websites},
+			Phone:                                bio.Phone,
+			Memberships: &pb.Memberships{
+				LeadCommittees:             committees(bio.Memberships.LeadCommittees),
+				RegularMemberCommittees:    committees(bio.Memberships.RegularMemberCommittees),
+				SubstituteMemberCommittees: committees(bio.Memberships.SubstituteMemberCommittees),
+				ViceChairOtherCommittees:   committees(bio.Memberships.ViceChairOtherCommittees),
+			},
+			MandatedPublishableInfo: bio.MandatedPublishableInfo,
+		},
+		Media: &pb.Media{
+			Photo: &pb.Foto{
+				Url:       p.Media.Foto.URL,
+				Copyright: p.Media.Foto.Copyright,
+				LargeUrl:  p.Media.Foto.LargeUrl,
+				AltText:   p.Media.Foto.AltText,
+			},
+			SpeechesUrl: p.Media.SpeechesUrl,
+			SpeechesRss: p.Media.SpeechesRSS,
+		},
+	}
+}
+
+func committees(cs []v1.Committee) []*pb.Committee {
+	result := make([]*pb.Committee, len(cs))
+	for i, c := range cs {
+		result[i] = &pb.Committee{Id: c.Id, Name: c.Name, Url: c.Url}
+	}
+	return result
+}
+
+func committeeListEntry(c v1.CommitteeListEntry) *pb.CommitteeListEntry {
+	return &pb.CommitteeListEntry{
+		Id:                   c.Id,
+		Live:                 int32(c.Live),
+		CommitteeName:        c.Name,
+		CommitteeShortName:   c.ShortName,
+		CommitteeTeaser:      c.Teaser,
+		LastChanged:          c.LastChanged,
+		ChangedDateTime:      c.ChangedDateTime,
+		CommitteeDetailXml:   c.DetailXML,
+		ImageUrl:             c.ImageURL,
+		ImageGrossUrl:        c.ImageGrossURL,
+		ImageXl:              c.ImageXL,
+		ImageXxl:             c.ImageXXL,
+		ImageCopyright:       c.ImageCopyright,
+		ImageLastChanged:     c.ImageLastChanged,
+		ImageChangedDateTime: c.ImageChangedDateTime,
+		ImageAltText:         c.ImageAltText,
+	}
+}
+
+func committeeDetails(c *v1.CommitteeDetails) *pb.CommitteeDetails {
+	news := make([]*pb.NewsItem, len(c.NewsItems))
+	for i, n := range c.NewsItems {
+		news[i] = newsItem(n)
+	}
+
+	return &pb.CommitteeDetails{
+		DocumentInfo:       documentInfo(c.DocumentInfo),
+		CommitteeId:        c.Id,
+		CommitteeName:      c.CommitteeName,
+		SourceUrl:          c.SourceURL,
+		ImageUrl:           c.ImageURL,
+		LargeImageUrl:      c.LargeImageURL,
+		ImageCopyright:     c.ImageCopyright,
+		ImageAltText:       c.ImageAltText,
+		Tasks:              c.Tasks,
+		Contact:            c.Contact,
+		ChairpersonId:      c.ChairpersonID,
+		DeputyChairpersons: c.DeputyChairpersons,
+		Members:            personListEntries(c.Members),
+		NewsItems:          news,
+	}
+}
+
+func personListEntries(ps []v1.PersonListEntry) []*pb.PersonListEntry {
+	result := make([]*pb.PersonListEntry, len(ps))
+	for i, p := range ps {
+		result[i] = personListEntry(p)
+	}
+	return result
+}
+
+func newsItem(n v1.NewsItem) *pb.NewsItem {
+	return &pb.NewsItem{Title: n.Title, Description: n.Description, PublicationDate: n.PublicationDate, Url: n.URL}
+}
+
+func event(e events.Event) (*pb.Event, error) {
+	result := &pb.Event{
+		Id:              uint64(e.ID),
+		Type:            string(e.Type),
+		Topic:           string(e.Topic),
+		Time:            timestamppb.New(e.Time),
+		PoliticianId:    e.PoliticianID,
+		Faction:         e.Faction,
+		PreviousFaction: e.PreviousFaction,
+		CommitteeId:     e.CommitteeID,
+	}
+
+	switch data := e.Data.(type) {
+	case nil:
+	case events.Change:
+		result.Data = &pb.Event_Change{Change: &pb.Change{From: data.From, To: data.To}}
+	case events.Membership:
+		result.Data = &pb.Event_Membership{Membership: &pb.Membership{Action: string(data.Action), Name: data.Name}}
+	case *v1.Politician:
+		result.Data = &pb.Event_Politician{Politician: politician(data)}
+	case v1.CommitteeListEntry:
+		result.Data = &pb.Event_Committee{Committee: committeeListEntry(data)}
+	case v1.NewsItem:
+		result.Data = &pb.Event_NewsItem{NewsItem: newsItem(data)}
+	default:
+		return nil, fmt.Errorf("no message for event data %T", e.Data)
+	}
+	return result, nil
+}
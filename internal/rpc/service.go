@@ -0,0 +1,212 @@
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	pb "github.com/kyzrfranz/bundestag-api/api/gen/bundestag/v1"
+	"github.com/kyzrfranz/bundestag-api/api/gen/bundestag/v1/bundestagv1connect"
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/events"
+	"github.com/kyzrfranz/bundestag-api/internal/richtext"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+// ConstituencySearch finds the constituencies of a zipcode.
+type ConstituencySearch interface {
+	Constituencies(ctx context.Context, zipcode string) ([]v1.Constituency, error)
+}
+
+// Service implements the BundestagService on top of the repositories the
+// REST handlers use.
+type Service struct {
+	politicians    resources.Repository[v1.PersonListEntry]
+	bios           resources.Repository[v1.Politician]
+	committees     resources.Repository[v1.CommitteeListEntry]
+	details        resources.Repository[v1.CommitteeDetails]
+	constituencies ConstituencySearch
+
+	bus      *events.Bus
+	shutdown <-chan struct{}
+	logger   *slog.Logger
+}
+
+var _ bundestagv1connect.BundestagServiceHandler = (*Service)(nil)
+
+// NewService streams the events of bus until shutdown is closed.
+func NewService(politicians resources.Repository[v1.PersonListEntry], bios resources.Repository[v1.Politician],
+	committees resources.Repository[v1.CommitteeListEntry], details resources.Repository[v1.CommitteeDetails],
+	constituencies ConstituencySearch, bus *events.Bus, shutdown <-chan struct{}, logger *slog.Logger) *Service {
+	return &Service{
+		politicians:    politicians,
+		bios:           bios,
+		committees:     committees,
+		details:        details,
+		constituencies: constituencies,
+		bus:            bus,
+		shutdown:       shutdown,
+		logger:         logger,
+	}
+}
+
+func (s *Service) ListPoliticians(ctx context.Context, req *connect.Request[pb.ListPoliticiansRequest]) (*connect.Response[pb.ListPoliticiansResponse], error) {
+	msg := req.Msg
+
+	var result []*pb.PersonListEntry
+	for _, p := range s.politicians.List(ctx) {
+		if (msg.Faction == "" || strings.EqualFold(p.Faction, msg.Faction)) &&
+			(msg.State == "" || strings.EqualFold(p.State, msg.State)) &&
+			(msg.Constituency == "" || p.Constituency.Number == msg.Constituency) {
+			result = append(result, personListEntry(p))
+		}
+	}
+	return connect.NewResponse(&pb.ListPoliticiansResponse{Politicians: result}), nil
+}
+
+func (s *Service) GetPolitician(ctx context.Context, req *connect.Request[pb.GetPoliticianRequest]) (*connect.Response[pb.GetPoliticianResponse], error) {
+	p, err := s.politicians.Get(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	return connect.NewResponse(&pb.GetPoliticianResponse{Politician: personListEntry(*p)}), nil
+}
+
+func (s *Service) GetPoliticianBio(ctx context.Context, req *connect.Request[pb.GetPoliticianBioRequest]) (*connect.Response[pb.GetPoliticianBioResponse], error) {
+	format, err := parseFormat(req.Msg.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.bios.Get(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	richtext.RenderPolitician(p, format)
+	return connect.NewResponse(&pb.GetPoliticianBioResponse{Politician: politician(p)}), nil
+}
+
+func (s *Service) ListCommittees(ctx context.Context, _ *connect.Request[pb.ListCommitteesRequest]) (*connect.Response[pb.ListCommitteesResponse], error) {
+	catalog := s.committees.List(ctx)
+	result := make([]*pb.CommitteeListEntry, len(catalog))
+	for i, c := range catalog {
+		result[i] = committeeListEntry(c)
+	}
+	return connect.NewResponse(&pb.ListCommitteesResponse{Committees: result}), nil
+}
+
+func (s *Service) GetCommittee(ctx context.Context, req *connect.Request[pb.GetCommitteeRequest]) (*connect.Response[pb.GetCommitteeResponse], error) {
+	c, err := s.committees.Get(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	return connect.NewResponse(&pb.GetCommitteeResponse{Committee: committeeListEntry(*c)}), nil
+}
+
+func (s *Service) GetCommitteeDetails(ctx context.Context, req *connect.Request[pb.GetCommitteeDetailsRequest]) (*connect.Response[pb.GetCommitteeDetailsResponse], error) {
+	format, err := parseFormat(req.Msg.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.details.Get(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	richtext.RenderCommittee(c, format)
+	return connect.NewResponse(&pb.GetCommitteeDetailsResponse{Details: committeeDetails(c)}), nil
+}
+
+func (s *Service) ListConstituencies(ctx context.Context, req *connect.Request[pb.ListConstituenciesRequest]) (*connect.Response[pb.ListConstituenciesResponse], error) {
+	found, err := s.constituencies.Constituencies(ctx, req.Msg.Zipcode)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	result := make([]*pb.Constituency, len(found))
+	for i, c := range found {
+		result[i] = constituency(c)
+	}
+	return connect.NewResponse(&pb.ListConstituenciesResponse{Constituencies: result}), nil
+}
+
+func (s *Service) ListConstituencyPoliticians(ctx context.Context, req *connect.Request[pb.ListConstituencyPoliticiansRequest]) (*connect.Response[pb.ListConstituencyPoliticiansResponse], error) {
+	found, err := s.constituencies.Constituencies(ctx, req.Msg.Zipcode)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	var result []*pb.PersonListEntry
+	for _, p := range s.politicians.List(ctx) {
+		if slices.ContainsFunc(found, func(c v1.Constituency) bool { return c.Number == p.Constituency.Number }) {
+			result = append(result, personListEntry(p))
+		}
+	}
+	return connect.NewResponse(&pb.ListConstituencyPoliticiansResponse{Politicians: result}), nil
+}
+
+// WatchCatalogChanges replays the events after last_event_id, or sends a
+// stream.resync event if it can't, and then sends
+// new ones as they are published, until the client goes away or the server
+// shuts down.
+func (s *Service) WatchCatalogChanges(ctx context.Context, req *connect.Request[pb.WatchCatalogChangesRequest], stream *connect.ServerStream[pb.WatchCatalogChangesResponse]) error {
+	topics, err := events.ParseTopics(req.Msg.Topics)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	missed, ch, cancel := s.bus.SubscribeSince(events.ID(req.Msg.LastEventId), 64)
+	defer cancel()
+
+	// clients wait for the headers, which otherwise go out with the first event
+	if err := stream.Send(nil); err != nil {
+		return err
+	}
+
+	send := func(e events.Event) error {
+		if !e.In(topics) {
+			return nil
+		}
+		msg, err := event(e)
+		if err != nil {
+			s.logger.Error("failed to convert event", "event", e.ID, "error", err)
+			return nil
+		}
+		return stream.Send(&pb.WatchCatalogChangesResponse{Event: msg})
+	}
+
+	for _, e := range missed {
+		if err := send(e); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.shutdown:
+			return nil
+		case e, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := send(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func parseFormat(s string) (richtext.Format, error) {
+	if s == "" {
+		return richtext.FormatHTML, nil
+	}
+	format, err := richtext.ParseFormat(s)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return format, nil
+}
@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	pb "github.com/kyzrfranz/bundestag-api/api/gen/bundestag/v1"
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/data"
+	"github.com/kyzrfranz/bundestag-api/internal/upstream"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+const catalogXML = `<mdbUebersicht><mdbs>
+<mdb fraktion="SPD"><mdbID status="Aktiv">1001</mdbID><mdbLand>Berlin</mdbLand><mdbWahlkreis><mdbWahlkreisNummer>75</mdbWahlkreisNummer></mdbWahlkreis></mdb>
+<mdb fraktion="CDU/CSU"><mdbID status="Aktiv">1002</mdbID><mdbLand>Bayern</mdbLand><mdbWahlkreis><mdbWahlkreisNummer>220</mdbWahlkreisNummer></mdbWahlkreis></mdb>
+<mdb fraktion="SPD"><mdbID status="Aktiv">1003</mdbID><mdbLand>Hamburg</mdbLand></mdb>
+</mdbs></mdbUebersicht>`
+
+// catalogFetcher serves catalogXML and counts the fetches.
+type catalogFetcher struct {
+	fetches atomic.Int32
+}
+
+func (f *catalogFetcher) Fetch() ([]byte, error) {
+	f.fetches.Add(1)
+	return []byte(catalogXML), nil
+}
+
+func TestListPoliticians(t *testing.T) {
+	fetcher := &catalogFetcher{}
+	reader := data.NewCatalogReader[v1.PersonCatalog, v1.PersonListEntry](upstream.NewCachedFetcher(fetcher, time.Minute))
+	s := NewService(resources.NewCatalogueRepo[v1.PersonListEntry](&reader), nil, nil, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		name string
+		req  *pb.ListPoliticiansRequest
+		want []string
+	}{
+		{"all", &pb.ListPoliticiansRequest{}, []string{"1001", "1002", "1003"}},
+		{"faction", &pb.ListPoliticiansRequest{Faction: "spd"}, []string{"1001", "1003"}},
+		{"state", &pb.ListPoliticiansRequest{State: "Bayern"}, []string{"1002"}},
+		{"constituency", &pb.ListPoliticiansRequest{Constituency: "75"}, []string{"1001"}},
+		{"none", &pb.ListPoliticiansRequest{Faction: "SPD", State: "Bayern"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.ListPoliticians(context.Background(), connect.NewRequest(tt.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, p := range res.Msg.Politicians {
+				ids = append(ids, p.Id.Value)
+			}
+			if len(ids) != len(tt.want) {
+				t.Fatalf("ids = %v, want %v", ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Errorf("ids = %v, want %v", ids, tt.want)
+				}
+			}
+		})
+	}
+
+	if n := fetcher.fetches.Load(); n != 1 {
+		t.Errorf("catalog fetched %d times, want once", n)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := parseFormat(""); err != nil || f != "html" {
+		t.Errorf("parseFormat(\"\") = %q, %v", f, err)
+	}
+	if f, err := parseFormat("md"); err != nil || f != "markdown" {
+		t.Errorf("parseFormat(md) = %q, %v", f, err)
+	}
+	if _, err := parseFormat("latex"); connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Errorf("parseFormat(latex) = %v, want invalid argument", err)
+	}
+}
@@ -0,0 +1,128 @@
+package timeline
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/richtext"
+)
+
+const (
+	SourceBiography   = "biography"
+	SourceBio         = "bio"
+	SourceMemberships = "memberships"
+)
+
+const (
+	months = `(?:Januar|Jänner|Februar|März|April|Mai|Juni|Juli|August|September|Oktober|November|Dezember)`
+	year   = `(?:19|20)\d{2}`
+	date   = `(?:(?:\d{1,2}\.\s*)?` + months + `\s+|\d{1,2}\.\d{1,2}\.|\d{1,2}/)?`
+)
+
+var (
+	// a station starts with a year expression at the beginning of the text,
+	// a sentence or a semicolon separated part
+	stationStartRe = regexp.MustCompile(`(?:^|\D[.;]\s+|\n\s*)((?:(?i:seit|ab|von|bis)\s+)?` + date + year + `\b)`)
+	periodRe       = regexp.MustCompile(`^(?:((?i:seit|ab|von|bis))\s+)?` + date + `(` + year + `)(?:\s*(?:bis|-|–|—|/)\s*` + date + `(` + year + `|(?i:heute)))?\s*[:,]?\s*`)
+	trailingRe     = regexp.MustCompile(`[\s.;,:]+$`)
+)
+
+// keywords per kind in order of precedence
+var kinds = []struct {
+	kind v1.StationKind
+	re   *regexp.Regexp
+}{
+	{v1.StationMandate, regexp.MustCompile(`(?i)(bundestag|landtag|abgeordnetenhaus|bürgerschaft|stadtrat|stadtverordnet|gemeinderat|kreistag|bezirksverordnet|bezirkstag|europäischen parlament|europaparlament|rat der stadt|mandat)`)},
+	{v1.StationParty, regexp.MustCompile(`(?i)(mitglied der (spd|cdu|csu|fdp|afd|grünen|linken|partei|bsw)|bündnis 90|ortsverein|ortsverband|kreisverband|stadtverband|bezirksverband|landesverband|parteivorstand|bundesvorstand|jusos|junge union|junge liberale|grüne jugend|generalsekretär|schatzmeister)`)},
+	{v1.StationEducation, regexp.MustCompile(`(?i)(abitur|studium|studierte|ausbildung|promotion|promoviert|diplom|staatsexamen|referendariat|volontariat|schüler|bachelor|master|lehre als|fachhochschulreife|mittlere reife|habilitation)`)},
+	{v1.StationProfession, regexp.MustCompile(`(?i)(anwält|anwalt|lehrer|referent|mitarbeiter|tätig|geschäftsführ|leiter|angestellt|beamt|richter|ärzt|arzt|ingenieur|selbstständig|selbständig|unternehmer|journalist|berater|sachbearbeiter|manager|inhaber|professor|dozent|soldat|polizei|bürgermeister|landrat|staatssekretär|minister|beruf|kaufm|kauffrau|wissenschaftlich)`)},
+}
+
+// institutionRe names places of education. Only stations without a kind of
+// their own are education for it, in "Lehrer an der Grundschule" the school is
+// just where the job is.
+var institutionRe = regexp.MustCompile(`(?i)(schule|gymnasium|universität|hochschule|akademie)`)
+
+// ParseBiography extracts dated stations from the narrative biography.
+func ParseBiography(biographicInfo string) []v1.CareerStation {
+	text := richtext.Render(biographicInfo, richtext.FormatText, nil)
+
+	matches := stationStartRe.FindAllStringSubmatchIndex(text, -1)
+	stations := make([]v1.CareerStation, 0, len(matches))
+	for i, m := range matches {
+		end := len(text)
+		if i+1 < len(matches) {
+			end = matches[i+1][2]
+		}
+		if s, ok := parseStation(text[m[2]:end]); ok {
+			stations = append(stations, s)
+		}
+	}
+
+	return stations
+}
+
+func parseStation(segment string) (v1.CareerStation, bool) {
+	m := periodRe.FindStringSubmatchIndex(segment)
+	if m == nil {
+		return v1.CareerStation{}, false
+	}
+
+	title := strings.Join(strings.Fields(trailingRe.ReplaceAllString(segment[m[1]:], "")), " ")
+	if title == "" {
+		return v1.CareerStation{}, false
+	}
+
+	s := v1.CareerStation{Title: title, Source: SourceBiography}
+
+	prefix := ""
+	if m[2] >= 0 {
+		prefix = strings.ToLower(segment[m[2]:m[3]])
+	}
+	first, _ := strconv.Atoi(segment[m[4]:m[5]])
+
+	switch {
+	case m[6] >= 0:
+		s.StartYear = first
+		if until := strings.ToLower(segment[m[6]:m[7]]); until == "heute" {
+			s.Ongoing = true
+		} else {
+			s.EndYear, _ = strconv.Atoi(until)
+		}
+	case prefix == "seit" || prefix == "ab":
+		s.StartYear, s.Ongoing = first, true
+	case prefix == "bis":
+		// the start is unknown
+		s.EndYear, s.Uncertain = first, true
+	default:
+		s.StartYear, s.EndYear = first, first
+	}
+
+	if s.EndYear != 0 && s.StartYear > s.EndYear {
+		s.Uncertain = true
+	}
+
+	s.Kind = v1.StationOther
+	matched := 0
+	for _, k := range kinds {
+		if k.re.MatchString(title) {
+			if matched == 0 {
+				s.Kind = k.kind
+			}
+			matched++
+		}
+	}
+	if matched == 0 && institutionRe.MatchString(title) {
+		s.Kind = v1.StationEducation
+		matched++
+	}
+	// nothing or more than one kind matched, or the sentence goes on after
+	// the station
+	if matched != 1 || strings.Count(title, ". ") > 0 {
+		s.Uncertain = true
+	}
+
+	return s, true
+}
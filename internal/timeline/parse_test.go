@@ -0,0 +1,94 @@
+package timeline
+
+import (
+	"slices"
+	"testing"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+)
+
+// station is a parsed station without its source, which is always the
+// biography.
+func station(kind v1.StationKind, title string, start, end int, ongoing, uncertain bool) v1.CareerStation {
+	return v1.CareerStation{Kind: kind, Title: title, StartYear: start, EndYear: end, Ongoing: ongoing, Source: SourceBiography, Uncertain: uncertain}
+}
+
+func TestParseBiography(t *testing.T) {
+	tests := []struct {
+		name string
+		bio  string
+		want []v1.CareerStation
+	}{
+		{
+			name: "sentences",
+			bio: `<p>Geboren am 12. Mai 1975 in Berlin; evangelisch; verheiratet.</p>` +
+				`<p>1994 Abitur am Gymnasium Steglitz. 1994 bis 2000 Studium der Rechtswissenschaften an der <a href="/uni">Humboldt-Universität</a> zu Berlin. ` +
+				`2000 bis 2002 Referendariat. Seit 2002 Rechtsanwältin in Berlin.</p>` +
+				`<p>Seit 1995 Mitglied der SPD. 2005 bis 2013 Vorsitzende des Ortsvereins Mitte. 2011 bis 2017 Mitglied des Abgeordnetenhauses von Berlin. ` +
+				`Seit 2017 Mitglied des Deutschen Bundestages.</p>`,
+			want: []v1.CareerStation{
+				station(v1.StationEducation, "Abitur am Gymnasium Steglitz", 1994, 1994, false, false),
+				station(v1.StationEducation, "Studium der Rechtswissenschaften an der Humboldt-Universität zu Berlin", 1994, 2000, false, false),
+				station(v1.StationEducation, "Referendariat", 2000, 2002, false, false),
+				station(v1.StationProfession, "Rechtsanwältin in Berlin", 2002, 0, true, false),
+				station(v1.StationParty, "Mitglied der SPD", 1995, 0, true, false),
+				station(v1.StationParty, "Vorsitzende des Ortsvereins Mitte", 2005, 2013, false, false),
+				station(v1.StationMandate, "Mitglied des Abgeordnetenhauses von Berlin", 2011, 2017, false, false),
+				station(v1.StationMandate, "Mitglied des Deutschen Bundestages", 2017, 0, true, false),
+			},
+		},
+		{
+			name: "semicolons, months and dashes",
+			bio:  `<p>1982–1985 Ausbildung zum Industriekaufmann; März 1990 bis Juni 2001 Geschäftsführer der Müller GmbH; ab 2003 Mitglied im Kreistag Ludwigsburg.</p>`,
+			want: []v1.CareerStation{
+				// Ausbildung and Kaufmann name two kinds
+				station(v1.StationEducation, "Ausbildung zum Industriekaufmann", 1982, 1985, false, true),
+				station(v1.StationProfession, "Geschäftsführer der Müller GmbH", 1990, 2001, false, false),
+				station(v1.StationMandate, "Mitglied im Kreistag Ludwigsburg", 2003, 0, true, false),
+			},
+		},
+		{
+			name: "dates and line breaks",
+			bio:  `<p>Bis 1998 Lehrer an der Grundschule Nord.<br>01.09.1999 - heute: Mitglied der CDU</p>`,
+			want: []v1.CareerStation{
+				station(v1.StationProfession, "Lehrer an der Grundschule Nord", 0, 1998, false, true),
+				station(v1.StationParty, "Mitglied der CDU", 1999, 0, true, false),
+			},
+		},
+		{
+			name: "institutions",
+			bio:  `<p>1985 bis 1991 Besuch der Realschule Kiel. 1991 bis 1994 Schüler am Gymnasium Eckernförde. Seit 2004 Leiter der Volkshochschule Kiel.</p>`,
+			want: []v1.CareerStation{
+				station(v1.StationEducation, "Besuch der Realschule Kiel", 1985, 1991, false, false),
+				station(v1.StationEducation, "Schüler am Gymnasium Eckernförde", 1991, 1994, false, false),
+				// the school is where the job is
+				station(v1.StationProfession, "Leiter der Volkshochschule Kiel", 2004, 0, true, false),
+			},
+		},
+		{
+			name: "uncertain",
+			bio:  `<p>2009 bis 2005 Referent im Ministerium. 2010 Wanderung durch die Alpen. 2013 Wahl in den Bundestag. Seitdem Mitglied im Haushaltsausschuss.</p>`,
+			want: []v1.CareerStation{
+				// the period is reversed
+				station(v1.StationProfession, "Referent im Ministerium", 2009, 2005, false, true),
+				// no kind
+				station(v1.StationOther, "Wanderung durch die Alpen", 2010, 2010, false, true),
+				// the sentence after it isn't dated
+				station(v1.StationMandate, "Wahl in den Bundestag. Seitdem Mitglied im Haushaltsausschuss", 2013, 2013, false, true),
+			},
+		},
+		{
+			name: "without years",
+			bio:  `<p>Verheiratet, zwei Kinder.</p>`,
+			want: []v1.CareerStation{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseBiography(tt.bio)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseBiography =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package timeline
+
+import (
+	"cmp"
+	"math"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+var (
+	bundestagRe = regexp.MustCompile(`(?i)bundestag`)
+	exitYearRe  = regexp.MustCompile(`(?:19|20)\d{2}`)
+)
+
+// Build merges the stations parsed from the biography with the current
+// mandate and committee memberships of the bio.
+func Build(p *v1.Politician) v1.CareerTimeline {
+	bio := p.Bio
+	stations := ParseBiography(bio.BiographicInfo)
+
+	exitYear, _ := strconv.Atoi(exitYearRe.FindString(bio.ExitDate))
+
+	i := slices.IndexFunc(stations, func(s v1.CareerStation) bool {
+		return s.Kind == v1.StationMandate && bundestagRe.MatchString(s.Title) && (s.Ongoing || s.EndYear == 0)
+	})
+	if i >= 0 {
+		if exitYear != 0 {
+			stations[i].EndYear, stations[i].Ongoing = exitYear, false
+		}
+	} else {
+		title := "Mitglied des Deutschen Bundestages"
+		if bio.Elected != "" {
+			title += ", " + bio.Elected
+		}
+		if bio.Constituency.Number != "" {
+			title += " (Wahlkreis " + strings.TrimSpace(bio.Constituency.Number+" "+bio.Constituency.Name) + ")"
+		}
+		stations = append(stations, v1.CareerStation{
+			Kind:    v1.StationMandate,
+			Title:   title,
+			EndYear: exitYear,
+			Ongoing: exitYear == 0,
+			Source:  SourceBio,
+		})
+	}
+
+	memberships := []struct {
+		role       string
+		committees []v1.Committee
+	}{
+		{"Obleute", bio.Memberships.LeadCommittees},
+		{"Ordentliches Mitglied", bio.Memberships.RegularMemberCommittees},
+		{"Stellvertretendes Mitglied", bio.Memberships.SubstituteMemberCommittees},
+		{"Stellvertretender Vorsitz", bio.Memberships.ViceChairOtherCommittees},
+	}
+	for _, m := range memberships {
+		for _, c := range m.committees {
+			stations = append(stations, v1.CareerStation{
+				Kind:    v1.StationCommittee,
+				Title:   m.role + ", " + c.Name,
+				EndYear: exitYear,
+				Ongoing: exitYear == 0,
+				Source:  SourceMemberships,
+			})
+		}
+	}
+
+	slices.SortStableFunc(stations, func(a, b v1.CareerStation) int {
+		return cmp.Or(cmp.Compare(sortYear(a), sortYear(b)), cmp.Compare(sources[a.Source], sources[b.Source]))
+	})
+
+	return v1.CareerTimeline{PoliticianID: bio.Id.Value, Stations: stations}
+}
+
+// sources orders stations of the same year: the biography first in the
+// order of the text, then the mandate of the bio and the committees in the
+// order of the memberships.
+var sources = map[string]int{SourceBiography: 0, SourceBio: 1, SourceMemberships: 2}
+
+// sortYear orders undated stations, i.e. the current mandate and
+// memberships, last.
+func sortYear(s v1.CareerStation) int {
+	switch {
+	case s.StartYear != 0:
+		return s.StartYear
+	case s.EndYear != 0 && !s.Ongoing:
+		return s.EndYear
+	}
+	return math.MaxInt32
+}
+
+type Handler struct {
+	bios resources.Repository[v1.Politician]
+}
+
+func NewHandler(bios resources.Repository[v1.Politician]) *Handler {
+	return &Handler{bios: bios}
+}
+
+func (h *Handler) Get(w http.ResponseWriter, req *http.Request) {
+	politician, err := h.bios.Get(req.Context(), req.PathValue("id"))
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err := rest.MarshalResponse(w, http.StatusOK, Build(politician)); err != nil {
+		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,90 @@
+package timeline
+
+import (
+	"testing"
+
+	v1 "github.com/kyzrfranz/bundestag-api/api/v1"
+)
+
+type brief struct {
+	kind      v1.StationKind
+	title     string
+	startYear int
+	endYear   int
+	ongoing   bool
+	source    string
+}
+
+func TestBuild(t *testing.T) {
+	memberships := v1.Memberships{
+		RegularMemberCommittees:    []v1.Committee{{Name: "Haushaltsausschuss"}},
+		SubstituteMemberCommittees: []v1.Committee{{Name: "Rechtsausschuss"}},
+		LeadCommittees:             []v1.Committee{{Name: "Innenausschuss"}},
+	}
+	tests := []struct {
+		name string
+		bio  v1.PoliticianBio
+		want []brief
+	}{
+		{
+			name: "without the Bundestag in the biography",
+			bio: v1.PoliticianBio{
+				BiographicInfo: `<p>2021 Abitur. Seit 2022 Mitglied der SPD.</p>`,
+				Elected:        "Direkt gewählt",
+				Constituency:   v1.Constituency{Number: "75", Name: "Berlin-Mitte"},
+				Memberships:    memberships,
+			},
+			want: []brief{
+				{v1.StationEducation, "Abitur", 2021, 2021, false, SourceBiography},
+				{v1.StationParty, "Mitglied der SPD", 2022, 0, true, SourceBiography},
+				{v1.StationMandate, "Mitglied des Deutschen Bundestages, Direkt gewählt (Wahlkreis 75 Berlin-Mitte)", 0, 0, true, SourceBio},
+				{v1.StationCommittee, "Obleute, Innenausschuss", 0, 0, true, SourceMemberships},
+				{v1.StationCommittee, "Ordentliches Mitglied, Haushaltsausschuss", 0, 0, true, SourceMemberships},
+				{v1.StationCommittee, "Stellvertretendes Mitglied, Rechtsausschuss", 0, 0, true, SourceMemberships},
+			},
+		},
+		{
+			// the mandate ends in the year of the last station of the
+			// biography and follows it
+			name: "left without the Bundestag in the biography",
+			bio: v1.PoliticianBio{
+				BiographicInfo: `<p>2025 Ehrenamtlich tätig.</p>`,
+				ExitDate:       "31.03.2025",
+				Memberships:    v1.Memberships{RegularMemberCommittees: []v1.Committee{{Name: "Haushaltsausschuss"}}},
+			},
+			want: []brief{
+				{v1.StationProfession, "Ehrenamtlich tätig", 2025, 2025, false, SourceBiography},
+				{v1.StationMandate, "Mitglied des Deutschen Bundestages", 0, 2025, false, SourceBio},
+				{v1.StationCommittee, "Ordentliches Mitglied, Haushaltsausschuss", 0, 2025, false, SourceMemberships},
+			},
+		},
+		{
+			name: "left with the Bundestag in the biography",
+			bio: v1.PoliticianBio{
+				BiographicInfo: `<p>Seit 2017 Mitglied des Deutschen Bundestages. 2019 Wahl zur Vorsitzenden des Kreisverbands.</p>`,
+				ExitDate:       "31.03.2025",
+			},
+			want: []brief{
+				{v1.StationMandate, "Mitglied des Deutschen Bundestages", 2017, 2025, false, SourceBiography},
+				{v1.StationParty, "Wahl zur Vorsitzenden des Kreisverbands", 2019, 2019, false, SourceBiography},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.bio.Id = v1.ID{Value: "1001"}
+			got := Build(&v1.Politician{Bio: tt.bio})
+			if got.PoliticianID != "1001" {
+				t.Errorf("PoliticianID = %q", got.PoliticianID)
+			}
+			if len(got.Stations) != len(tt.want) {
+				t.Fatalf("stations = %+v, want %+v", got.Stations, tt.want)
+			}
+			for i, s := range got.Stations {
+				if b := (brief{s.Kind, s.Title, s.StartYear, s.EndYear, s.Ongoing, s.Source}); b != tt.want[i] {
+					t.Errorf("station %d = %+v, want %+v", i, b, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package upstream
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Fetcher reads a document from upstream.
+type Fetcher interface {
+	Fetch() ([]byte, error)
+}
+
+// CachedFetcher keeps the last document of fetcher for ttl, so the catalogs
+// listed by every request are read from upstream once per ttl. Requests
+// arriving while the document is fetched wait for it. If upstream fails,
+// the last document is served until it succeeds again.
+type CachedFetcher struct {
+	fetcher Fetcher
+	ttl     time.Duration
+
+	mu      sync.Mutex
+	data    []byte
+	fetched time.Time
+}
+
+func NewCachedFetcher(fetcher Fetcher, ttl time.Duration) *CachedFetcher {
+	return &CachedFetcher{fetcher: fetcher, ttl: ttl}
+}
+
+func (c *CachedFetcher) Fetch() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.data != nil && time.Since(c.fetched) < c.ttl {
+		return c.data, nil
+	}
+
+	data, err := c.fetcher.Fetch()
+	if err != nil {
+		if c.data != nil {
+			fmt.Printf("failed to fetch catalog, serving the cached one: %v\n", err)
+			return c.data, nil
+		}
+		return nil, err
+	}
+	c.data, c.fetched = data, time.Now()
+	return data, nil
+}
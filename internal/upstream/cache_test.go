@@ -0,0 +1,73 @@
+package upstream
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// counter returns the number of the fetch, or err.
+type counter struct {
+	fetches atomic.Int32
+	err     error
+	delay   time.Duration
+}
+
+func (c *counter) Fetch() ([]byte, error) {
+	n := c.fetches.Add(1)
+	time.Sleep(c.delay)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return []byte{byte(n)}, nil
+}
+
+func TestCachedFetcher(t *testing.T) {
+	upstream := &counter{}
+	cached := NewCachedFetcher(upstream, 50*time.Millisecond)
+
+	for range 3 {
+		if data, err := cached.Fetch(); err != nil || data[0] != 1 {
+			t.Fatalf("Fetch = %v, %v, want the first document", data, err)
+		}
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if data, _ := cached.Fetch(); data[0] != 2 {
+		t.Errorf("Fetch after the ttl = %v, want the second document", data)
+	}
+
+	// upstream failing, the last document is served
+	time.Sleep(60 * time.Millisecond)
+	upstream.err = errors.New("unavailable")
+	if data, err := cached.Fetch(); err != nil || data[0] != 2 {
+		t.Errorf("Fetch with upstream failing = %v, %v, want the second document", data, err)
+	}
+}
+
+func TestCachedFetcherFails(t *testing.T) {
+	cached := NewCachedFetcher(&counter{err: errors.New("unavailable")}, time.Minute)
+	if _, err := cached.Fetch(); err == nil {
+		t.Error("Fetch without a document succeeded")
+	}
+}
+
+func TestCachedFetcherConcurrent(t *testing.T) {
+	upstream := &counter{delay: 20 * time.Millisecond}
+	cached := NewCachedFetcher(upstream, time.Minute)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			if _, err := cached.Fetch(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+	if n := upstream.fetches.Load(); n != 1 {
+		t.Errorf("fetched %d times, want once", n)
+	}
+}
@@ -0,0 +1,220 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kyzrfranz/bundestag-api/internal/events"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	maxAttempts    = 6
+	initialBackoff = 10 * time.Second
+	maxBackoff     = 30 * time.Minute
+	// queueSize is how many events may wait for delivery to a subscription
+	queueSize = 64
+)
+
+// Delivery is a single attempt to send an event to a subscription.
+type Delivery struct {
+	ID             string        `json:"id"`
+	SubscriptionID string        `json:"subscriptionId"`
+	EventID        events.ID     `json:"eventId"`
+	EventType      events.Type   `json:"eventType"`
+	Attempt        int           `json:"attempt"`
+	Time           time.Time     `json:"time"`
+	Duration       time.Duration `json:"duration"`
+	StatusCode     int           `json:"statusCode,omitempty"`
+	Error          string        `json:"error,omitempty"`
+	Success        bool          `json:"success"`
+}
+
+// Dispatcher delivers events to each subscription in order, one at a time.
+// Events that don't fit into the queue of a subscription are dropped, so a
+// slow receiver holds up neither the others nor the bus.
+type Dispatcher struct {
+	store  *Store
+	client *http.Client
+	logger *slog.Logger
+
+	// queues by subscription ID, only used by Run
+	queues map[string]chan job
+}
+
+type job struct {
+	sub Subscription
+	e   events.Event
+}
+
+func NewDispatcher(store *Store, logger *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		store:  store,
+		client: &http.Client{Timeout: 10 * time.Second},
+		logger: logger,
+		queues: map[string]chan job{},
+	}
+}
+
+// Run delivers every event received on ch to the matching subscriptions
+// until ch is closed or ctx is done.
+func (d *Dispatcher) Run(ctx context.Context, ch <-chan events.Event) {
+	defer func() {
+		for id, q := range d.queues {
+			close(q)
+			delete(d.queues, id)
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-ch:
+			if !ok {
+				return
+			}
+			d.dispatch(ctx, e)
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, e events.Event) {
+	subs := d.store.List()
+	current := make(map[string]bool, len(subs))
+	for _, sub := range subs {
+		current[sub.ID] = true
+		if !sub.Matches(e) {
+			continue
+		}
+		q, ok := d.queues[sub.ID]
+		if !ok {
+			q = make(chan job, queueSize)
+			d.queues[sub.ID] = q
+			go d.work(ctx, q)
+		}
+		select {
+		case q <- job{sub, e}:
+		default:
+			d.logger.Warn("webhook queue full, dropping event", "subscription", sub.ID, "event", e.ID)
+		}
+	}
+
+	// the workers of deleted subscriptions stop, see work
+	for id, q := range d.queues {
+		if !current[id] {
+			close(q)
+			delete(d.queues, id)
+		}
+	}
+}
+
+// work delivers the jobs of a subscription until its queue is closed. What
+// is still queued for a deleted subscription is skipped.
+func (d *Dispatcher) work(ctx context.Context, q <-chan job) {
+	for j := range q {
+		if ctx.Err() != nil {
+			return
+		}
+		sub, ok := d.store.Get(j.sub.ID)
+		if !ok {
+			continue
+		}
+		d.deliver(ctx, sub, j.e)
+	}
+}
+
+// deliver sends e to sub, retrying with exponential backoff until the
+// receiver answers with a 2xx status or maxAttempts is reached.
+func (d *Dispatcher) deliver(ctx context.Context, sub Subscription, e events.Event) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		d.logger.Error("failed to marshal webhook payload", "event", e.ID, "error", err)
+		return
+	}
+
+	deliveryID := fmt.Sprintf("%s-%s", sub.ID, e.ID)
+	backoff := initialBackoff
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		delivery := d.attempt(ctx, sub, e, body, deliveryID, attempt)
+		d.store.RecordDelivery(delivery)
+		if delivery.Success {
+			return
+		}
+
+		if attempt == maxAttempts {
+			d.logger.Warn("giving up on webhook delivery", "subscription", sub.ID, "event", e.ID, "error", delivery.Error)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, sub Subscription, e events.Event, body []byte, deliveryID string, attempt int) Delivery {
+	delivery := Delivery{
+		ID:             deliveryID,
+		SubscriptionID: sub.ID,
+		EventID:        e.ID,
+		EventType:      e.Type,
+		Attempt:        attempt,
+		Time:           time.Now().UTC(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	timestamp := strconv.FormatInt(delivery.Time.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(e.Type))
+	req.Header.Set(HeaderDelivery, deliveryID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(sub.Secret, timestamp, body))
+
+	res, err := d.client.Do(req)
+	delivery.Duration = time.Since(delivery.Time)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer res.Body.Close()
+
+	delivery.StatusCode = res.StatusCode
+	delivery.Success = res.StatusCode >= 200 && res.StatusCode < 300
+	if !delivery.Success {
+		delivery.Error = res.Status
+	}
+
+	return delivery
+}
+
+// Sign computes the hex encoded HMAC-SHA256 of "<timestamp>.<body>". Receivers
+// recompute it with their secret to verify the X-Webhook-Signature header.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
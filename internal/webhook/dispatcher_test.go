@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyzrfranz/bundestag-api/internal/events"
+	"github.com/kyzrfranz/bundestag-api/pkg/resources"
+)
+
+// receiver records the IDs of the events it gets, holding every request
+// until release is closed.
+type receiver struct {
+	release     chan struct{}
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+
+	mu  sync.Mutex
+	ids []events.ID
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	n := r.inFlight.Add(1)
+	defer r.inFlight.Add(-1)
+	for {
+		m := r.maxInFlight.Load()
+		if n <= m || r.maxInFlight.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	<-r.release
+
+	var e events.Event
+	if err := json.NewDecoder(req.Body).Decode(&e); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.mu.Lock()
+	r.ids = append(r.ids, e.ID)
+	r.mu.Unlock()
+}
+
+func (r *receiver) received() []events.ID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.ids)
+}
+
+func waitFor(t *testing.T, what string, done func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDispatcherQueues(t *testing.T) {
+	slow := &receiver{release: make(chan struct{})}
+	fast := &receiver{release: make(chan struct{})}
+	close(fast.release)
+	slowSrv, fastSrv := httptest.NewServer(slow), httptest.NewServer(fast)
+	defer slowSrv.Close()
+	defer fastSrv.Close()
+
+	store, err := NewStore(resources.NewFileCache(filepath.Join(t.TempDir(), "webhooks.json")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Add(Subscription{ID: "slow", URL: slowSrv.URL})
+	store.Add(Subscription{ID: "fast", URL: fastSrv.URL, Events: []string{string(events.CommitteeAdded)}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := NewDispatcher(store, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	const published = queueSize + 6
+	for id := events.ID(1); id <= published; id++ {
+		d.dispatch(ctx, events.Event{ID: id, Type: events.PoliticianUpdated})
+	}
+	d.dispatch(ctx, events.Event{ID: published + 1, Type: events.CommitteeAdded})
+
+	// a slow receiver doesn't hold up the others
+	waitFor(t, "the fast receiver", func() bool { return len(fast.received()) == 1 })
+
+	close(slow.release)
+	waitFor(t, "the slow receiver", func() bool { return len(store.Deliveries("slow")) >= queueSize })
+	time.Sleep(20 * time.Millisecond)
+
+	// one at a time and in order, what didn't fit into the queue is dropped
+	ids := slow.received()
+	if len(ids) < queueSize || len(ids) > queueSize+1 || ids[0] != 1 || !slices.IsSorted(ids) {
+		t.Errorf("slow receiver got %v", ids)
+	}
+	if slow.maxInFlight.Load() != 1 {
+		t.Errorf("%d deliveries to the slow receiver at once", slow.maxInFlight.Load())
+	}
+
+	// the worker of a deleted subscription stops
+	store.Delete("fast")
+	d.dispatch(ctx, events.Event{ID: published + 2, Type: events.CommitteeAdded})
+	if _, ok := d.queues["fast"]; ok || len(d.queues) != 1 {
+		t.Errorf("queues = %v", d.queues)
+	}
+}
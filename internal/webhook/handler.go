@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kyzrfranz/bundestag-api/internal/rest"
+	"github.com/samber/lo"
+)
+
+// Handler manages subscriptions over HTTP. All endpoints require the
+// configured token as bearer authorization.
+type Handler struct {
+	store *Store
+	token string
+}
+
+func NewHandler(store *Store, token string) *Handler {
+	return &Handler{store: store, token: token}
+}
+
+func (h *Handler) Create(w http.ResponseWriter, req *http.Request) {
+	if !h.authorized(w, req) {
+		return
+	}
+
+	var sub Subscription
+	if err := json.NewDecoder(req.Body).Decode(&sub); err != nil {
+		http.Error(w, "Invalid subscription", http.StatusBadRequest)
+		return
+	}
+
+	target, err := url.Parse(sub.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		http.Error(w, "Invalid subscription url", http.StatusBadRequest)
+		return
+	}
+
+	sub.ID = randomHex(8)
+	if sub.Secret == "" {
+		sub.Secret = randomHex(32)
+	}
+	sub.CreatedAt = time.Now().UTC()
+
+	if err := h.store.Add(sub); err != nil {
+		http.Error(w, "Failed to store subscription", http.StatusInternalServerError)
+		return
+	}
+
+	// the secret is only ever returned on creation
+	if err := rest.MarshalResponse(w, http.StatusOK, sub); err != nil {
+		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) List(w http.ResponseWriter, req *http.Request) {
+	if !h.authorized(w, req) {
+		return
+	}
+
+	subs := lo.Map(h.store.List(), func(s Subscription, _ int) Subscription {
+		return s.redacted()
+	})
+
+	if err := rest.MarshalResponse(w, http.StatusOK, subs); err != nil {
+		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) Get(w http.ResponseWriter, req *http.Request) {
+	if !h.authorized(w, req) {
+		return
+	}
+
+	sub, ok := h.store.Get(req.PathValue("id"))
+	if !ok {
+		http.Error(w, "Subscription not found", http.StatusNotFound)
+		return
+	}
+
+	if err := rest.MarshalResponse(w, http.StatusOK, sub.redacted()); err != nil {
+		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, req *http.Request) {
+	if !h.authorized(w, req) {
+		return
+	}
+
+	found, err := h.store.Delete(req.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Failed to delete subscription", http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		http.Error(w, "Subscription not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) Deliveries(w http.ResponseWriter, req *http.Request) {
+	if !h.authorized(w, req) {
+		return
+	}
+
+	id := req.PathValue("id")
+	if _, ok := h.store.Get(id); !ok {
+		http.Error(w, "Subscription not found", http.StatusNotFound)
+		return
+	}
+
+	if err := rest.MarshalResponse(w, http.StatusOK, h.store.Deliveries(id)); err != nil {
+		http.Error(w, "Failed to marshal response", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *Handler) authorized(w http.ResponseWriter, req *http.Request) bool {
+	expected := "Bearer " + h.token
+	if subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), []byte(expected)) != 1 {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	myhttp "github.com/kyzrfranz/bundestag-api/internal/http"
+	"github.com/samber/lo"
+)
+
+const deliveryLogSize = 100
+
+// Store keeps the registered subscriptions, persisted through an RWCache, and
+// an in-memory log of the most recent deliveries per subscription.
+type Store struct {
+	mu            sync.RWMutex
+	cache         myhttp.RWCache
+	subscriptions map[string]Subscription
+	deliveries    map[string][]Delivery
+}
+
+func NewStore(cache myhttp.RWCache) (*Store, error) {
+	s := &Store{
+		cache:         cache,
+		subscriptions: map[string]Subscription{},
+		deliveries:    map[string][]Delivery{},
+	}
+
+	data, err := cache.Read()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return s, nil
+	}
+
+	var subs []Subscription
+	if err := json.Unmarshal(data, &subs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webhook subscriptions: %w", err)
+	}
+	for _, sub := range subs {
+		s.subscriptions[sub.ID] = sub
+	}
+
+	return s, nil
+}
+
+func (s *Store) Add(sub Subscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subscriptions[sub.ID] = sub
+	return s.persist()
+}
+
+func (s *Store) Get(id string) (Subscription, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sub, ok := s.subscriptions[id]
+	return sub, ok
+}
+
+func (s *Store) List() []Subscription {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return lo.Values(s.subscriptions)
+}
+
+func (s *Store) Delete(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscriptions[id]; !ok {
+		return false, nil
+	}
+	delete(s.subscriptions, id)
+	delete(s.deliveries, id)
+	return true, s.persist()
+}
+
+func (s *Store) RecordDelivery(d Delivery) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscriptions[d.SubscriptionID]; !ok {
+		return
+	}
+
+	log := append(s.deliveries[d.SubscriptionID], d)
+	if len(log) > deliveryLogSize {
+		log = log[len(log)-deliveryLogSize:]
+	}
+	s.deliveries[d.SubscriptionID] = log
+}
+
+// Deliveries returns the delivery log of a subscription, newest first.
+func (s *Store) Deliveries(id string) []Delivery {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	log := slices.Clone(s.deliveries[id])
+	slices.Reverse(log)
+	return log
+}
+
+func (s *Store) persist() error {
+	data, err := json.Marshal(lo.Values(s.subscriptions))
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook subscriptions: %w", err)
+	}
+	return s.cache.Write(data)
+}
@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kyzrfranz/bundestag-api/internal/events"
+)
+
+// Subscription registers a target URL for change events. Empty filter lists
+// match everything, Events may contain wildcards such as "committee.*".
+type Subscription struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"secret,omitempty"`
+	Events      []string  `json:"events,omitempty"`
+	Politicians []string  `json:"politicians,omitempty"`
+	Factions    []string  `json:"factions,omitempty"`
+	Committees  []string  `json:"committees,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func (s Subscription) Matches(e events.Event) bool {
+	if len(s.Events) > 0 && !slices.ContainsFunc(s.Events, func(t string) bool { return matchType(t, e.Type) }) {
+		return false
+	}
+	if len(s.Politicians) > 0 && !slices.Contains(s.Politicians, e.PoliticianID) {
+		return false
+	}
+	if len(s.Factions) > 0 && !slices.Contains(s.Factions, e.Faction) && !slices.Contains(s.Factions, e.PreviousFaction) {
+		return false
+	}
+	if len(s.Committees) > 0 && !slices.Contains(s.Committees, e.CommitteeID) {
+		return false
+	}
+	return true
+}
+
+// redacted returns a copy without the signing secret, used for listings.
+func (s Subscription) redacted() Subscription {
+	s.Secret = ""
+	return s
+}
+
+func matchType(pattern string, t events.Type) bool {
+	if pattern == "*" {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, ".*"); ok {
+		return strings.HasPrefix(string(t), prefix+".")
+	}
+	return pattern == string(t)
+}
@@ -3,7 +3,9 @@ package resources
 import (
 	"context"
 	"encoding/xml"
+	"fmt"
 	myhttp "github.com/kyzrfranz/bundestag-api/internal/http"
+	"github.com/samber/lo"
 	"net/url"
 )
 
@@ -16,19 +18,71 @@ type EntryGetter interface {
 	GetEntry(id string) (*Entry, error)
 }
 
+type EntriesGetter interface {
+	GetEntries() ([]Entry, error)
+}
+
+type DetailEntryGetter interface {
+	EntryGetter
+	EntriesGetter
+}
+
+// DetailRefresher bypasses the detail cache and replaces the cached document
+// with the current upstream version.
+type DetailRefresher[T any] interface {
+	Refresh(ctx context.Context, entry Entry) (*T, error)
+}
+
+// DetailBatcher reads the details of many entries with a single read of the
+// cache, e.g. to embed them in a list. Details are decoded one at a time by
+// the returned get, which fails for ids that weren't requested or couldn't be
+// fetched.
+type DetailBatcher[T any] interface {
+	Batch(ctx context.Context, ids []string) (get func(id string) (*T, error))
+}
+
 type detailRepo[T any] struct {
-	getter EntryGetter
+	getter DetailEntryGetter
 }
 
-func NewDetailRepo[T any](getter EntryGetter) Repository[T] {
+func NewDetailRepo[T any](getter DetailEntryGetter) DetailRepository[T] {
 	return detailRepo[T]{
 		getter: getter,
 	}
 }
 
+// List returns the details of all catalog entries. Missing details are
+// fetched once and cached, entries that fail are left out.
 func (p detailRepo[T]) List(ctx context.Context) []T {
-	//TODO implement me
-	panic("implement me")
+	entries, err := p.getter.GetEntries()
+	if err != nil {
+		fmt.Printf("failed to get catalogue: %v\n", err)
+		return nil
+	}
+
+	urls := lo.Map(entries, func(e Entry, _ int) *url.URL {
+		return e.GetDetailUrl()
+	})
+	data, err := myhttp.FetchCachedUrls(urls, NewFileCache("bio.json"))
+	if err != nil {
+		fmt.Printf("failed to get details: %v\n", err)
+	}
+
+	details := make([]T, 0, len(data))
+	for _, u := range urls {
+		raw, ok := data[u.String()]
+		if !ok {
+			continue
+		}
+		detail, err := unmarshalDetail[T](raw)
+		if err != nil {
+			fmt.Printf("failed to unmarshal details of %s: %v\n", u, err)
+			continue
+		}
+		details = append(details, *detail)
+	}
+
+	return details
 }
 
 func (p detailRepo[T]) Get(ctx context.Context, id string) (*T, error) {
@@ -42,9 +96,56 @@ func (p detailRepo[T]) Get(ctx context.Context, id string) (*T, error) {
 
 	dtg = *entry
 	data, err := myhttp.FetchCachedUrl(dtg.GetDetailUrl(), NewFileCache("bio.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalDetail[T](data)
+}
+
+func (p detailRepo[T]) Batch(ctx context.Context, ids []string) func(id string) (*T, error) {
+	entries, err := p.getter.GetEntries()
+	if err != nil {
+		return func(string) (*T, error) { return nil, err }
+	}
+
+	wanted := lo.SliceToMap(ids, func(id string) (string, bool) { return id, true })
+	urls := map[string]*url.URL{}
+	for _, e := range entries {
+		if wanted[e.GetId()] {
+			urls[e.GetId()] = e.GetDetailUrl()
+		}
+	}
+	data, err := myhttp.FetchCachedUrls(lo.Values(urls), NewFileCache("bio.json"))
+	if err != nil {
+		fmt.Printf("failed to get details: %v\n", err)
+	}
+
+	return func(id string) (*T, error) {
+		u, ok := urls[id]
+		if !ok {
+			return nil, fmt.Errorf("no catalog entry %s", id)
+		}
+		raw, ok := data[u.String()]
+		if !ok {
+			return nil, fmt.Errorf(myhttp.ErrResourceNotFound, u)
+		}
+		return unmarshalDetail[T](raw)
+	}
+}
+
+func (p detailRepo[T]) Refresh(ctx context.Context, entry Entry) (*T, error) {
+	data, err := myhttp.RefreshCachedUrl(entry.GetDetailUrl(), NewFileCache("bio.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalDetail[T](data)
+}
 
+func unmarshalDetail[T any](data []byte) (*T, error) {
 	var detailType T
-	if err = xml.Unmarshal(data, &detailType); err != nil {
+	if err := xml.Unmarshal(data, &detailType); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,84 @@
+package resources
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+)
+
+type detail struct {
+	XMLName xml.Name `xml:"detail"`
+	ID      string   `xml:"id"`
+}
+
+type entry struct {
+	id  string
+	url *url.URL
+}
+
+func (e entry) GetDetailUrl() *url.URL { return e.url }
+func (e entry) GetId() string          { return e.id }
+
+type entries []Entry
+
+func (e entries) GetEntries() ([]Entry, error) { return e, nil }
+
+func (e entries) GetEntry(id string) (*Entry, error) {
+	for i := range e {
+		if e[i].GetId() == id {
+			return &e[i], nil
+		}
+	}
+	return nil, fmt.Errorf("not found")
+}
+
+func TestDetailRepoBatch(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	var fetched atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched.Add(1)
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, "<detail><id>%s</id></detail>", r.URL.Path[1:])
+	}))
+	defer upstream.Close()
+
+	var catalog entries
+	for _, id := range []string{"1", "2", "3", "missing"} {
+		u, _ := url.Parse(upstream.URL + "/" + id)
+		catalog = append(catalog, entry{id: id, url: u})
+	}
+	repo := NewDetailRepo[detail](catalog)
+
+	get := repo.Batch(context.Background(), []string{"1", "2", "missing"})
+	if fetched.Load() != 3 {
+		t.Errorf("fetched %d details, want 3", fetched.Load())
+	}
+	for _, id := range []string{"1", "2"} {
+		if d, err := get(id); err != nil || d.ID != id {
+			t.Errorf("get(%s) = %v, %v", id, d, err)
+		}
+	}
+	for _, id := range []string{"3", "missing", "unknown"} {
+		if d, err := get(id); err == nil {
+			t.Errorf("get(%s) = %v, want an error", id, d)
+		}
+	}
+
+	// the fetched details are cached
+	get = repo.Batch(context.Background(), []string{"1", "2"})
+	if _, err := get("2"); err != nil || fetched.Load() != 3 {
+		t.Errorf("second batch fetched %d details, err %v", fetched.Load()-3, err)
+	}
+	if d, err := repo.Get(context.Background(), "1"); err != nil || d.ID != "1" || fetched.Load() != 3 {
+		t.Errorf("Get = %v, %v after %d fetches", d, err, fetched.Load())
+	}
+}
@@ -0,0 +1,52 @@
+package resources
+
+import (
+	"context"
+	"errors"
+)
+
+var errReadOnly = errors.New("repository is read-only")
+
+type mappedRepo[S any, T any] struct {
+	repo    Repository[S]
+	convert func(*S) *T
+}
+
+// NewMappedRepo serves the resources of repo converted by convert, e.g. into
+// the model of another API version. It is read-only.
+func NewMappedRepo[S any, T any](repo Repository[S], convert func(*S) *T) Repository[T] {
+	return mappedRepo[S, T]{repo: repo, convert: convert}
+}
+
+func (m mappedRepo[S, T]) List(ctx context.Context) []T {
+	list := m.repo.List(ctx)
+	result := make([]T, len(list))
+	for i := range list {
+		result[i] = *m.convert(&list[i])
+	}
+	return result
+}
+
+func (m mappedRepo[S, T]) Get(ctx context.Context, id string) (*T, error) {
+	res, err := m.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return m.convert(res), nil
+}
+
+func (m mappedRepo[S, T]) Delete(ctx context.Context, id string) error {
+	return errReadOnly
+}
+
+func (m mappedRepo[S, T]) Create(ctx context.Context, item *T) (*T, error) {
+	return nil, errReadOnly
+}
+
+func (m mappedRepo[S, T]) Update(ctx context.Context, oldItem *T, newItem *T) (*T, error) {
+	return nil, errReadOnly
+}
+
+func (m mappedRepo[S, T]) Name() string {
+	return m.repo.Name()
+}
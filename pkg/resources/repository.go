@@ -12,3 +12,9 @@ type Repository[T any] interface {
 	Update(ctx context.Context, oldItem *T, newItem *T) (*T, error)
 	Name() string
 }
+
+type DetailRepository[T any] interface {
+	Repository[T]
+	DetailRefresher[T]
+	DetailBatcher[T]
+}